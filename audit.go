@@ -0,0 +1,118 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const auditLogTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_log (
+    module VARCHAR(255) NOT NULL DEFAULT '',
+    migration_id VARCHAR(255) NOT NULL,
+    direction VARCHAR(16) NOT NULL,
+    batch INTEGER NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const auditLogInsertTemplate = `INSERT INTO %s_log (module, migration_id, direction, batch, actor, duration_ms, error, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+type AuditEntry struct {
+	MigrationID string
+	Direction   Direction
+	Batch       int
+	Actor       string
+	DurationMs  int64
+	Error       *string
+	CreatedAt   time.Time
+}
+
+func (r *Migrator) writeAuditLog(ctx context.Context, result *Result, runErr error) error {
+	if _, err := r.conn.Exec(ctx, r.query(auditLogTableTemplate)); err != nil {
+		return errors.Join(ErrFailedToWriteAuditLog, err)
+	}
+
+	for _, migration := range result.Migrations {
+		if err := r.insertAuditEntry(ctx, AuditEntry{
+			MigrationID: migration.ID,
+			Direction:   result.Direction,
+			Batch:       result.Batch,
+			Actor:       r.actor,
+			DurationMs:  migration.Duration.Milliseconds(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, failure := range result.Failures {
+		message := failure.Err.Error()
+		if err := r.insertAuditEntry(ctx, AuditEntry{
+			MigrationID: failure.ID,
+			Direction:   result.Direction,
+			Batch:       result.Batch,
+			Actor:       r.actor,
+			Error:       &message,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if runErr == nil || len(result.Failures) > 0 {
+		return nil
+	}
+
+	message := runErr.Error()
+	return r.insertAuditEntry(ctx, AuditEntry{
+		MigrationID: result.FailedID,
+		Direction:   result.Direction,
+		Batch:       result.Batch,
+		Actor:       r.actor,
+		DurationMs:  result.Duration.Milliseconds(),
+		Error:       &message,
+	})
+}
+
+func (r *Migrator) insertAuditEntry(ctx context.Context, entry AuditEntry) error {
+	_, err := r.conn.Exec(ctx, r.query(auditLogInsertTemplate),
+		r.module(), entry.MigrationID, string(entry.Direction), entry.Batch, entry.Actor, entry.DurationMs, entry.Error,
+		r.now())
+	if err != nil {
+		return errors.Join(ErrFailedToWriteAuditLog, err)
+	}
+	return nil
+}
+
+func (r *Migrator) AuditLog(ctx context.Context) ([]AuditEntry, error) {
+	if _, err := r.conn.Exec(ctx, r.query(auditLogTableTemplate)); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.Query(ctx, r.query(`SELECT migration_id, direction, batch, actor, duration_ms, error, created_at
+FROM %s_log WHERE module = ? ORDER BY created_at`), r.module())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var entries []AuditEntry
+	for rows.Next() {
+		var entry AuditEntry
+		var direction string
+		err := rows.Scan(&entry.MigrationID, &direction, &entry.Batch, &entry.Actor,
+			&entry.DurationMs, &entry.Error, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entry.Direction = Direction(direction)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,74 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_WithAuditLog(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithAuditLog("deploy-bot"))
+	migrator.Register(&mockMigration{
+		id:          "1",
+		description: "create users table",
+		upQueries:   []string{"CREATE TABLE users (id INTEGER PRIMARY KEY)"},
+		downQueries: []string{"DROP TABLE users"},
+	})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back migrations: %v", err)
+	}
+
+	migrator.Register(&mockMigration{
+		id:          "2",
+		description: "broken",
+		upQueries:   []string{"INVALID SQL STATEMENT"},
+	})
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	entries, err := migrator.AuditLog(context.Background())
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d", len(entries))
+	}
+
+	expected := []struct {
+		id        string
+		direction Direction
+		failed    bool
+	}{
+		{id: "1", direction: DirectionUp},
+		{id: "1", direction: DirectionDown},
+		{id: "2", direction: DirectionUp, failed: true},
+	}
+	for i, want := range expected {
+		entry := entries[i]
+		if entry.MigrationID != want.id || entry.Direction != want.direction {
+			t.Errorf("entry %d: expected %s %s, got %s %s", i, want.direction, want.id, entry.Direction, entry.MigrationID)
+		}
+		if entry.Actor != "deploy-bot" {
+			t.Errorf("entry %d: expected actor deploy-bot, got %s", i, entry.Actor)
+		}
+		if (entry.Error != nil) != want.failed {
+			t.Errorf("entry %d: unexpected error text %v", i, entry.Error)
+		}
+	}
+}
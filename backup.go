@@ -0,0 +1,90 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+type BackupHook func(ctx context.Context, plan *Plan) error
+
+type ConfirmFunc func(plan Plan) (bool, error)
+
+var destructivePrefixes = []string{"DROP TABLE ", "DROP SCHEMA ", "DROP DATABASE ", "TRUNCATE ", "DELETE FROM "}
+
+var nonDestructiveDrops = map[string]bool{
+	"CONSTRAINT": true,
+	"INDEX":      true,
+	"KEY":        true,
+	"PRIMARY":    true,
+	"FOREIGN":    true,
+	"CHECK":      true,
+	"DEFAULT":    true,
+}
+
+func isDestructive(statement string) bool {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(statement), " ")) + " "
+	for _, prefix := range destructivePrefixes {
+		if strings.HasPrefix(normalized, prefix) {
+			return true
+		}
+	}
+
+	if !strings.HasPrefix(normalized, "ALTER TABLE ") {
+		return false
+	}
+	if strings.Contains(normalized, " DROP COLUMN ") {
+		return true
+	}
+	fields := strings.Fields(normalized)
+	return len(fields) > 4 && fields[3] == "DROP" && !nonDestructiveDrops[fields[4]]
+}
+
+func (r *Migrator) guardDestructive(config *runConfig, build func() (*Plan, error)) error {
+	if r.confirm == nil {
+		return nil
+	}
+
+	plan, err := build()
+	if err != nil {
+		return err
+	}
+	if !plan.Destructive() && plan.Direction != DirectionDown {
+		return nil
+	}
+	return r.confirmPlan(config, plan)
+}
+
+func (r *Migrator) backupDestructive(ctx context.Context, build func() (*Plan, error)) error {
+	if r.backupHook == nil {
+		return nil
+	}
+
+	plan, err := build()
+	if err != nil {
+		return err
+	}
+	if !plan.Destructive() {
+		return nil
+	}
+	if err := r.backupHook(ctx, plan); err != nil {
+		return errors.Join(ErrBackupFailed, err)
+	}
+	return nil
+}
+
+func (r *Migrator) confirmPlan(config *runConfig, plan *Plan) error {
+	if config.confirmed {
+		return nil
+	}
+
+	confirmed, err := r.confirm(*plan)
+	if err != nil {
+		return errors.Join(ErrNotConfirmed, err)
+	}
+	if !confirmed {
+		return ErrNotConfirmed
+	}
+	config.confirmed = true
+	return nil
+}
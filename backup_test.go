@@ -0,0 +1,166 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestIsDestructive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"DROP TABLE users":                                  true,
+		"drop  table if exists users":                       true,
+		"TRUNCATE users":                                    true,
+		"DELETE FROM users WHERE id = 1":                    true,
+		"ALTER TABLE users DROP COLUMN email":               true,
+		"ALTER TABLE users DROP email":                      true,
+		"ALTER TABLE users DROP CONSTRAINT fk_users":        false,
+		"ALTER TABLE users ALTER COLUMN email DROP DEFAULT": false,
+		"DROP INDEX idx_users_email":                        false,
+		"CREATE TABLE users (id INTEGER)":                   false,
+		"UPDATE users SET email = NULL":                     false,
+	}
+	for statement, want := range tests {
+		if got := isDestructive(statement); got != want {
+			t.Errorf("isDestructive(%q) = %v, want %v", statement, got, want)
+		}
+	}
+}
+
+func TestMigrator_WithBackupHook(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var plans []*Plan
+	hookErr := errors.New("snapshot failed")
+	migrator := New(db, WithBackupHook(func(_ context.Context, plan *Plan) error {
+		plans = append(plans, plan)
+		if len(plans) == 1 {
+			return nil
+		}
+		return hookErr
+	}))
+	migrator.Register(CreateMigration("1", "create users").
+		CreateTable("users", "id INTEGER PRIMARY KEY").
+		Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if len(plans) != 0 {
+		t.Fatalf("expected hook to be skipped for non-destructive batch, got %d calls", len(plans))
+	}
+
+	migrator.Register(CreateMigration("2", "drop users").
+		RawUp("DROP TABLE users").
+		Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply destructive migration: %v", err)
+	}
+	if len(plans) != 1 || plans[0].Direction != DirectionUp || !plans[0].Migrations[0].Destructive {
+		t.Fatalf("expected hook to receive destructive up plan, got %+v", plans)
+	}
+
+	_, err = migrator.Down(2)
+	if !errors.Is(err, ErrBackupFailed) || !errors.Is(err, hookErr) {
+		t.Fatalf("expected ErrBackupFailed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1", "2")
+}
+
+func TestMigrator_WithConfirm(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var prompts []Plan
+	confirmed := false
+	migrator := New(db, WithConfirm(func(plan Plan) (bool, error) {
+		prompts = append(prompts, plan)
+		return confirmed, nil
+	}))
+	migrator.Register(&mockMigration{
+		id:          "1",
+		upQueries:   []string{"CREATE TABLE users (id INTEGER)"},
+		downQueries: []string{"ALTER TABLE users ADD COLUMN email TEXT"},
+	})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if len(prompts) != 0 {
+		t.Fatalf("expected no prompt for non-destructive batch, got %d", len(prompts))
+	}
+
+	if _, err := migrator.Down(1); !errors.Is(err, ErrNotConfirmed) {
+		t.Fatalf("expected ErrNotConfirmed, got %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].Direction != DirectionDown {
+		t.Fatalf("expected down run to be confirmed, got %+v", prompts)
+	}
+	assertAppliedIDs(t, migrator, "1")
+
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"TRUNCATE users"}})
+	if _, err := migrator.Up(); !errors.Is(err, ErrNotConfirmed) {
+		t.Fatalf("expected ErrNotConfirmed for truncate, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+
+	confirmed = true
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected confirmed rollback to succeed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+func TestMigrator_BackupHookAfterValidation(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	backups := 0
+	migrator := New(db, WithOutOfOrder(OutOfOrderError), WithBackupHook(func(context.Context, *Plan) error {
+		backups++
+		return nil
+	}))
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&mockMigration{id: "3", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}},
+	)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"DROP TABLE users"}})
+	if _, err := migrator.Up(); !errors.Is(err, ErrMigrationGap) {
+		t.Fatalf("expected ErrMigrationGap, got %v", err)
+	}
+	if backups != 0 {
+		t.Errorf("expected no backup for a run rejected by validation, got %d", backups)
+	}
+}
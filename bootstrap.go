@@ -0,0 +1,197 @@
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const schemaCacheSeparator = "\n-- migrator:statement\n"
+
+type SchemaDumpFunc func(ctx context.Context) ([]string, error)
+
+func (r *Migrator) Bootstrap(ctx context.Context, cacheDir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.run(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		applied, err := r.getAppliedMigrations(ctx)
+		if err != nil {
+			return errors.Join(ErrFailedToGetAppliedMigrations, err)
+		}
+		if len(applied) > 0 {
+			return r.up(ctx, runConfig{}, result)
+		}
+
+		key, err := r.schemaCacheKey()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(cacheDir, key+".sql")
+
+		script, err := os.ReadFile(path)
+		if err == nil {
+			return r.applySchemaCache(ctx, string(script), result)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return errors.Join(ErrFailedToBootstrapSchema, err)
+		}
+
+		if err := r.up(ctx, runConfig{}, result); err != nil {
+			return err
+		}
+
+		statements, err := r.DumpSchema(ctx)
+		if err != nil {
+			return errors.Join(ErrFailedToBootstrapSchema, err)
+		}
+		if err := os.MkdirAll(cacheDir, 0o750); err != nil {
+			return errors.Join(ErrFailedToBootstrapSchema, err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(statements, schemaCacheSeparator)), 0o600); err != nil {
+			return errors.Join(ErrFailedToBootstrapSchema, err)
+		}
+		return nil
+	})
+}
+
+func (r *Migrator) schemaCacheKey() (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(r.dialect))
+	for _, migration := range r.pendingMigrations(nil) {
+		checksum, err := r.checksum(migration)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte{0})
+		hash.Write([]byte(migration.ID()))
+		hash.Write([]byte{0})
+		hash.Write([]byte(checksum))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
+
+func (r *Migrator) applySchemaCache(ctx context.Context, script string, result *Result) error {
+	migrations := r.pendingMigrations(nil)
+	result.Batch = 1
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToBeginTransaction, err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	for _, statement := range strings.Split(script, schemaCacheSeparator) {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+		if _, err := r.execStatement(ctx, tx, DirectionUp, result.Batch, "", statement); err != nil {
+			return errors.Join(ErrFailedToBootstrapSchema, err)
+		}
+	}
+
+	records := make([]historyRecord, len(migrations))
+	for i, migration := range migrations {
+		checksum, err := r.checksum(migration)
+		if err != nil {
+			return err
+		}
+		records[i] = historyRecord{
+			id:          migration.ID(),
+			description: migration.Description(),
+			batch:       result.Batch,
+			checksum:    checksum,
+			appliedAt:   r.now(),
+		}
+	}
+	if err := r.insertHistory(ctx, tx, records); err != nil {
+		return errors.Join(ErrFailedToBootstrapSchema, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Join(ErrFailedToBootstrapSchema, err)
+	}
+	tx = nil
+
+	for _, migration := range migrations {
+		result.Migrations = append(result.Migrations, newMigrationResult(migration.ID(), migration.Description(), time.Now()))
+	}
+	return nil
+}
+
+func (r *Migrator) DumpSchema(ctx context.Context) ([]string, error) {
+	if r.schemaDumper != nil {
+		return r.schemaDumper(ctx)
+	}
+
+	switch r.dialect {
+	case DialectSQLite:
+		return r.dumpSQLiteSchema(ctx)
+	case DialectMySQL:
+		return r.dumpMySQLSchema(ctx)
+	default:
+		return nil, ErrUnsupportedDialect
+	}
+}
+
+func (r *Migrator) dumpSQLiteSchema(ctx context.Context) ([]string, error) {
+	rows, err := r.conn.Query(ctx, `SELECT tbl_name, sql FROM sqlite_master
+WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' ORDER BY rowid`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var statements []string
+	for rows.Next() {
+		var table, statement string
+		if err := rows.Scan(&table, &statement); err != nil {
+			return nil, err
+		}
+		if !r.ownsTable(table) {
+			statements = append(statements, statement)
+		}
+	}
+	return statements, rows.Err()
+}
+
+func (r *Migrator) dumpMySQLSchema(ctx context.Context) ([]string, error) {
+	tables, err := r.listTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []string{"SET FOREIGN_KEY_CHECKS = 0"}
+	for _, table := range tables {
+		if r.ownsTable(table) {
+			continue
+		}
+
+		rows, err := r.conn.Query(ctx, "SHOW CREATE TABLE "+r.dialect.quoteIdent(table))
+		if err != nil {
+			return nil, err
+		}
+		var name, statement string
+		if rows.Next() {
+			err = rows.Scan(&name, &statement)
+		}
+		err = errors.Join(err, rows.Err(), rows.Close())
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return append(statements, "SET FOREIGN_KEY_CHECKS = 1"), nil
+}
@@ -0,0 +1,78 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+)
+
+func newBootstrapMigrator(t *testing.T, seed string) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(
+		CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY", "email TEXT").
+			CreateIndex("idx_users_email", "users", "email").
+			Build(),
+		CreateMigration("2", "seed").RawUp(seed).Build(),
+	)
+	return migrator, db
+}
+
+func TestMigrator_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := t.TempDir()
+
+	first, firstDB := newBootstrapMigrator(t, "INSERT INTO users (email) VALUES ('a')")
+	if err := first.Bootstrap(ctx, cache); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, firstDB, "users"); n != 1 {
+		t.Errorf("expected full chain to run on a cold cache, got %d users", n)
+	}
+
+	second, secondDB := newBootstrapMigrator(t, "INSERT INTO users (email) VALUES ('a')")
+	if err := second.Bootstrap(ctx, cache); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, secondDB, "users"); n != 0 {
+		t.Errorf("expected schema to come from the cache without replaying migrations, got %d users", n)
+	}
+	assertAppliedIDs(t, second, "1", "2")
+
+	want, _ := first.SchemaSnapshot(ctx)
+	got, _ := second.SchemaSnapshot(ctx)
+	if got != want {
+		t.Errorf("expected cached schema to match migrated schema\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	if err := second.Bootstrap(ctx, cache); err != nil {
+		t.Errorf("expected bootstrap of an up-to-date database to be a no-op, got %v", err)
+	}
+
+	changed, changedDB := newBootstrapMigrator(t, "INSERT INTO users (email) VALUES ('b')")
+	if err := changed.Bootstrap(ctx, cache); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, changedDB, "users"); n != 1 {
+		t.Errorf("expected full chain when the cache key changes, got %d users", n)
+	}
+
+	entries, err := os.ReadDir(cache)
+	if err != nil || len(entries) != 2 {
+		t.Errorf("expected one cache file per key, got %v, %v", entries, err)
+	}
+}
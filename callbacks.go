@@ -0,0 +1,32 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const afterMigrateID = "afterMigrate"
+
+func (r *Migrator) runAfterMigrate(ctx context.Context, result *Result) error {
+	if len(r.afterMigrate) == 0 || len(result.Migrations) == 0 {
+		return nil
+	}
+
+	queries, err := r.renderQueries(&baseMigration{id: afterMigrateID}, r.afterMigrate)
+	if err != nil {
+		return errors.Join(ErrAfterMigrateFailed, err)
+	}
+
+	for _, script := range queries {
+		for _, statement := range SplitStatements(script) {
+			if isNoopQuery(statement) {
+				continue
+			}
+			if _, err := r.execStatement(ctx, r.conn, DirectionUp, result.Batch, afterMigrateID, statement); err != nil {
+				return fmt.Errorf("%w: %q: %w", ErrAfterMigrateFailed, firstLine(statement), err)
+			}
+		}
+	}
+	return nil
+}
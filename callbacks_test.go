@@ -0,0 +1,65 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_WithAfterMigrate(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithAfterMigrate(
+		"CREATE TABLE IF NOT EXISTS runs (n INTEGER);\nINSERT INTO runs (n) VALUES (1);",
+		"ANALYZE",
+	))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to run up without pending migrations: %v", err)
+	}
+	if count := countRows(t, db, "runs"); count != 1 {
+		t.Errorf("expected callbacks to run once for the only batch, got %d runs", count)
+	}
+
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}})
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if count := countRows(t, db, "runs"); count != 2 {
+		t.Errorf("expected callbacks to run after the second batch, got %d runs", count)
+	}
+}
+
+func TestMigrator_WithAfterMigrate_Failure(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithAfterMigrate("REFRESH MATERIALIZED VIEW missing"))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}})
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrAfterMigrateFailed) {
+		t.Fatalf("expected ErrAfterMigrateFailed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
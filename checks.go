@@ -0,0 +1,76 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+)
+
+type Check struct {
+	Name  string
+	Query string
+	Want  string
+}
+
+type Checked interface {
+	Checks() []Check
+}
+
+func checksOf(migration Migration) []Check {
+	if checked, ok := migration.(Checked); ok {
+		return checked.Checks()
+	}
+	return nil
+}
+
+func (r *Migrator) runChecks(ctx context.Context, tx Executor, migrationID string, checks []Check) error {
+	for _, check := range checks {
+		if err := r.runCheck(ctx, tx, check); err != nil {
+			return fmt.Errorf("%w: %s: %s: %w", ErrCheckFailed, migrationID, check.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Migrator) runCheck(ctx context.Context, tx Executor, check Check) error {
+	rows, err := tx.Query(ctx, check.Query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if check.Want == "" {
+		if rows.Next() {
+			return fmt.Errorf("expected no rows from %q", check.Query)
+		}
+		return rows.Err()
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("expected %q, got no rows", check.Want)
+	}
+
+	var value any
+	if err := rows.Scan(&value); err != nil {
+		return err
+	}
+	if got := checkValue(value); got != check.Want {
+		return fmt.Errorf("expected %q, got %q", check.Want, got)
+	}
+	return nil
+}
+
+func checkValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
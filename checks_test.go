@@ -0,0 +1,74 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func newChecksTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	db.SetMaxOpenConns(1)
+
+	for _, query := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT)",
+		"INSERT INTO users (email) VALUES ('a@example.com'), (NULL)",
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("failed to prepare database: %v", err)
+		}
+	}
+	return db
+}
+
+func TestMigrator_MigrationChecks(t *testing.T) {
+	t.Parallel()
+
+	db := newChecksTestDB(t)
+	migrator := New(db)
+	migrator.Register(CreateMigration("1", "backfill emails").
+		RawUp("UPDATE users SET email = 'unknown' WHERE id = 1 AND email IS NULL").
+		Check("no null emails", "SELECT id FROM users WHERE email IS NULL").
+		Build())
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrCheckFailed) {
+		t.Fatalf("expected ErrCheckFailed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+
+	fixed := New(db)
+	fixed.Register(CreateMigration("1", "backfill emails").
+		RawUp("UPDATE users SET email = 'unknown' WHERE email IS NULL").
+		Check("no null emails", "SELECT id FROM users WHERE email IS NULL").
+		CheckValue("users kept", "SELECT COUNT(*) FROM users", "2").
+		Build())
+	if _, err := fixed.Up(); err != nil {
+		t.Fatalf("expected checks to pass, got %v", err)
+	}
+	assertAppliedIDs(t, fixed, "1")
+}
+
+func TestMigrator_WithChecks(t *testing.T) {
+	t.Parallel()
+
+	db := newChecksTestDB(t)
+	migrator := New(db, WithChecks(Check{Name: "user count", Query: "SELECT COUNT(*) FROM users", Want: "2"}))
+	migrator.Register(&mockMigration{id: "1", description: "delete", upQueries: []string{"DELETE FROM users WHERE email IS NULL"}})
+
+	_, err := migrator.Up()
+	if !errors.Is(err, ErrCheckFailed) {
+		t.Fatalf("expected ErrCheckFailed, got %v", err)
+	}
+	if n := countRows(t, db, "users"); n != 2 {
+		t.Errorf("expected failed check to roll back the batch, got %d users", n)
+	}
+	assertAppliedIDs(t, migrator)
+}
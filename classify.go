@@ -0,0 +1,67 @@
+package migrator
+
+import (
+	"regexp"
+	"strings"
+)
+
+type OperationClass string
+
+const (
+	OperationSafe        OperationClass = "safe"
+	OperationBlocking    OperationClass = "blocking"
+	OperationDestructive OperationClass = "destructive"
+)
+
+type PlannedOperation struct {
+	Statement string
+	Class     OperationClass
+}
+
+var blockingPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^CREATE (UNIQUE )?INDEX (?:IF NOT EXISTS )?`),
+	regexp.MustCompile(`^ALTER TABLE .* ALTER (COLUMN )?\S+ (SET DATA )?TYPE `),
+	regexp.MustCompile(`^ALTER TABLE .* ALTER (COLUMN )?\S+ SET NOT NULL`),
+	regexp.MustCompile(`^ALTER TABLE .* (MODIFY|CHANGE) (COLUMN )?`),
+	regexp.MustCompile(`^ALTER TABLE .* ADD (CONSTRAINT \S+ )?(FOREIGN KEY|CHECK|PRIMARY KEY|UNIQUE)`),
+	regexp.MustCompile(`^(LOCK TABLE|LOCK TABLES|VACUUM FULL|CLUSTER|REINDEX|OPTIMIZE TABLE) `),
+}
+
+func classifyStatement(statement string) OperationClass {
+	if isDestructive(statement) {
+		return OperationDestructive
+	}
+
+	normalized := strings.ToUpper(strings.Join(strings.Fields(strings.TrimSuffix(strings.TrimSpace(statement), ";")), " ")) + " "
+	if strings.Contains(normalized, " CONCURRENTLY ") || strings.Contains(normalized, " NOT VALID ") ||
+		strings.Contains(normalized, "ALGORITHM=INPLACE") || strings.Contains(normalized, "ALGORITHM=INSTANT") {
+		return OperationSafe
+	}
+	for _, pattern := range blockingPatterns {
+		if pattern.MatchString(normalized) {
+			return OperationBlocking
+		}
+	}
+	return OperationSafe
+}
+
+func (p *Plan) Blocking() bool {
+	for _, migration := range p.Migrations {
+		if migration.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Plan) Operations(class OperationClass) []PlannedOperation {
+	var operations []PlannedOperation
+	for _, migration := range p.Migrations {
+		for _, operation := range migration.Operations {
+			if operation.Class == class {
+				operations = append(operations, operation)
+			}
+		}
+	}
+	return operations
+}
@@ -0,0 +1,74 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestClassifyStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]OperationClass{
+		"CREATE TABLE users (id INTEGER)":                                                                 OperationSafe,
+		"ALTER TABLE users ADD COLUMN email TEXT":                                                         OperationSafe,
+		"CREATE INDEX CONCURRENTLY idx_users_email ON users (email)":                                      OperationSafe,
+		"ALTER TABLE orders ADD CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id) NOT VALID": OperationSafe,
+		"ALTER TABLE orders ADD CONSTRAINT chk_total CHECK (total >= 0) NOT VALID;":                       OperationSafe,
+		"ALTER TABLE orders VALIDATE CONSTRAINT chk_total;":                                               OperationSafe,
+		"CREATE INDEX idx_users_email ON users (email)":                                                   OperationBlocking,
+		"create unique index idx_users_email on users (email)":                                            OperationBlocking,
+		"ALTER TABLE users ALTER COLUMN age TYPE BIGINT":                                                  OperationBlocking,
+		"ALTER TABLE users ALTER COLUMN email SET NOT NULL":                                               OperationBlocking,
+		"ALTER TABLE users MODIFY COLUMN email VARCHAR(512)":                                              OperationBlocking,
+		"ALTER TABLE orders ADD CONSTRAINT chk_total CHECK (total >= 0)":                                  OperationBlocking,
+		"VACUUM FULL users":                   OperationBlocking,
+		"DROP TABLE users":                    OperationDestructive,
+		"ALTER TABLE users DROP COLUMN email": OperationDestructive,
+		"TRUNCATE audit_log":                  OperationDestructive,
+	}
+	for statement, expected := range tests {
+		if class := classifyStatement(statement); class != expected {
+			t.Errorf("%s: expected %s, got %s", statement, expected, class)
+		}
+	}
+}
+
+func TestMigrator_PlanClassifiesOperations(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithEnvironment("production", GuardBlockingUp))
+	migrator.Register(CreateMigration("001", "create users").
+		CreateTable("users", "id INTEGER", "email TEXT").
+		CreateIndex("idx_users_email", "users", "email").
+		Build())
+
+	plan, err := migrator.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("failed to build plan: %v", err)
+	}
+	if !plan.Blocking() || plan.Destructive() {
+		t.Errorf("expected a blocking, non-destructive plan, got %+v", plan.Migrations)
+	}
+	if operations := plan.Operations(OperationBlocking); len(operations) != 1 {
+		t.Errorf("expected one blocking operation, got %+v", operations)
+	}
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrBlockingOperation) {
+		t.Fatalf("expected ErrBlockingOperation, got %v", err)
+	}
+	if _, err := migrator.Up(AllowBlocking()); err != nil {
+		t.Fatalf("expected AllowBlocking to run the migration, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001")
+}
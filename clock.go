@@ -0,0 +1,22 @@
+package migrator
+
+import (
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+func (r *Migrator) now() time.Time {
+	if r.clock == nil {
+		return time.Now().UTC()
+	}
+	return r.clock.Now().UTC()
+}
@@ -0,0 +1,45 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestMigrator_WithClock(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	fixed := time.Date(2024, 6, 1, 12, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	migrator := New(db, WithAuditLog("tester"), WithClock(ClockFunc(func() time.Time { return fixed })))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id INTEGER)"}})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(status) != 1 || !status[0].AppliedAt.Equal(fixed) {
+		t.Errorf("expected applied_at %v, got %+v", fixed, status)
+	}
+
+	entries, err := migrator.AuditLog(context.Background())
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if len(entries) != 1 || !entries[0].CreatedAt.Equal(fixed) {
+		t.Errorf("expected audit entry at %v, got %+v", fixed, entries)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shuldan/migrator"
+)
+
+func main() {
+	dir := flag.String("dir", "migrations", "directory with <id>_<description>.up.sql/.down.sql files")
+	out := flag.String("out", "migrations_gen.go", "output Go file")
+	pkg := flag.String("package", "migrations", "package name of the generated file")
+	flag.Parse()
+
+	if err := run(*dir, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "migrator-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out, pkg string) error {
+	files, err := migrator.ReadSQLFiles(os.DirFS("."), dir)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := migrator.GenerateSource(&buf, pkg, files); err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, buf.Bytes(), 0o600)
+}
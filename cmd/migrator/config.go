@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shuldan/migrator"
+)
+
+var defaultConfigFiles = []string{"migrator.yaml", "migrator.yml", "migrator.toml"}
+
+var dialectDrivers = map[string]string{
+	"sqlite":   "sqlite3",
+	"postgres": "postgres",
+	"mysql":    "mysql",
+}
+
+type config struct {
+	Driver           string
+	DSN              string
+	Dir              string
+	TablePrefix      string
+	Dialect          string
+	LockOwner        string
+	LockLease        time.Duration
+	Environment      string
+	AllowDestructive bool
+}
+
+type configFlags struct {
+	path    *string
+	profile *string
+	values  config
+	lease   *string
+}
+
+func registerConfigFlags(flags *flag.FlagSet) *configFlags {
+	f := &configFlags{
+		path:    flags.String("config", "", "path to migrator.yaml or migrator.toml"),
+		profile: flags.String("env", os.Getenv("MIGRATOR_ENV"), "profile from the config file"),
+		lease:   flags.String("lock-lease", "", "lock table lease, e.g. 30s"),
+	}
+	flags.StringVar(&f.values.Driver, "driver", "", "database/sql driver name")
+	flags.StringVar(&f.values.DSN, "dsn", "", "database connection string or URL: postgres://..., mysql://..., sqlite:file.db")
+	flags.StringVar(&f.values.Dir, "dir", "", "migrations directory")
+	flags.StringVar(&f.values.TablePrefix, "table-prefix", "", "prefix for the history table")
+	flags.StringVar(&f.values.Dialect, "dialect", "", "sql dialect: postgres, mysql or sqlite")
+	flags.StringVar(&f.values.LockOwner, "lock-owner", "", "lock table owner")
+	flags.BoolVar(&f.values.AllowDestructive, "allow-destructive", false, "allow rollbacks and destructive changes in the production profile")
+	return f
+}
+
+func (f *configFlags) resolve(flags *flag.FlagSet) (config, error) {
+	cfg, err := loadConfig(*f.path, *f.profile)
+	if err != nil {
+		return config{}, err
+	}
+
+	var lease time.Duration
+	if *f.lease != "" {
+		if lease, err = time.ParseDuration(*f.lease); err != nil {
+			return config{}, fmt.Errorf("invalid -lock-lease: %w", err)
+		}
+	}
+
+	flags.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "driver":
+			cfg.Driver = f.values.Driver
+		case "dsn":
+			cfg.DSN = f.values.DSN
+		case "dir":
+			cfg.Dir = f.values.Dir
+		case "table-prefix":
+			cfg.TablePrefix = f.values.TablePrefix
+		case "dialect":
+			cfg.Dialect = f.values.Dialect
+		case "lock-owner":
+			cfg.LockOwner = f.values.LockOwner
+		case "lock-lease":
+			cfg.LockLease = lease
+		case "allow-destructive":
+			cfg.AllowDestructive = f.values.AllowDestructive
+		}
+	})
+
+	if cfg.Dir == "" {
+		cfg.Dir = "migrations"
+	}
+	cfg.Environment = *f.profile
+	if cfg.DSN, err = migrator.ResolveSecrets(context.Background(), cfg.DSN, migrator.DefaultSecretResolvers()); err != nil {
+		return config{}, err
+	}
+	if err := cfg.applyDSN(); err != nil {
+		return config{}, err
+	}
+	if cfg.Driver == "" {
+		cfg.Driver = dialectDrivers[cfg.Dialect]
+	}
+	return cfg, nil
+}
+
+func loadConfig(path, profile string) (config, error) {
+	if path == "" {
+		for _, name := range defaultConfigFiles {
+			if _, err := os.Stat(name); err == nil {
+				path = name
+				break
+			}
+		}
+	}
+
+	var cfg config
+	if path == "" {
+		if profile != "" {
+			return cfg, fmt.Errorf("profile %q requested but no config file found", profile)
+		}
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	var values map[string]string
+	switch filepath.Ext(path) {
+	case ".toml":
+		values, err = parseTOML(string(data))
+	default:
+		values, err = parseYAML(string(data))
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := cfg.apply(values, ""); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if profile == "" {
+		return cfg, nil
+	}
+
+	prefix := "profiles." + profile + "."
+	found := false
+	for key := range values {
+		if strings.HasPrefix(key, prefix) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return cfg, fmt.Errorf("%s: unknown profile %q", path, profile)
+	}
+	if err := cfg.apply(values, prefix); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *config) apply(values map[string]string, prefix string) error {
+	for key, value := range values {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok || (prefix == "" && strings.HasPrefix(key, "profiles.")) {
+			continue
+		}
+
+		switch name {
+		case "driver":
+			c.Driver = value
+		case "dsn":
+			c.DSN = value
+		case "dir":
+			c.Dir = value
+		case "table_prefix":
+			c.TablePrefix = value
+		case "dialect":
+			c.Dialect = value
+		case "lock.owner":
+			c.LockOwner = value
+		case "lock.lease":
+			lease, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+			c.LockLease = lease
+		default:
+			return fmt.Errorf("unknown key %q", key)
+		}
+	}
+	return nil
+}
+
+func parseYAML(data string) (map[string]string, error) {
+	type level struct {
+		indent int
+		prefix string
+	}
+
+	values := make(map[string]string)
+	var stack []level
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := stripComment(scanner.Text())
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "---" {
+			continue
+		}
+
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+		key, value, ok := strings.Cut(strings.TrimSpace(text), ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected key: value", line)
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		prefix := ""
+		if len(stack) > 0 {
+			prefix = stack[len(stack)-1].prefix
+		}
+
+		key = prefix + strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			stack = append(stack, level{indent: indent, prefix: key + "."})
+			continue
+		}
+
+		unquoted, err := unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values[key] = unquoted
+	}
+	return values, scanner.Err()
+}
+
+func parseTOML(data string) (map[string]string, error) {
+	values := make(map[string]string)
+	prefix := ""
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", line)
+			}
+			prefix = strings.TrimSpace(text[1:len(text)-1]) + "."
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		unquoted, err := unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values[prefix+strings.TrimSpace(key)] = unquoted
+	}
+	return values, scanner.Err()
+}
+
+func stripComment(line string) string {
+	quote := rune(0)
+	for i, ch := range line {
+		switch {
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote == 0 && (ch == '"' || ch == '\''):
+			quote = ch
+		case quote == 0 && ch == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquote(value string) (string, error) {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1], nil
+	}
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", errors.New("invalid quoted string")
+		}
+		return unquoted, nil
+	}
+	return value, nil
+}
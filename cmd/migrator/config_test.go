@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const yamlConfig = `# shared settings
+driver: sqlite3
+dsn: "file:dev.db"
+dir: migrations
+dialect: sqlite
+lock:
+  owner: ci
+  lease: 30s
+
+profiles:
+  production:
+    dsn: 'file:prod.db#main'
+    lock:
+      lease: 1m
+`
+
+const tomlConfig = `driver = "sqlite3"
+dsn = "file:dev.db"
+dialect = "sqlite" # inline comment
+
+[lock]
+owner = "ci"
+lease = "30s"
+
+[profiles.production]
+dsn = "file:prod.db#main"
+
+[profiles.production.lock]
+lease = "1m"
+`
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	for name, content := range map[string]string{"migrator.yaml": yamlConfig, "migrator.toml": tomlConfig} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeConfig(t, name, content)
+
+			cfg, err := loadConfig(path, "")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if cfg.Driver != "sqlite3" || cfg.DSN != "file:dev.db" || cfg.Dialect != "sqlite" {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+			if cfg.LockOwner != "ci" || cfg.LockLease != 30*time.Second {
+				t.Errorf("unexpected lock settings: %+v", cfg)
+			}
+
+			cfg, err = loadConfig(path, "production")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if cfg.DSN != "file:prod.db#main" || cfg.LockLease != time.Minute || cfg.LockOwner != "ci" {
+				t.Errorf("expected profile to override shared settings, got %+v", cfg)
+			}
+
+			if _, err := loadConfig(path, "staging"); err == nil || !strings.Contains(err.Error(), "unknown profile") {
+				t.Errorf("expected unknown profile error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, "migrator.yaml", "dsn: x\ntable: users\n")
+	if _, err := loadConfig(path, ""); err == nil || !strings.Contains(err.Error(), `unknown key "table"`) {
+		t.Errorf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestRun_ConfigFileWithFlagOverrides(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	migrations := filepath.Join(dir, "migrations")
+	if err := os.Mkdir(migrations, 0o755); err != nil {
+		t.Fatalf("failed to create migrations dir: %v", err)
+	}
+	files := map[string]string{
+		"001_users.up.sql":   "CREATE TABLE users (id INTEGER);",
+		"001_users.down.sql": "DROP TABLE users;",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(migrations, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write migration: %v", err)
+		}
+	}
+
+	path := writeConfig(t, "migrator.yaml", "driver: sqlite3\ndsn: "+filepath.Join(dir, "ignored.db")+"\ndialect: sqlite\ndir: "+migrations+"\n")
+	dsn := filepath.Join(dir, "app.db")
+
+	if err := run([]string{"up", "-config", path, "-dsn", dsn}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected up to succeed, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"status", "-config", path, "-dsn", dsn}, &out); err != nil {
+		t.Fatalf("expected status to succeed, got %v", err)
+	}
+	if !strings.Contains(out.String(), "001") || !strings.Contains(out.String(), "applied") {
+		t.Errorf("expected applied migration in status, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ignored.db")); err == nil {
+		t.Error("expected -dsn flag to override the config file")
+	}
+
+	if err := run([]string{"down", "-config", path, "-dsn", dsn, "1"}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected down to succeed, got %v", err)
+	}
+}
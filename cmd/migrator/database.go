@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/shuldan/migrator"
+)
+
+func openMigrator(cfg config) (*migrator.Migrator, *sql.DB, error) {
+	if cfg.Driver == "" || cfg.DSN == "" {
+		return nil, nil, errors.New("dsn is required (set it in migrator.yaml or with -dsn; use a postgres://, mysql:// or sqlite: URL or set -driver)")
+	}
+
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := migrator.OpenSource(cfg.Dir)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	migrations, err := source.Load(context.Background())
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+
+	opts := []migrator.Option{
+		migrator.WithDialect(migrator.Dialect(cfg.Dialect)),
+		migrator.WithTablePrefix(cfg.TablePrefix),
+	}
+	if cfg.Environment != "" {
+		opts = append(opts, migrator.WithEnvironment(cfg.Environment))
+	}
+	if cfg.LockLease > 0 {
+		opts = append(opts, migrator.WithLockTable(cfg.LockOwner, cfg.LockLease))
+	}
+
+	m := migrator.New(db, opts...)
+	m.Register(migrations...)
+	return m, db, nil
+}
+
+func (c config) runOptions() []migrator.RunOption {
+	if c.AllowDestructive {
+		return []migrator.RunOption{migrator.AllowDestructive()}
+	}
+	return nil
+}
+
+func withMigrator(name string, args []string, fn func(m *migrator.Migrator, cfg config, args []string) error) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFlags := registerConfigFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := configFlags.resolve(flags)
+	if err != nil {
+		return err
+	}
+
+	m, db, err := openMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	return fn(m, cfg, flags.Args())
+}
+
+func up(args []string, stdout io.Writer) error {
+	return withMigrator("up", args, func(m *migrator.Migrator, cfg config, _ []string) error {
+		result, err := m.Up(cfg.runOptions()...)
+		printResult(stdout, result)
+		return err
+	})
+}
+
+func down(args []string, stdout io.Writer) error {
+	return withMigrator("down", args, func(m *migrator.Migrator, cfg config, args []string) error {
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[0])
+			}
+			steps = n
+		}
+		result, err := m.Down(steps, cfg.runOptions()...)
+		printResult(stdout, result)
+		return err
+	})
+}
+
+func printResult(w io.Writer, result *migrator.Result) {
+	if result == nil || len(result.Migrations) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, result)
+	for _, migration := range result.Migrations {
+		for _, statement := range migration.Statements {
+			if statement.RowsAffected == 0 {
+				_, _ = fmt.Fprintf(w, "warning: %s: statement affected 0 rows: %s\n", migration.ID, strings.TrimSpace(statement.Statement))
+			}
+		}
+	}
+}
+
+func status(args []string, stdout io.Writer) error {
+	return withMigrator("status", args, func(m *migrator.Migrator, _ config, _ []string) error {
+		return m.WriteStatus(context.Background(), stdout, false)
+	})
+}
+
+func watch(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	configFlags := registerConfigFlags(flags)
+	interval := flags.Duration("interval", time.Second, "how often to poll the migrations directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := configFlags.resolve(flags)
+	if err != nil {
+		return err
+	}
+
+	m, db, err := openMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	_, _ = fmt.Fprintf(stdout, "watching %s\n", cfg.Dir)
+	err = m.Watch(ctx, os.DirFS(cfg.Dir), ".", *interval, func(event migrator.WatchEvent) {
+		printWatchEvent(stdout, event)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func printWatchEvent(w io.Writer, event migrator.WatchEvent) {
+	for _, change := range event.Changes {
+		_, _ = fmt.Fprintf(w, "--- %s\n%s", change.Name, change.Diff)
+	}
+	for _, id := range event.Applied {
+		_, _ = fmt.Fprintln(w, "applied", id)
+	}
+	for _, id := range event.Drifted {
+		_, _ = fmt.Fprintf(w, "warning: %s was changed after it was applied; roll it back to re-run it\n", id)
+	}
+	if event.Err != nil {
+		_, _ = fmt.Fprintln(w, "error:", event.Err)
+	}
+}
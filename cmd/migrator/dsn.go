@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type connection struct {
+	driver  string
+	dialect string
+	dsn     string
+}
+
+func parseDSN(raw string) (connection, bool, error) {
+	scheme, rest, ok := strings.Cut(raw, ":")
+	if !ok {
+		return connection{}, false, nil
+	}
+
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return connection{driver: "postgres", dialect: "postgres", dsn: raw}, true, nil
+	case "mysql":
+		dsn, err := mysqlDSN(raw)
+		if err != nil {
+			return connection{}, false, err
+		}
+		return connection{driver: "mysql", dialect: "mysql", dsn: dsn}, true, nil
+	case "sqlite", "sqlite3":
+		path := strings.TrimPrefix(rest, "//")
+		if path == "" {
+			return connection{}, false, errors.New("invalid dsn: missing sqlite database path")
+		}
+		return connection{driver: "sqlite3", dialect: "sqlite", dsn: path}, true, nil
+	default:
+		return connection{}, false, nil
+	}
+}
+
+func mysqlDSN(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", errors.New("invalid dsn: malformed mysql url")
+	}
+	if u.Host == "" {
+		return "", errors.New("invalid dsn: missing mysql host")
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host += ":3306"
+	}
+
+	var dsn strings.Builder
+	if u.User != nil {
+		dsn.WriteString(u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			dsn.WriteString(":" + password)
+		}
+		dsn.WriteString("@")
+	}
+	dsn.WriteString("tcp(" + host + ")/" + strings.TrimPrefix(u.Path, "/"))
+	if u.RawQuery != "" {
+		dsn.WriteString("?" + u.RawQuery)
+	}
+	return dsn.String(), nil
+}
+
+func (c *config) applyDSN() error {
+	conn, ok, err := parseDSN(c.DSN)
+	if err != nil || !ok {
+		return err
+	}
+
+	if c.Dialect == "" {
+		c.Dialect = conn.dialect
+	} else if c.Dialect != conn.dialect {
+		return fmt.Errorf("dialect %q does not match the %s dsn", c.Dialect, conn.dialect)
+	}
+	if c.Driver == "" {
+		c.Driver = conn.driver
+	}
+	c.DSN = conn.dsn
+	return nil
+}
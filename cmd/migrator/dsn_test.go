@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw      string
+		expected connection
+		ok       bool
+	}{
+		{raw: "postgres://app:secret@db:5432/app?sslmode=disable", expected: connection{driver: "postgres", dialect: "postgres", dsn: "postgres://app:secret@db:5432/app?sslmode=disable"}, ok: true},
+		{raw: "postgresql://db/app", expected: connection{driver: "postgres", dialect: "postgres", dsn: "postgresql://db/app"}, ok: true},
+		{raw: "mysql://app:p%40ss@db/app?parseTime=true", expected: connection{driver: "mysql", dialect: "mysql", dsn: "app:p@ss@tcp(db:3306)/app?parseTime=true"}, ok: true},
+		{raw: "sqlite:app.db", expected: connection{driver: "sqlite3", dialect: "sqlite", dsn: "app.db"}, ok: true},
+		{raw: "sqlite:///var/lib/app.db", expected: connection{driver: "sqlite3", dialect: "sqlite", dsn: "/var/lib/app.db"}, ok: true},
+		{raw: "file:dev.db"},
+		{raw: "app.db"},
+	}
+
+	for _, test := range tests {
+		conn, ok, err := parseDSN(test.raw)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.raw, err)
+			continue
+		}
+		if ok != test.ok || conn != test.expected {
+			t.Errorf("%s: expected %+v (%v), got %+v (%v)", test.raw, test.expected, test.ok, conn, ok)
+		}
+	}
+
+	if _, _, err := parseDSN("mysql:///app"); err == nil {
+		t.Error("expected error for mysql dsn without host")
+	}
+}
+
+func TestParseDSN_DriversRegistered(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{
+		"postgres://app:secret@db:5432/app?sslmode=disable",
+		"postgresql://db/app",
+		"mysql://app:secret@db/app?parseTime=true",
+		"sqlite:" + filepath.Join(t.TempDir(), "app.db"),
+	} {
+		conn, ok, err := parseDSN(raw)
+		if err != nil || !ok {
+			t.Fatalf("%s: expected a supported dsn, got %v (%v)", raw, ok, err)
+		}
+		db, err := sql.Open(conn.driver, conn.dsn)
+		if err != nil {
+			t.Errorf("%s: expected driver %q to be registered, got %v", raw, conn.driver, err)
+			continue
+		}
+		_ = db.Close()
+	}
+}
+
+func TestRun_SQLiteURL(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "001_users.up.sql"), []byte("CREATE TABLE users (id INTEGER);"), 0o644); err != nil {
+		t.Fatalf("failed to write migration: %v", err)
+	}
+	dsn := "sqlite:" + filepath.Join(dir, "app.db")
+
+	if err := run([]string{"up", "-dir", dir, "-dsn", dsn}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected up to succeed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.db")); err != nil {
+		t.Errorf("expected sqlite database to be created: %v", err)
+	}
+
+	if err := run([]string{"up", "-dir", dir, "-dsn", dsn, "-dialect", "postgres"}, &bytes.Buffer{}); err == nil {
+		t.Error("expected mismatched dialect to fail")
+	}
+}
+
+func TestRun_DSNSecretPlaceholder(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "001_users.up.sql"), []byte("CREATE TABLE users (id INTEGER);"), 0o644); err != nil {
+		t.Fatalf("failed to write migration: %v", err)
+	}
+	t.Setenv("MIGRATOR_TEST_DATABASE_PATH", filepath.Join(dir, "secret.db"))
+
+	if err := run([]string{"up", "-dir", dir, "-dsn", "sqlite:${env://MIGRATOR_TEST_DATABASE_PATH}"}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected up to succeed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "secret.db")); err != nil {
+		t.Errorf("expected placeholder to resolve to the database path: %v", err)
+	}
+}
+
+func TestRun_HTTPSource(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	files := map[string]string{
+		"/index.txt":        "001_users.up.sql\n",
+		"/001_users.up.sql": "CREATE TABLE users (id INTEGER);",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		content, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	dsn := "sqlite:" + filepath.Join(t.TempDir(), "app.db")
+	if err := run([]string{"up", "-dir", server.URL, "-dsn", dsn}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("expected up from an http source to succeed, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"status", "-dir", server.URL, "-dsn", dsn}, &out); err != nil {
+		t.Fatalf("expected status to succeed, got %v", err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("001")) {
+		t.Errorf("expected migration 001 in status output, got %q", out.String())
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shuldan/migrator"
+)
+
+var errUsage = errors.New("usage: migrator <command> [flags] [args]\n\ncommands:\n" +
+	"  create <name>  scaffold a new timestamp-prefixed migration\n" +
+	"  up             apply pending migrations\n" +
+	"  down [steps]   roll back the given number of migrations (default 1)\n" +
+	"  status         print the migration status table\n" +
+	"  watch          apply new migrations as they appear in the directory\n\n" +
+	"up, down, status and watch read migrator.yaml (or migrator.toml); flags override file values")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "migrator:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		return create(args[1:], stdout)
+	case "up":
+		return up(args[1:], stdout)
+	case "down":
+		return down(args[1:], stdout)
+	case "status":
+		return status(args[1:], stdout)
+	case "watch":
+		return watch(args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown command %q\n%w", args[0], errUsage)
+	}
+}
+
+func create(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	dir := flags.String("dir", "migrations", "directory to place the new migration in")
+	format := flags.String("format", string(migrator.ScaffoldSQL), "skeleton format: sql or go")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: migrator create [-dir dir] [-format sql|go] <name>")
+	}
+
+	paths, err := migrator.Scaffold(*dir, flags.Arg(0), migrator.ScaffoldFormat(*format), time.Now())
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		_, _ = fmt.Fprintln(stdout, "created", p)
+	}
+	return nil
+}
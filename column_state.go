@@ -0,0 +1,168 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const columnStateTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_column_state (
+    module VARCHAR(255) NOT NULL,
+    migration_id VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    column_default TEXT,
+    nullable BOOLEAN NOT NULL,
+    PRIMARY KEY (module, migration_id, position)
+);
+`
+
+type columnAttribute string
+
+const (
+	columnDefault  columnAttribute = "default"
+	columnNullable columnAttribute = "nullable"
+)
+
+type columnChange struct {
+	table     string
+	column    string
+	attribute columnAttribute
+	position  int
+}
+
+type columnState struct {
+	def      sql.NullString
+	nullable bool
+}
+
+type columnChanger interface {
+	columnChanges() []columnChange
+}
+
+func columnChangesOf(migration Migration) []columnChange {
+	if changer, ok := migration.(columnChanger); ok {
+		return changer.columnChanges()
+	}
+	return nil
+}
+
+func (r *Migrator) captureColumnState(ctx context.Context, tx Executor, migration Migration) error {
+	changes := columnChangesOf(migration)
+	query := introspection[r.dialect].columnState
+	if len(changes) == 0 || query == "" {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, r.query(columnStateTableTemplate)); err != nil {
+		return errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+	if _, err := tx.Exec(ctx, r.query("DELETE FROM %s_column_state WHERE module = ? AND migration_id = ?"), r.module(), migration.ID()); err != nil {
+		return errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+
+	for _, change := range changes {
+		table := r.tablePrefix + change.table
+		state, found, err := r.readColumnState(ctx, tx, query, table, change.column)
+		if err != nil {
+			return errors.Join(ErrFailedToCaptureColumnState, err)
+		}
+		if !found {
+			continue
+		}
+
+		_, err = tx.Exec(ctx, r.query("INSERT INTO %s_column_state (module, migration_id, position, column_default, nullable) VALUES (?, ?, ?, ?, ?)"),
+			r.module(), migration.ID(), change.position, state.def, state.nullable)
+		if err != nil {
+			return errors.Join(ErrFailedToCaptureColumnState, err)
+		}
+	}
+	return nil
+}
+
+func (r *Migrator) readColumnState(ctx context.Context, tx Executor, query, table, column string) (columnState, bool, error) {
+	rows, err := tx.Query(ctx, r.dialect.rebind(query), table, column)
+	if err != nil {
+		return columnState{}, false, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var state columnState
+	if !rows.Next() {
+		return state, false, rows.Err()
+	}
+	if err := rows.Scan(&state.def, &state.nullable); err != nil {
+		return state, false, err
+	}
+	return state, true, rows.Err()
+}
+
+func (r *Migrator) restoreColumnState(ctx context.Context, tx Executor, migration Migration, queries []string) ([]string, error) {
+	changes := columnChangesOf(migration)
+	if len(changes) == 0 || introspection[r.dialect].columnState == "" {
+		return queries, nil
+	}
+
+	if _, err := tx.Exec(ctx, r.query(columnStateTableTemplate)); err != nil {
+		return nil, errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+	rows, err := tx.Query(ctx, r.query("SELECT position, column_default, nullable FROM %s_column_state WHERE module = ? AND migration_id = ?"), r.module(), migration.ID())
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	states := make(map[int]columnState)
+	for rows.Next() {
+		var position int
+		var state columnState
+		if err := rows.Scan(&position, &state.def, &state.nullable); err != nil {
+			return nil, errors.Join(ErrFailedToCaptureColumnState, err)
+		}
+		states[position] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+
+	restored := make([]string, len(queries))
+	copy(restored, queries)
+	for _, change := range changes {
+		state, ok := states[change.position]
+		index := len(queries) - 1 - change.position
+		if !ok || index < 0 {
+			continue
+		}
+		table := r.tablePrefix + change.table
+		restored[index] = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, change.column, state.restore(change.attribute))
+	}
+	return restored, nil
+}
+
+func (r *Migrator) forgetColumnState(ctx context.Context, tx Executor, migration Migration) error {
+	if len(columnChangesOf(migration)) == 0 || introspection[r.dialect].columnState == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, r.query("DELETE FROM %s_column_state WHERE module = ? AND migration_id = ?"), r.module(), migration.ID()); err != nil {
+		return errors.Join(ErrFailedToCaptureColumnState, err)
+	}
+	return nil
+}
+
+func (s columnState) restore(attribute columnAttribute) string {
+	switch {
+	case attribute == columnNullable && s.nullable:
+		return "DROP NOT NULL"
+	case attribute == columnNullable:
+		return "SET NOT NULL"
+	case s.def.Valid:
+		return "SET DEFAULT " + s.def.String
+	default:
+		return "DROP DEFAULT"
+	}
+}
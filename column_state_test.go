@@ -0,0 +1,144 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMigrationBuilder_ColumnAlterations(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "alter columns").
+		SetColumnDefault("users", "status", "'active'").
+		DropNotNull("users", "email").
+		Build()
+
+	expectedUp := []string{
+		"ALTER TABLE users ALTER COLUMN status SET DEFAULT 'active';",
+		"ALTER TABLE users ALTER COLUMN email DROP NOT NULL;",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+
+	expectedDown := []string{
+		"ALTER TABLE users ALTER COLUMN email SET NOT NULL;",
+		"ALTER TABLE users ALTER COLUMN status DROP DEFAULT;",
+	}
+	if !slices.Equal(migration.Down(), expectedDown) {
+		t.Errorf("expected down queries %q, got %q", expectedDown, migration.Down())
+	}
+}
+
+func TestMigrator_ColumnAlterationsRestorePreviousState(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migration := CreateMigration("001", "relax users").
+		DropColumnDefault("users", "email").
+		DropNotNull("users", "email").
+		SetNotNull("users", "nickname").
+		Build()
+
+	migrator := New(db, WithDialect(DialectPostgres))
+	if _, err := db.Exec(migrator.query(columnStateTableTemplate)); err != nil {
+		t.Fatalf("failed to create column state table: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO schema_migrations_column_state (module, migration_id, position, column_default, nullable) VALUES
+		('', '001', 0, '''nobody''', 0), ('', '001', 1, '''nobody''', 0), ('', '001', 2, NULL, 1)`); err != nil {
+		t.Fatalf("failed to seed column state: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	restored, err := migrator.restoreColumnState(context.Background(), stdTx{tx: tx}, migration, migration.Down())
+	if err != nil {
+		t.Fatalf("failed to restore column state: %v", err)
+	}
+
+	expected := []string{
+		"ALTER TABLE users ALTER COLUMN nickname DROP NOT NULL;",
+		"ALTER TABLE users ALTER COLUMN email SET NOT NULL;",
+		"ALTER TABLE users ALTER COLUMN email SET DEFAULT 'nobody';",
+	}
+	if !slices.Equal(restored, expected) {
+		t.Errorf("expected restore statements %q, got %q", expected, restored)
+	}
+}
+
+func TestMigrator_ColumnAlterationsUnsupportedDialects(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE users (email TEXT NOT NULL DEFAULT 'nobody')"); err != nil {
+		t.Fatalf("failed to create users table: %v", err)
+	}
+
+	migration := CreateMigration("001", "relax users").DropNotNull("users", "email").Build()
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(migration)
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect on sqlite, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+
+	if _, err := New(db, WithDialect(DialectMySQL)).downQueries(migration); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect on mysql, got %v", err)
+	}
+	if _, err := New(db, WithDialect(DialectPostgres)).upQueries(migration); err != nil {
+		t.Errorf("expected postgres to be supported, got %v", err)
+	}
+}
+
+func TestMigrator_AddColumnWithBackfill(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("failed to create users table: %v", err)
+	}
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(CreateMigration("001", "add required status").
+		AddColumnWithBackfill("users", "id", "status TEXT", "'active'").
+		Build())
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
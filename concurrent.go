@@ -0,0 +1,149 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type NonTransactional interface {
+	NoTransaction() bool
+}
+
+type concurrentIndexLister interface {
+	concurrentIndexes() []string
+}
+
+func (r *Migrator) isTransactional(migration Migration) bool {
+	nonTransactional, ok := migration.(NonTransactional)
+	return !ok || !nonTransactional.NoTransaction()
+}
+
+func splitByTransaction[T any](items []T, transactional func(T) bool) [][]T {
+	var segments [][]T
+	for i, item := range items {
+		if i == 0 || transactional(item) != transactional(items[i-1]) {
+			segments = append(segments, nil)
+		}
+		segments[len(segments)-1] = append(segments[len(segments)-1], item)
+	}
+	return segments
+}
+
+func (r *Migrator) applyWithoutTransaction(ctx context.Context, migrations []Migration, batch, offset, total int, result *Result) error {
+	for i, migration := range migrations {
+		r.reportProgress(offset+i, total, migration)
+		started := time.Now()
+		r.emit(Event{Kind: EventMigrationStarted, Direction: DirectionUp, Batch: batch, MigrationID: migration.ID()})
+
+		if err := r.executeWithoutTransaction(ctx, migration, batch); err != nil {
+			if recordErr := r.recordFailure(ctx, migration, batch, started); recordErr != nil {
+				err = errors.Join(err, recordErr)
+			}
+			if failure := (MigrationFailure{ID: migration.ID(), Err: err}); r.skipFailure(failure) {
+				result.Failures = append(result.Failures, failure)
+				continue
+			}
+			result.FailedID = migration.ID()
+			return errors.Join(ErrMigrationFailed, err)
+		}
+
+		result.Migrations = append(result.Migrations, newMigrationResult(migration.ID(), migration.Description(), started))
+	}
+	return nil
+}
+
+func (r *Migrator) executeWithoutTransaction(ctx context.Context, migration Migration, batch int) error {
+	indexes := r.concurrentIndexes(migration)
+	for _, index := range indexes {
+		if err := r.dropInvalidIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	if err := r.executeMigrationUp(ctx, r.conn, migration, batch); err != nil {
+		return err
+	}
+	if err := r.runChecks(ctx, r.conn, migration.ID(), r.checks); err != nil {
+		return err
+	}
+
+	for _, index := range indexes {
+		valid, err := r.indexValid(ctx, index)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			_, dropErr := r.conn.Exec(ctx, "DROP INDEX CONCURRENTLY IF EXISTS "+index)
+			_ = r.deleteMigrationRecord(ctx, r.conn, migration.ID())
+			return errors.Join(fmt.Errorf("%w: %s", ErrInvalidIndex, index), dropErr)
+		}
+	}
+	return nil
+}
+
+func (r *Migrator) rollbackWithoutTransaction(ctx context.Context, rollbackList []MigrationStatus, migrationMap map[string]Migration, offset, total int, result *Result) error {
+	for i, migrationStatus := range rollbackList {
+		r.reportProgress(offset+i, total, migrationMap[migrationStatus.ID])
+		started := time.Now()
+		r.emit(Event{Kind: EventMigrationStarted, Direction: DirectionDown, Batch: migrationStatus.Batch, MigrationID: migrationStatus.ID})
+
+		if err := r.rollbackSingleMigration(ctx, r.conn, migrationStatus, migrationMap); err != nil {
+			result.FailedID = migrationStatus.ID
+			return err
+		}
+		result.Migrations = append(result.Migrations, newMigrationResult(migrationStatus.ID, migrationStatus.Description, started))
+	}
+	return nil
+}
+
+func (r *Migrator) concurrentIndexes(migration Migration) []string {
+	lister, ok := migration.(concurrentIndexLister)
+	if !ok || r.dialect != DialectPostgres {
+		return nil
+	}
+
+	indexes := lister.concurrentIndexes()
+	if r.tablePrefix == "" {
+		return indexes
+	}
+
+	prefixed := make([]string, len(indexes))
+	for i, index := range indexes {
+		prefixed[i] = r.tablePrefix + index
+	}
+	return prefixed
+}
+
+func (r *Migrator) dropInvalidIndex(ctx context.Context, index string) error {
+	valid, err := r.indexValid(ctx, index)
+	if err != nil || valid {
+		return err
+	}
+
+	_, err = r.conn.Exec(ctx, "DROP INDEX CONCURRENTLY IF EXISTS "+index)
+	return err
+}
+
+func (r *Migrator) indexValid(ctx context.Context, index string) (bool, error) {
+	rows, err := r.conn.Query(ctx, r.dialect.rebind(
+		"SELECT i.indisvalid FROM pg_index i "+
+			"JOIN pg_class c ON c.oid = i.indexrelid "+
+			"JOIN pg_namespace n ON n.oid = c.relnamespace "+
+			"WHERE n.nspname = current_schema() AND c.relname = ?"), index)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	valid := true
+	if rows.Next() {
+		if err := rows.Scan(&valid); err != nil {
+			return false, err
+		}
+	}
+	return valid, rows.Err()
+}
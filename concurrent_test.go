@@ -0,0 +1,126 @@
+package migrator
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type nonTransactionalMigration struct {
+	mockMigration
+}
+
+func (m *nonTransactionalMigration) NoTransaction() bool {
+	return true
+}
+
+func TestMigrationBuilder_CreateIndexConcurrently(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "index emails").
+		CreateIndexConcurrently("idx_users_email", "users", "email").
+		Build()
+
+	if migration.Up()[0] != "CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_users_email ON users (email);" {
+		t.Errorf("unexpected up query: %q", migration.Up()[0])
+	}
+	if migration.Down()[0] != "DROP INDEX CONCURRENTLY IF EXISTS idx_users_email;" {
+		t.Errorf("unexpected down query: %q", migration.Down()[0])
+	}
+	if nonTransactional, ok := migration.(NonTransactional); !ok || !nonTransactional.NoTransaction() {
+		t.Error("expected concurrent index migration to opt out of the batch transaction")
+	}
+	if CreateMigration("2", "plain").CreateIndex("idx", "users", "email").Build().(NonTransactional).NoTransaction() {
+		t.Error("expected plain index migration to stay transactional")
+	}
+}
+
+func TestSplitByTransaction(t *testing.T) {
+	t.Parallel()
+
+	segments := splitByTransaction([]int{1, 2, 3, 4, 5}, func(n int) bool { return n != 3 })
+	expected := [][]int{{1, 2}, {3}, {4, 5}}
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("expected %v, got %v", expected, segments)
+	}
+}
+
+func TestMigrator_NonTransactionalMigration(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	conn := &countingConn{Conn: stdConn{db: db}}
+	migrator := NewWithConn(conn)
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER, email TEXT)"}, downQueries: []string{"DROP TABLE users"}},
+		&nonTransactionalMigration{mockMigration{id: "2", upQueries: []string{"CREATE INDEX idx_users_email ON users (email)"}, downQueries: []string{"DROP INDEX idx_users_email"}}},
+		&mockMigration{id: "3", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}, downQueries: []string{"DROP TABLE posts"}},
+	)
+
+	var progress []int
+	migrator.progress = func(done, total int, current Migration) {
+		progress = append(progress, done)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if conn.begins != 2 {
+		t.Errorf("expected the batch to be split into 2 transactions, got %d", conn.begins)
+	}
+	if !reflect.DeepEqual(progress, []int{0, 1, 2, 3}) {
+		t.Errorf("expected progress across segments, got %v", progress)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, s := range status {
+		if s.Batch != 1 {
+			t.Errorf("expected all migrations in batch 1, got %d for %s", s.Batch, s.ID)
+		}
+	}
+
+	conn.begins = 0
+	if _, err := migrator.Down(0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if conn.begins != 2 {
+		t.Errorf("expected rollback to be split into 2 transactions, got %d", conn.begins)
+	}
+}
+
+func TestMigrator_NonTransactionalMigration_Failure(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&nonTransactionalMigration{mockMigration{id: "2", upQueries: []string{"CREATE INDEX idx ON missing (id)"}}},
+	)
+
+	_, err = migrator.Up()
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected failure from the non-transactional migration, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
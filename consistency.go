@@ -0,0 +1,80 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type SourceMismatchError struct {
+	MissingFromSource   []string
+	MissingFromRegistry []string
+	Changed             []string
+}
+
+func (e *SourceMismatchError) Error() string {
+	var details []string
+	if len(e.MissingFromSource) > 0 {
+		details = append(details, "registered but not in source: "+strings.Join(e.MissingFromSource, ", "))
+	}
+	if len(e.MissingFromRegistry) > 0 {
+		details = append(details, "in source but not registered: "+strings.Join(e.MissingFromRegistry, ", "))
+	}
+	if len(e.Changed) > 0 {
+		details = append(details, "checksum differs: "+strings.Join(e.Changed, ", "))
+	}
+	return fmt.Sprintf("%s: %s", ErrSourceMismatch, strings.Join(details, "; "))
+}
+
+func (e *SourceMismatchError) Unwrap() error {
+	return ErrSourceMismatch
+}
+
+func (r *Migrator) CompareSource(ctx context.Context, source Source) error {
+	loaded, err := source.Load(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered := r.buildMigrationMap(r.activeMigrations())
+	onDisk := r.buildMigrationMap(loaded)
+
+	mismatch := &SourceMismatchError{}
+	for id, migration := range registered {
+		other, exists := onDisk[id]
+		if !exists {
+			mismatch.MissingFromSource = append(mismatch.MissingFromSource, id)
+			continue
+		}
+
+		want, err := r.checksum(migration)
+		if err != nil {
+			return err
+		}
+		got, err := r.checksum(other)
+		if err != nil {
+			return err
+		}
+		if want != got {
+			mismatch.Changed = append(mismatch.Changed, id)
+		}
+	}
+	for id := range onDisk {
+		if _, exists := registered[id]; !exists {
+			mismatch.MissingFromRegistry = append(mismatch.MissingFromRegistry, id)
+		}
+	}
+
+	if len(mismatch.MissingFromSource)+len(mismatch.MissingFromRegistry)+len(mismatch.Changed) == 0 {
+		return nil
+	}
+	sort.Strings(mismatch.MissingFromSource)
+	sort.Strings(mismatch.MissingFromRegistry)
+	sort.Strings(mismatch.Changed)
+	return mismatch
+}
@@ -0,0 +1,63 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrator_CompareSource(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	fsys := fstest.MapFS{
+		"001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id INTEGER);")},
+		"001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD COLUMN email TEXT;")},
+	}
+	embedded, err := LoadFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	migrator := New(db)
+	migrator.Register(embedded...)
+
+	ctx := context.Background()
+	if err := migrator.CompareSource(ctx, FSSource{FS: fsys, Dir: "."}); err != nil {
+		t.Fatalf("expected matching source, got %v", err)
+	}
+
+	fsys["002_add_email.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE users ADD COLUMN email VARCHAR(255);")}
+	fsys["003_add_name.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE users ADD COLUMN name TEXT;")}
+	delete(fsys, "001_create_users.up.sql")
+	delete(fsys, "001_create_users.down.sql")
+
+	err = migrator.CompareSource(ctx, FSSource{FS: fsys, Dir: "."})
+	if !errors.Is(err, ErrSourceMismatch) {
+		t.Fatalf("expected ErrSourceMismatch, got %v", err)
+	}
+
+	var mismatch *SourceMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected *SourceMismatchError, got %T", err)
+	}
+	expected := &SourceMismatchError{
+		MissingFromSource:   []string{"001"},
+		MissingFromRegistry: []string{"003"},
+		Changed:             []string{"002"},
+	}
+	if !reflect.DeepEqual(mismatch, expected) {
+		t.Errorf("expected %+v, got %+v", expected, mismatch)
+	}
+}
@@ -0,0 +1,49 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+)
+
+func (f *Fleet) coordinatedUp(ctx context.Context, config runConfig) (*FleetReport, error) {
+	report, err := f.run(ctx, func(ctx context.Context, m *Migrator, _ *ShardResult) error {
+		_, err := m.Plan(ctx)
+		return err
+	})
+	if err != nil {
+		return report, errors.Join(ErrFleetPrepareFailed, err)
+	}
+
+	report, err = f.run(ctx, func(ctx context.Context, m *Migrator, result *ShardResult) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		var err error
+		result.Result, err = m.runWithResult(ctx, DirectionUp, func(ctx context.Context, res *Result) error {
+			err := m.up(ctx, config, res)
+			result.Applied = res.IDs()
+			return err
+		})
+		return err
+	})
+	if err == nil {
+		return report, nil
+	}
+
+	for i, shard := range f.shards {
+		result := &report.Shards[i]
+		if len(result.Applied) == 0 {
+			continue
+		}
+
+		m := f.newMigrator(shard)
+		if _, result.RollbackErr = m.Down(len(result.Applied), AllowDestructive()); result.RollbackErr == nil {
+			result.RolledBack = true
+		}
+		if applied, statusErr := m.Status(); statusErr == nil {
+			result.Version = currentVersion(applied)
+		}
+	}
+
+	return report, errors.Join(ErrFleetRolledBack, report.Err())
+}
@@ -0,0 +1,100 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func coordinatedMigrations() []Migration {
+	return []Migration{
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}, downQueries: []string{"DROP TABLE a"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"CREATE TABLE b (id INTEGER)"}, downQueries: []string{"DROP TABLE b"}},
+	}
+}
+
+func TestFleet_Coordinated_RollsBackAppliedShards(t *testing.T) {
+	t.Parallel()
+
+	broken := openFleetShard(t, "broken")
+	if _, err := broken.Exec("CREATE TABLE b (id INTEGER)"); err != nil {
+		t.Fatalf("failed to prepare shard: %v", err)
+	}
+
+	fleet := NewFleet([]Shard{
+		{Name: "eu-1", DB: openFleetShard(t, "eu-1")},
+		{Name: "broken", DB: broken},
+		{Name: "us-1", DB: openFleetShard(t, "us-1")},
+	}).Coordinated()
+	for _, migration := range coordinatedMigrations() {
+		fleet.Register(&nonTransactionalMigration{mockMigration: *migration.(*mockMigration)})
+	}
+
+	report, err := fleet.Up(context.Background())
+	if !errors.Is(err, ErrFleetRolledBack) {
+		t.Fatalf("expected ErrFleetRolledBack, got %v", err)
+	}
+
+	for _, shard := range report.Shards {
+		if shard.Version != "" {
+			t.Errorf("expected shard %s to end at no version, got %q", shard.Name, shard.Version)
+		}
+		if len(shard.Applied) > 0 && (!shard.RolledBack || shard.RollbackErr != nil) {
+			t.Errorf("expected shard %s to be rolled back, got %+v", shard.Name, shard)
+		}
+	}
+
+	failed := report.Shards[1]
+	if failed.Err == nil || len(failed.Applied) != 1 || !failed.RolledBack {
+		t.Errorf("expected broken shard to fail after applying 1 and roll it back, got %+v", failed)
+	}
+
+	var count int
+	if err := broken.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'a'").Scan(&count); err != nil {
+		t.Fatalf("failed to inspect shard: %v", err)
+	}
+	if count != 0 {
+		t.Error("expected table a to be dropped on the broken shard")
+	}
+}
+
+func TestFleet_Coordinated_PrepareFailure(t *testing.T) {
+	t.Parallel()
+
+	healthy := openFleetShard(t, "healthy")
+	fleet := NewFleet([]Shard{
+		{Name: "healthy", DB: healthy},
+		{Name: "closed", DB: closedShard(t)},
+	}).Coordinated()
+	fleet.Register(coordinatedMigrations()...)
+
+	if _, err := fleet.Up(context.Background()); !errors.Is(err, ErrFleetPrepareFailed) {
+		t.Fatalf("expected ErrFleetPrepareFailed, got %v", err)
+	}
+
+	var count int
+	if err := healthy.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'a'").Scan(&count); err != nil {
+		t.Fatalf("failed to inspect shard: %v", err)
+	}
+	if count != 0 {
+		t.Error("expected no migrations to be applied when a shard fails to prepare")
+	}
+}
+
+func TestFleet_Coordinated_Success(t *testing.T) {
+	t.Parallel()
+
+	fleet := NewFleet([]Shard{
+		{Name: "eu-1", DB: openFleetShard(t, "eu-1")},
+		{Name: "us-1", DB: openFleetShard(t, "us-1")},
+	}).Coordinated().Parallelism(2)
+	fleet.Register(coordinatedMigrations()...)
+
+	report, err := fleet.Up(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if versions := report.ByVersion(); len(versions["2"]) != 2 {
+		t.Errorf("expected all shards at version 2, got %v", versions)
+	}
+}
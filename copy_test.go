@@ -0,0 +1,54 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMigrationBuilder_CopyTable(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	setup := []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT, active INTEGER)",
+		"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 2500) " +
+			"INSERT INTO users (id, email, active) SELECT i, 'user' || i, i % 5 != 0 FROM n",
+		"CREATE TABLE users_v2 (id INTEGER PRIMARY KEY, email TEXT)",
+	}
+	for _, query := range setup {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("failed to prepare database: %v", err)
+		}
+	}
+
+	limiter := &countingLimiter{}
+	migrator := New(db, WithLimiter(limiter))
+	migrator.Register(CreateMigration("1", "copy users").
+		CopyTable("users", "users_v2", []string{"id", "email"}, "active = 1").
+		Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "users_v2"); n != 2000 {
+		t.Errorf("expected 2000 copied rows, got %d", n)
+	}
+	if limiter.calls != 3 {
+		t.Errorf("expected 2 full chunks and a final empty chunk, got %d statements", limiter.calls)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "users_v2"); n != 0 {
+		t.Errorf("expected copied rows to be removed, got %d", n)
+	}
+}
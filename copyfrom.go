@@ -0,0 +1,292 @@
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const copyInsertParams = 500
+
+var errCopyUnsupported = errors.New("driver does not support COPY FROM STDIN")
+
+type CopyFormat int
+
+const (
+	CopyCSV CopyFormat = iota
+	CopyTSV
+)
+
+type CopySpec struct {
+	Table       string
+	Columns     []string
+	Format      CopyFormat
+	Header      bool
+	ClearOnDown bool
+	Truncate    bool
+}
+
+type CopySource interface {
+	Next() bool
+	Values() ([]any, error)
+	Err() error
+}
+
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, table string, columns []string, src CopySource) (int64, error)
+}
+
+type copyMigration struct {
+	id          string
+	description string
+	spec        CopySpec
+	open        OpenFunc
+}
+
+func CopyMigration(id, description string, spec CopySpec, open OpenFunc) Migration {
+	return &copyMigration{id: id, description: description, spec: spec, open: open}
+}
+
+func (m *copyMigration) ID() string {
+	return m.id
+}
+
+func (m *copyMigration) Description() string {
+	return m.description
+}
+
+func (m *copyMigration) Up() []string {
+	return nil
+}
+
+func (m *copyMigration) Down() []string {
+	return stripIdentifiers(m.markedQueries(DirectionDown))
+}
+
+func (m *copyMigration) markedQueries(direction Direction) []string {
+	switch {
+	case direction == DirectionUp:
+		return nil
+	case !m.spec.ClearOnDown:
+		return []string{fmt.Sprintf("-- Rows copied into %s are kept; set ClearOnDown to remove them", m.spec.Table)}
+	case m.spec.Truncate:
+		return []string{"TRUNCATE TABLE " + identifier(m.spec.Table)}
+	default:
+		return []string{"DELETE FROM " + identifier(m.spec.Table)}
+	}
+}
+
+func (m *copyMigration) checksum() (string, error) {
+	data, err := m.open()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = data.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type csvSource struct {
+	reader *csv.Reader
+	nulls  bool
+	values []any
+	err    error
+}
+
+func newCSVSource(r io.Reader, format CopyFormat) *csvSource {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	if format == CopyTSV {
+		reader.Comma = '\t'
+		reader.LazyQuotes = true
+	}
+	return &csvSource{reader: reader, nulls: format == CopyTSV}
+}
+
+func (s *csvSource) Next() bool {
+	record, err := s.reader.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		return false
+	}
+
+	s.values = make([]any, len(record))
+	for i, field := range record {
+		if s.nulls && field == `\N` {
+			continue
+		}
+		s.values[i] = field
+	}
+	return true
+}
+
+func (s *csvSource) Values() ([]any, error) {
+	return s.values, nil
+}
+
+func (s *csvSource) Err() error {
+	return s.err
+}
+
+func (r *Migrator) runCopyUp(ctx context.Context, tx Executor, migration *copyMigration, batch int) (historyRecord, error) {
+	started := time.Now()
+
+	checksum, err := migration.checksum()
+	if err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+
+	data, err := migration.open()
+	if err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+	defer func() {
+		_ = data.Close()
+	}()
+
+	table := r.tablePrefix + migration.spec.Table
+	src := newCSVSource(data, migration.spec.Format)
+	columns := migration.spec.Columns
+	if migration.spec.Header && src.Next() {
+		if len(columns) == 0 {
+			for _, value := range src.values {
+				column, _ := value.(string)
+				columns = append(columns, column)
+			}
+		}
+	}
+	if err := src.Err(); err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+	if len(columns) == 0 {
+		return historyRecord{}, fmt.Errorf("%w: no columns for %s", ErrInvalidCopyData, table)
+	}
+
+	statement := fmt.Sprintf("COPY %s (%s) FROM STDIN", table, strings.Join(columns, ", "))
+	_, err = r.copyFrom(ctx, tx, table, columns, src)
+
+	event := newStatementEvent(DirectionUp, batch, migration.ID(), statement, started, err)
+	r.emit(event)
+	r.logStatement(ctx, event, nil)
+	if err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+
+	return historyRecord{
+		id:          migration.ID(),
+		description: migration.Description(),
+		batch:       batch,
+		checksum:    checksum,
+		duration:    time.Since(started),
+		appliedAt:   r.now(),
+	}, nil
+}
+
+func (r *Migrator) copyFrom(ctx context.Context, tx Executor, table string, columns []string, src CopySource) (int64, error) {
+	if copier, ok := tx.(CopyFromer); ok {
+		return copier.CopyFrom(ctx, table, columns, src)
+	}
+	if std, ok := tx.(stdTx); ok && r.dialect == DialectPostgres {
+		count, err := std.copyIn(ctx, table, columns, src)
+		if !errors.Is(err, errCopyUnsupported) {
+			return count, err
+		}
+	}
+	return r.copyWithInserts(ctx, tx, table, columns, src)
+}
+
+func (t stdTx) copyIn(ctx context.Context, table string, columns []string, src CopySource) (int64, error) {
+	const savepoint = "migrator_copy"
+
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return 0, err
+	}
+	stmt, err := t.tx.PrepareContext(ctx, fmt.Sprintf("COPY %s (%s) FROM STDIN", table, strings.Join(columns, ", ")))
+	if err != nil {
+		if _, rollbackErr := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			return 0, errors.Join(err, rollbackErr)
+		}
+		return 0, errors.Join(errCopyUnsupported, err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var count int64
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return count, err
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := src.Err(); err != nil {
+		return count, err
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return count, err
+	}
+	_, err = t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return count, err
+}
+
+func (r *Migrator) copyWithInserts(ctx context.Context, tx Executor, table string, columns []string, src CopySource) (int64, error) {
+	chunk := max(copyInsertParams/len(columns), 1)
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	var count int64
+	args := make([]any, 0, chunk*len(columns))
+	flush := func(rows int) error {
+		if rows == 0 {
+			return nil
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, strings.Join(columns, ", "),
+			strings.TrimSuffix(strings.Repeat(row+", ", rows), ", "))
+		if _, err := tx.Exec(ctx, r.dialect.rebind(query), args...); err != nil {
+			return err
+		}
+		count += int64(rows)
+		args = args[:0]
+		return nil
+	}
+
+	rows := 0
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return count, err
+		}
+		if len(values) != len(columns) {
+			return count, fmt.Errorf("%w: expected %d values, got %d", ErrInvalidCopyData, len(columns), len(values))
+		}
+		args = append(args, values...)
+		if rows++; rows == chunk {
+			if err := flush(rows); err != nil {
+				return count, err
+			}
+			rows = 0
+		}
+	}
+	if err := src.Err(); err != nil {
+		return count, err
+	}
+	return count, flush(rows)
+}
@@ -0,0 +1,237 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func stringOpener(content string) OpenFunc {
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(content)), nil
+	}
+}
+
+func newCopyTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE countries (code TEXT, name TEXT, capital TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	return db
+}
+
+func TestMigrator_CopyMigrationCSV(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+
+	var data strings.Builder
+	data.WriteString("code,name,capital\n")
+	for i := range 1200 {
+		fmt.Fprintf(&data, "c%d,\"Country, %d\",City %d\n", i, i, i)
+	}
+
+	migrator := New(db)
+	migrator.Register(CopyMigration("1", "load countries",
+		CopySpec{Table: "countries", Format: CopyCSV, Header: true, ClearOnDown: true}, stringOpener(data.String())))
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "countries"); n != 1200 {
+		t.Errorf("expected 1200 rows, got %d", n)
+	}
+	if err := migrator.Verify(context.Background()); err != nil {
+		t.Errorf("expected checksum of the data file to verify, got %v", err)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "countries"); n != 0 {
+		t.Errorf("expected rows to be deleted, got %d", n)
+	}
+}
+
+func TestMigrator_CopyMigrationKeepsRowsWithoutClearOnDown(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+	if _, err := db.Exec("CREATE TABLE app_countries (code TEXT, name TEXT, capital TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO app_countries (code, name) VALUES ('xx', 'Existing')"); err != nil {
+		t.Fatalf("failed to seed table: %v", err)
+	}
+
+	migrator := New(db, WithTablePrefix("app_"))
+	migration := CopyMigration("1", "load countries",
+		CopySpec{Table: "countries", Columns: []string{"code", "name", "capital"}}, stringOpener("fr,France,Paris\n"))
+	migrator.Register(migration)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "app_countries"); n != 2 {
+		t.Errorf("expected rows to be copied into the prefixed table, got %d", n)
+	}
+	if n := countRows(t, db, "countries"); n != 0 {
+		t.Errorf("expected the unprefixed table to stay empty, got %d", n)
+	}
+	if isReversible(migration) {
+		t.Error("expected copy migration without ClearOnDown to be irreversible")
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "app_countries"); n != 2 {
+		t.Errorf("expected rollback to keep the rows, got %d", n)
+	}
+
+	cleared := CopyMigration("2", "load countries", CopySpec{Table: "countries", ClearOnDown: true, Truncate: true}, stringOpener(""))
+	if down, err := migrator.downQueries(cleared); err != nil || len(down) != 1 || down[0] != "TRUNCATE TABLE app_countries" {
+		t.Errorf("expected prefixed TRUNCATE, got %q (%v)", down, err)
+	}
+	if classifyStatement(cleared.Down()[0]) != OperationDestructive {
+		t.Error("expected ClearOnDown rollback to be classified as destructive")
+	}
+}
+
+func TestMigrator_CopyMigrationTSV(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+	migrator := New(db)
+	migrator.Register(CopyMigration("1", "load countries",
+		CopySpec{Table: "countries", Columns: []string{"code", "name", "capital"}, Format: CopyTSV},
+		stringOpener("fr\tFrance\tParis\naq\tAntarctica\t\\N\n")))
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var nulls int
+	if err := db.QueryRow("SELECT COUNT(*) FROM countries WHERE capital IS NULL").Scan(&nulls); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if nulls != 1 {
+		t.Errorf("expected \\N to load as NULL, got %d null capitals", nulls)
+	}
+
+	broken := New(db)
+	broken.Register(CopyMigration("2", "short row",
+		CopySpec{Table: "countries", Columns: []string{"code", "name", "capital"}, Format: CopyTSV},
+		stringOpener("de\tGermany\n")))
+	if _, err := broken.Up(); !errors.Is(err, ErrInvalidCopyData) {
+		t.Errorf("expected ErrInvalidCopyData, got %v", err)
+	}
+}
+
+func TestMigrator_CopyMigrationCSVKeepsBackslashN(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+	migrator := New(db)
+	migrator.Register(CopyMigration("1", "load countries",
+		CopySpec{Table: "countries", Columns: []string{"code", "name", "capital"}, Format: CopyCSV},
+		stringOpener("aq,Antarctica,\\N\n")))
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var capital sql.NullString
+	if err := db.QueryRow("SELECT capital FROM countries WHERE code = 'aq'").Scan(&capital); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if !capital.Valid || capital.String != `\N` {
+		t.Errorf("expected CSV \\N to load as text, got %+v", capital)
+	}
+}
+
+func TestMigrator_CopyFromFallsBackToInserts(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	migrator := New(db, WithDialect(DialectPostgres))
+	src := newCSVSource(strings.NewReader("fr\tFrance\tParis\nde\tGermany\tBerlin\n"), CopyTSV)
+	count, err := migrator.copyFrom(context.Background(), stdTx{tx: tx}, "countries", []string{"code", "name", "capital"}, src)
+	if err != nil || count != 2 {
+		t.Fatalf("expected inserts when COPY cannot be prepared, got %d (%v)", count, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if n := countRows(t, db, "countries"); n != 2 {
+		t.Errorf("expected 2 rows, got %d", n)
+	}
+}
+
+type copyingConn struct {
+	Conn
+	copied *int64
+}
+
+func (c copyingConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.Conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return copyingTx{Tx: tx, copied: c.copied}, nil
+}
+
+type copyingTx struct {
+	Tx
+	copied *int64
+}
+
+func (t copyingTx) CopyFrom(_ context.Context, _ string, _ []string, src CopySource) (int64, error) {
+	for src.Next() {
+		*t.copied++
+	}
+	return *t.copied, src.Err()
+}
+
+func TestMigrator_CopyMigrationUsesCopyFromer(t *testing.T) {
+	t.Parallel()
+
+	db := newCopyTestDB(t)
+	var copied int64
+	migrator := NewWithConn(copyingConn{Conn: stdConn{db: db}, copied: &copied})
+	migrator.Register(CopyMigration("1", "load countries",
+		CopySpec{Table: "countries", Columns: []string{"code", "name", "capital"}},
+		stringOpener("fr,France,Paris\nde,Germany,Berlin\n")))
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if copied != 2 {
+		t.Errorf("expected rows to go through CopyFrom, got %d", copied)
+	}
+	if n := countRows(t, db, "countries"); n != 0 {
+		t.Errorf("expected no INSERT fallback, got %d rows", n)
+	}
+}
@@ -0,0 +1,77 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Dependent interface {
+	DependsOn() []string
+}
+
+func dependenciesOf(migration Migration) []string {
+	if dependent, ok := migration.(Dependent); ok {
+		return dependent.DependsOn()
+	}
+	return nil
+}
+
+func (r *Migrator) orderByDependencies(pending []Migration, applied []MigrationStatus) ([]Migration, error) {
+	appliedMap := make(map[string]bool, len(applied))
+	for _, migration := range applied {
+		appliedMap[migration.ID] = true
+	}
+
+	selected := make(map[string]bool, len(pending))
+	for _, migration := range pending {
+		selected[migration.ID()] = true
+	}
+
+	for _, migration := range pending {
+		for _, dependency := range dependenciesOf(migration) {
+			if appliedMap[dependency] || selected[dependency] {
+				continue
+			}
+			if r.baseline != "" && r.compareIDs(dependency, r.baseline) <= 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s depends on %s", ErrMissingDependency, migration.ID(), dependency)
+		}
+	}
+
+	ordered := make([]Migration, 0, len(pending))
+	done := make(map[string]bool, len(pending))
+	remaining := pending
+	for len(remaining) > 0 {
+		var blocked []Migration
+		progressed := false
+		for _, migration := range remaining {
+			if !progressed && dependenciesDone(migration, selected, done) {
+				ordered = append(ordered, migration)
+				done[migration.ID()] = true
+				progressed = true
+				continue
+			}
+			blocked = append(blocked, migration)
+		}
+
+		if !progressed {
+			ids := make([]string, len(blocked))
+			for i, migration := range blocked {
+				ids[i] = migration.ID()
+			}
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(ids, ", "))
+		}
+		remaining = blocked
+	}
+	return ordered, nil
+}
+
+func dependenciesDone(migration Migration, selected, done map[string]bool) bool {
+	for _, dependency := range dependenciesOf(migration) {
+		if selected[dependency] && !done[dependency] {
+			return false
+		}
+	}
+	return true
+}
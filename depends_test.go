@@ -0,0 +1,77 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		CreateMigration("20240101_add_orders_user_fk", "orders reference users").
+			RawUp("CREATE TABLE orders (id INTEGER, user_id INTEGER REFERENCES team_users (id))").
+			RawUp("INSERT INTO orders SELECT 1, id FROM team_users").
+			DependsOn("20240201_create_team_users").
+			Build(),
+		CreateMigration("20240201_create_team_users", "create users").
+			RawUp("CREATE TABLE team_users (id INTEGER PRIMARY KEY)").
+			RawUp("INSERT INTO team_users VALUES (1)").
+			Build(),
+	)
+
+	events := migrator.Events()
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected dependency to be applied first, got %v", err)
+	}
+
+	var started []string
+	for len(events) > 0 {
+		if event := <-events; event.Kind == EventMigrationStarted {
+			started = append(started, event.MigrationID)
+		}
+	}
+	if len(started) != 2 || started[0] != "20240201_create_team_users" {
+		t.Errorf("expected topological order, got %v", started)
+	}
+}
+
+func TestOrderByDependencies_Errors(t *testing.T) {
+	t.Parallel()
+
+	migrator := New(nil)
+
+	_, err := migrator.orderByDependencies([]Migration{
+		CreateMigration("1", "a").DependsOn("missing").Build(),
+	}, nil)
+	if !errors.Is(err, ErrMissingDependency) {
+		t.Errorf("expected ErrMissingDependency, got %v", err)
+	}
+
+	_, err = migrator.orderByDependencies([]Migration{
+		CreateMigration("1", "a").DependsOn("2").Build(),
+		CreateMigration("2", "b").DependsOn("1").Build(),
+		CreateMigration("3", "c").Build(),
+	}, nil)
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle, got %v", err)
+	}
+
+	ordered, err := migrator.orderByDependencies([]Migration{
+		CreateMigration("2", "b").DependsOn("1").Build(),
+	}, []MigrationStatus{{ID: "1"}})
+	if err != nil || len(ordered) != 1 {
+		t.Errorf("expected applied dependency to be satisfied, got %v (%v)", ordered, err)
+	}
+}
@@ -0,0 +1,139 @@
+package migrator
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Dialect string
+
+const (
+	DialectGeneric  Dialect = ""
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+func (d Dialect) rebind(query string) string {
+	if d != DialectPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, ch := range query {
+		if ch != '?' {
+			b.WriteRune(ch)
+			continue
+		}
+		n++
+		b.WriteString("$")
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+func (d Dialect) quoteIdent(name string) string {
+	if d == DialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d Dialect) currentSchemaSQL() (string, error) {
+	switch d {
+	case DialectPostgres:
+		return "SHOW search_path", nil
+	case DialectMySQL:
+		return "SELECT DATABASE()", nil
+	default:
+		return "", ErrUnsupportedDialect
+	}
+}
+
+func (d Dialect) useSchemaSQL(schema string) (string, error) {
+	switch d {
+	case DialectPostgres:
+		return "SET search_path TO " + d.quoteIdent(schema), nil
+	case DialectMySQL:
+		return "USE " + d.quoteIdent(schema), nil
+	default:
+		return "", ErrUnsupportedDialect
+	}
+}
+
+func (d Dialect) restoreSchemaSQL(previous string) (string, error) {
+	switch d {
+	case DialectPostgres:
+		if previous == "" {
+			return "RESET search_path", nil
+		}
+		return "SET search_path TO " + previous, nil
+	case DialectMySQL:
+		return "USE " + d.quoteIdent(previous), nil
+	default:
+		return "", ErrUnsupportedDialect
+	}
+}
+
+func (d Dialect) supportsSavepoints() bool {
+	return d == DialectPostgres || d == DialectMySQL || d == DialectSQLite
+}
+
+type dialectVariant struct {
+	index   int
+	down    bool
+	dialect Dialect
+	query   string
+}
+
+type dialectRestricted interface {
+	unsupportedDialects() []Dialect
+}
+
+func checkDialect(migration Migration, dialect Dialect) error {
+	restricted, ok := migration.(dialectRestricted)
+	if !ok || !slices.Contains(restricted.unsupportedDialects(), dialect) {
+		return nil
+	}
+	return fmt.Errorf("%w: migration %s cannot run on %s", ErrUnsupportedDialect, migration.ID(), dialect)
+}
+
+type dialectSpecific interface {
+	dialectVariants() []dialectVariant
+}
+
+func queriesForDialect(migration Migration, dialect Dialect, direction Direction) []string {
+	queries := migration.Up()
+	if direction == DirectionDown {
+		queries = migration.Down()
+	}
+	if marked, ok := migration.(markedMigration); ok {
+		queries = marked.markedQueries(direction)
+	}
+	specific, ok := migration.(dialectSpecific)
+	if !ok {
+		return queries
+	}
+
+	var adapted []string
+	for _, variant := range specific.dialectVariants() {
+		index := variant.index
+		if variant.down {
+			index = len(queries) - 1 - variant.index
+		}
+		if variant.dialect != dialect || variant.down != (direction == DirectionDown) || index < 0 || index >= len(queries) {
+			continue
+		}
+		if adapted == nil {
+			adapted = slices.Clone(queries)
+		}
+		adapted[index] = variant.query
+	}
+	if adapted == nil {
+		return queries
+	}
+	return adapted
+}
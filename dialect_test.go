@@ -0,0 +1,61 @@
+package migrator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDialect_rebind(t *testing.T) {
+	t.Parallel()
+
+	query := "INSERT INTO schema_migrations (id, description, batch) VALUES (?, ?, ?)"
+
+	if got := DialectGeneric.rebind(query); got != query {
+		t.Errorf("expected generic query unchanged, got '%s'", got)
+	}
+
+	expected := "INSERT INTO schema_migrations (id, description, batch) VALUES ($1, $2, $3)"
+	if got := DialectPostgres.rebind(query); got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestDialect_quoteIdent(t *testing.T) {
+	t.Parallel()
+
+	if got := DialectPostgres.quoteIdent(`we"ird`); got != `"we""ird"` {
+		t.Errorf("unexpected postgres identifier: %s", got)
+	}
+	if got := DialectMySQL.quoteIdent("we`ird"); got != "`we``ird`" {
+		t.Errorf("unexpected mysql identifier: %s", got)
+	}
+}
+
+func TestDialect_useSchemaSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect  Dialect
+		expected string
+		err      error
+	}{
+		{dialect: DialectPostgres, expected: `SET search_path TO "tenant_1"`},
+		{dialect: DialectMySQL, expected: "USE `tenant_1`"},
+		{dialect: DialectSQLite, err: ErrUnsupportedDialect},
+		{dialect: DialectGeneric, err: ErrUnsupportedDialect},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.dialect.useSchemaSQL("tenant_1")
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%q: expected error %v, got %v", tt.dialect, tt.err, err)
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected '%s', got '%s'", tt.dialect, tt.expected, got)
+		}
+	}
+
+	if got, _ := DialectPostgres.restoreSchemaSQL(""); got != "RESET search_path" {
+		t.Errorf("expected empty search_path to be reset, got '%s'", got)
+	}
+}
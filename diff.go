@@ -0,0 +1,94 @@
+package migrator
+
+import (
+	"context"
+)
+
+type ColumnSchema struct {
+	Name       string
+	Definition string
+}
+
+type IndexSchema struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+type TableSchema struct {
+	Name        string
+	Columns     []ColumnSchema
+	Constraints []string
+	Indexes     []IndexSchema
+}
+
+type Schema struct {
+	Tables []TableSchema
+}
+
+func (r *Migrator) Diff(ctx context.Context, id, description string, desired Schema) (Migration, error) {
+	builder := CreateMigration(id, description)
+	changed := false
+
+	for _, table := range desired.Tables {
+		tableChanged, err := r.diffTable(ctx, builder, table)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || tableChanged
+	}
+
+	if !changed {
+		return nil, ErrNoSchemaChanges
+	}
+	return builder.Build(), nil
+}
+
+func (r *Migrator) diffTable(ctx context.Context, builder *MigrationBuilder, table TableSchema) (bool, error) {
+	exists, err := r.HasTable(ctx, table.Name)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	if !exists {
+		columns := make([]string, 0, len(table.Columns)+len(table.Constraints))
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name+" "+column.Definition)
+		}
+		columns = append(columns, table.Constraints...)
+		builder.CreateTable(table.Name, columns...)
+		changed = true
+	} else {
+		for _, column := range table.Columns {
+			hasColumn, err := r.HasColumn(ctx, table.Name, column.Name)
+			if err != nil {
+				return false, err
+			}
+			if !hasColumn {
+				builder.AddColumn(table.Name, column.Name+" "+column.Definition)
+				changed = true
+			}
+		}
+	}
+
+	for _, index := range table.Indexes {
+		hasIndex := false
+		if exists {
+			if hasIndex, err = r.HasIndex(ctx, table.Name, index.Name); err != nil {
+				return false, err
+			}
+		}
+		if hasIndex {
+			continue
+		}
+		if index.Unique {
+			builder.CreateUniqueIndex(index.Name, table.Name, index.Columns...)
+		} else {
+			builder.CreateIndex(index.Name, table.Name, index.Columns...)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
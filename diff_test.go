@@ -0,0 +1,67 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Diff(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY)")
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	desired := Schema{Tables: []TableSchema{
+		{
+			Name:    "users",
+			Columns: []ColumnSchema{{Name: "id", Definition: "INTEGER PRIMARY KEY"}, {Name: "email", Definition: "TEXT"}},
+			Indexes: []IndexSchema{{Name: "idx_users_email", Columns: []string{"email"}, Unique: true}},
+		},
+		{
+			Name:    "posts",
+			Columns: []ColumnSchema{{Name: "id", Definition: "INTEGER PRIMARY KEY"}},
+		},
+	}}
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migration, err := migrator.Diff(context.Background(), "002", "sync schema", desired)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectedUp := []string{
+		"ALTER TABLE users ADD COLUMN email TEXT;",
+		"CREATE UNIQUE INDEX idx_users_email ON users (email);",
+		"CREATE TABLE IF NOT EXISTS posts (\n    id INTEGER PRIMARY KEY\n);",
+	}
+	if len(migration.Up()) != len(expectedUp) {
+		t.Fatalf("expected %d up queries, got %q", len(expectedUp), migration.Up())
+	}
+	for i, query := range expectedUp {
+		if migration.Up()[i] != query {
+			t.Errorf("expected up query %d to be %q, got %q", i, query, migration.Up()[i])
+		}
+	}
+
+	migrator.Register(migration)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply generated migration: %v", err)
+	}
+
+	if _, err := migrator.Diff(context.Background(), "003", "noop", desired); !errors.Is(err, ErrNoSchemaChanges) {
+		t.Errorf("expected ErrNoSchemaChanges, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dirtyTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_dirty (
+    module VARCHAR(255) NOT NULL,
+    id VARCHAR(255) NOT NULL,
+    direction VARCHAR(16) NOT NULL,
+    error TEXT NOT NULL,
+    failed_at BIGINT NOT NULL,
+    PRIMARY KEY (module)
+);
+`
+
+var ddlKeywords = map[string]bool{
+	"ALTER": true, "CREATE": true, "DROP": true, "RENAME": true, "TRUNCATE": true,
+}
+
+type DirtyState struct {
+	ID        string
+	Direction Direction
+	Error     string
+	FailedAt  time.Time
+}
+
+func (r *Migrator) Dirty(ctx context.Context) (*DirtyState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.createMigrationTable(); err != nil {
+		return nil, err
+	}
+	return r.loadDirty(ctx)
+}
+
+func (r *Migrator) ForceClean(ctx context.Context, version string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.createMigrationTable(); err != nil {
+		return err
+	}
+
+	state, err := r.loadDirty(ctx)
+	if err != nil || state == nil {
+		return err
+	}
+	if state.ID != version {
+		return fmt.Errorf("%w: database is dirty at %s, not %s", ErrDirtyVersionMismatch, state.ID, version)
+	}
+
+	_, err = r.conn.Exec(ctx, r.query("DELETE FROM %s_dirty WHERE module = ? AND id = ?"), r.module(), version)
+	return err
+}
+
+func (r *Migrator) checkDirty(ctx context.Context) error {
+	state, err := r.loadDirty(ctx)
+	if err != nil || state == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s failed while migrating %s: %s", ErrDirtyDatabase, state.ID, state.Direction, state.Error)
+}
+
+func (r *Migrator) loadDirty(ctx context.Context) (*DirtyState, error) {
+	rows, err := r.conn.Query(ctx, r.query("SELECT id, direction, error, failed_at FROM %s_dirty WHERE module = ?"), r.module())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var state DirtyState
+	var failedAt int64
+	if err := rows.Scan(&state.ID, &state.Direction, &state.Error, &failedAt); err != nil {
+		return nil, err
+	}
+	state.FailedAt = time.UnixMilli(failedAt)
+	return &state, rows.Err()
+}
+
+func (r *Migrator) markDirty(ctx context.Context, migration Migration, direction Direction, cause error) error {
+	if migration == nil || r.executed[migration.ID()] == 0 || !r.leavesPartialState(migration, direction) {
+		return nil
+	}
+
+	_, err := r.conn.Exec(ctx,
+		r.query("INSERT INTO %s_dirty (module, id, direction, error, failed_at) VALUES (?, ?, ?, ?, ?)"),
+		r.module(), migration.ID(), string(direction), cause.Error(), r.now().UnixMilli())
+	return err
+}
+
+func (r *Migrator) leavesPartialState(migration Migration, direction Direction) bool {
+	if !r.isTransactional(migration) {
+		return true
+	}
+	if r.dialect != DialectMySQL {
+		return false
+	}
+
+	queries, err := r.upQueries(migration)
+	if direction == DirectionDown {
+		queries, err = r.downQueries(migration)
+	}
+	if err != nil {
+		return false
+	}
+	for _, query := range queries {
+		for _, statement := range SplitStatements(query) {
+			if tokens := tokenizeSQL(statement); len(tokens) > 0 && ddlKeywords[strings.ToUpper(tokens[0])] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Migrator) recordDirty(ctx context.Context, migrationMap map[string]Migration, direction Direction, result *Result, err error) error {
+	if result.FailedID == "" {
+		return err
+	}
+	if dirtyErr := r.markDirty(ctx, migrationMap[result.FailedID], direction, err); dirtyErr != nil {
+		return errors.Join(err, dirtyErr)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_DirtyState(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&nonTransactionalMigration{mockMigration{id: "2", upQueries: []string{
+			"CREATE TABLE audit (id INTEGER)",
+			"CREATE INDEX idx ON missing (id)",
+		}}},
+	)
+
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected the non-transactional migration to fail")
+	}
+
+	state, err := migrator.Dirty(ctx)
+	if err != nil {
+		t.Fatalf("failed to read dirty state: %v", err)
+	}
+	if state == nil || state.ID != "2" || state.Direction != DirectionUp {
+		t.Fatalf("expected migration 2 to be marked dirty, got %+v", state)
+	}
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrDirtyDatabase) {
+		t.Fatalf("expected ErrDirtyDatabase, got %v", err)
+	}
+	if _, err := migrator.Down(1); !errors.Is(err, ErrDirtyDatabase) {
+		t.Fatalf("expected ErrDirtyDatabase on down, got %v", err)
+	}
+
+	if err := migrator.ForceClean(ctx, "1"); !errors.Is(err, ErrDirtyVersionMismatch) {
+		t.Fatalf("expected ErrDirtyVersionMismatch, got %v", err)
+	}
+
+	if _, err := db.Exec("DROP TABLE audit"); err != nil {
+		t.Fatalf("failed to clean up partial state: %v", err)
+	}
+	if err := migrator.ForceClean(ctx, "2"); err != nil {
+		t.Fatalf("failed to force clean: %v", err)
+	}
+	if state, err := migrator.Dirty(ctx); err != nil || state != nil {
+		t.Fatalf("expected clean state, got %+v, %v", state, err)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected down to run after force clean, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+func TestMigrator_DirtyState_TransactionalFailure(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE INDEX idx ON missing (id)"}})
+
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected migration to fail")
+	}
+	if state, err := migrator.Dirty(context.Background()); err != nil || state != nil {
+		t.Fatalf("expected rolled back transaction to leave a clean state, got %+v, %v", state, err)
+	}
+}
@@ -0,0 +1,79 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+)
+
+type ExecResult interface {
+	RowsAffected() (int64, error)
+}
+
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+type Executor interface {
+	Exec(ctx context.Context, query string, args ...any) (ExecResult, error)
+	Query(ctx context.Context, query string, args ...any) (Rows, error)
+}
+
+type Tx interface {
+	Executor
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+type Conn interface {
+	Executor
+	Begin(ctx context.Context) (Tx, error)
+}
+
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+type stdConn struct {
+	db DBTX
+}
+
+func (c stdConn) Exec(ctx context.Context, query string, args ...any) (ExecResult, error) {
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+func (c stdConn) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+func (c stdConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return stdTx{tx: tx}, nil
+}
+
+type stdTx struct {
+	tx *sql.Tx
+}
+
+func (t stdTx) Exec(ctx context.Context, query string, args ...any) (ExecResult, error) {
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+func (t stdTx) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	return t.tx.QueryContext(ctx, query, args...)
+}
+
+func (t stdTx) Commit(context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t stdTx) Rollback(context.Context) error {
+	return t.tx.Rollback()
+}
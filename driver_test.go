@@ -0,0 +1,160 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+type countingConn struct {
+	Conn
+	execs   int
+	queries int
+	begins  int
+}
+
+func (c *countingConn) Exec(ctx context.Context, query string, args ...any) (ExecResult, error) {
+	c.execs++
+	return c.Conn.Exec(ctx, query, args...)
+}
+
+func (c *countingConn) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	c.queries++
+	return c.Conn.Query(ctx, query, args...)
+}
+
+func (c *countingConn) Begin(ctx context.Context) (Tx, error) {
+	c.begins++
+	return c.Conn.Begin(ctx)
+}
+
+func TestMigrator_NewWithConn(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	conn := &countingConn{Conn: stdConn{db: db}}
+	migrator := NewWithConn(conn)
+	migrator.Register(&mockMigration{
+		id:          "1",
+		description: "create users table",
+		upQueries:   []string{"CREATE TABLE users (id INTEGER PRIMARY KEY)"},
+		downQueries: []string{"DROP TABLE users"},
+	})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if conn.begins != 2 {
+		t.Errorf("expected 2 transactions, got %d", conn.begins)
+	}
+	if conn.queries == 0 {
+		t.Error("expected history to be read through the connection")
+	}
+	if conn.execs == 0 {
+		t.Error("expected schema statements to go through the connection")
+	}
+}
+
+func TestStdTx_CommitAndRollback(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	conn := stdConn{db: db}
+	ctx := context.Background()
+	if _, err := conn.Exec(ctx, "CREATE TABLE items (id INTEGER)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "INSERT INTO items (id) VALUES (?)", 1); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("failed to rollback: %v", err)
+	}
+
+	tx, err = conn.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "INSERT INTO items (id) VALUES (?)", 2); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT id FROM items")
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("expected only committed row 2, got %v", ids)
+	}
+}
+
+type recordingDB struct {
+	*sql.DB
+	statements []string
+}
+
+func (d *recordingDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	d.statements = append(d.statements, query)
+	return d.DB.ExecContext(ctx, query, args...)
+}
+
+func TestMigrator_New_WrappedDB(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	wrapped := &recordingDB{DB: db}
+	migrator := New(wrapped)
+	if _, err := migrator.Status(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(wrapped.statements) == 0 {
+		t.Error("expected statements to be executed through the wrapped handle")
+	}
+}
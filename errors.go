@@ -3,11 +3,84 @@ package migrator
 import "errors"
 
 var (
-	ErrMigrationFailed                     = errors.New("database migration failed")
-	ErrFailedToCreateSchemaMigrationsTable = errors.New("failed to create schema_migrations table")
-	ErrFailedToCreateSchemaMigrationsIndex = errors.New("failed to create index on schema_migrations table")
-	ErrFailedToGetAppliedMigrations        = errors.New("failed to fetch applied migrations")
-	ErrFailedToBeginTransaction            = errors.New("failed to begin database transaction")
-	ErrNoMigrationsToRollback              = errors.New("no applied migrations to rollback")
-	ErrFailedToExecuteQuery                = errors.New("failed to execute database query")
+	ErrMigrationFailed                      = errors.New("database migration failed")
+	ErrFailedToCreateSchemaMigrationsTable  = errors.New("failed to create schema_migrations table")
+	ErrFailedToCreateSchemaMigrationsIndex  = errors.New("failed to create index on schema_migrations table")
+	ErrFailedToUpgradeSchemaMigrationsTable = errors.New("failed to upgrade schema_migrations table")
+	ErrFailedToWriteAuditLog                = errors.New("failed to write migration audit log")
+	ErrFailedToGetAppliedMigrations         = errors.New("failed to fetch applied migrations")
+	ErrFailedToBeginTransaction             = errors.New("failed to begin database transaction")
+	ErrNoMigrationsToRollback               = errors.New("no applied migrations to rollback")
+	ErrFailedToExecuteQuery                 = errors.New("failed to execute database query")
+	ErrUnsupportedDialect                   = errors.New("operation is not supported by the database dialect")
+	ErrFailedToListTenants                  = errors.New("failed to list tenants")
+	ErrFailedToSwitchTenant                 = errors.New("failed to switch to tenant schema")
+	ErrAppliedMigrationModified             = errors.New("applied migration has been modified")
+	ErrAppliedMigrationMissing              = errors.New("applied migration is no longer registered")
+	ErrIrreversibleMigration                = errors.New("migration has no executable down queries")
+	ErrPartialFailure                       = errors.New("some migrations failed and were skipped")
+	ErrMigrationGap                         = errors.New("registered migrations older than the newest applied one were never applied")
+	ErrFailedToRenderTemplate               = errors.New("failed to render migration template")
+	ErrInvalidColumnChange                  = errors.New("invalid column change")
+	ErrInvalidIndex                         = errors.New("index was left invalid after concurrent build")
+	ErrEstimateExceeded                     = errors.New("statement is estimated to touch more rows than allowed")
+	ErrReadOnlyDatabase                     = errors.New("database is read-only or a replica in recovery")
+	ErrFailedToPruneHistory                 = errors.New("failed to prune migration history")
+	ErrFailedToDropTables                   = errors.New("failed to drop tables")
+	ErrMissingDependency                    = errors.New("migration depends on a migration that is neither applied nor pending")
+	ErrDependencyCycle                      = errors.New("migration dependencies form a cycle")
+	ErrDuplicateMigrationID                 = errors.New("duplicate migration id")
+	ErrInvalidMigrationName                 = errors.New("invalid migration name")
+	ErrUnsupportedScaffoldFormat            = errors.New("unsupported scaffold format")
+	ErrFailedToAcquireLock                  = errors.New("failed to acquire migration lock")
+	ErrFailedToReleaseLock                  = errors.New("failed to release migration lock")
+	ErrLockNotConfigured                    = errors.New("lock table is not configured")
+	ErrLockLost                             = errors.New("migration lock was taken over by another process")
+	ErrInvalidCopyData                      = errors.New("copy data does not match the target columns")
+	ErrFailedToLoadFixtures                 = errors.New("failed to load fixtures")
+	ErrInvalidFixture                       = errors.New("fixture file must contain a list of rows")
+	ErrFailedToBootstrapSchema              = errors.New("failed to bootstrap schema from cache")
+	ErrCheckFailed                          = errors.New("post-migration check failed")
+	ErrPreflightFailed                      = errors.New("pre-flight checks failed")
+	ErrFailedToGenerateScript               = errors.New("failed to generate migration script")
+	ErrInvalidSQL                           = errors.New("invalid SQL statement")
+	ErrNotConfirmed                         = errors.New("destructive migration was not confirmed")
+	ErrBackupFailed                         = errors.New("backup before destructive migration failed")
+	ErrFailedToFetchSource                  = errors.New("failed to fetch migration source")
+	ErrInvalidSource                        = errors.New("invalid migration source")
+	ErrInvalidMigrationFileName             = errors.New("migration file name must look like <id>_<description>.up.sql")
+	ErrInvalidSchema                        = errors.New("invalid schema definition")
+	ErrNoSchemaChanges                      = errors.New("database schema already matches the desired schema")
+	ErrPendingMigrations                    = errors.New("database has pending migrations")
+	ErrFailedToBroadcast                    = errors.New("failed to broadcast schema version")
+	ErrWebhookFailed                        = errors.New("webhook notification failed")
+	ErrFleetPrepareFailed                   = errors.New("fleet migration aborted before applying: a shard failed to plan")
+	ErrFleetRolledBack                      = errors.New("fleet migration failed and applied shards were rolled back")
+	ErrShardSkipped                         = errors.New("shard skipped after an earlier failure")
+	ErrDirtyDatabase                        = errors.New("database is dirty after a partially applied migration; resolve it manually and call ForceClean")
+	ErrDirtyVersionMismatch                 = errors.New("version does not match the dirty migration")
+	ErrAfterMigrateFailed                   = errors.New("after-migrate callback failed")
+	ErrSourceMismatch                       = errors.New("registered migrations do not match the migration source")
+	ErrFailedToExportHistory                = errors.New("failed to export migration history")
+	ErrFailedToImportHistory                = errors.New("failed to import migration history")
+	ErrInvalidHistoryExport                 = errors.New("invalid migration history export")
+	ErrFailedToResolveSecret                = errors.New("failed to resolve secret")
+	ErrInvalidPlanFile                      = errors.New("invalid saved plan file")
+	ErrPlanMismatch                         = errors.New("registry or database changed since the plan was saved")
+	ErrProtectedEnvironment                 = errors.New("operation is not allowed in a protected environment without AllowDestructive or a confirmation callback")
+	ErrProtectedMigration                   = errors.New("rollback would include protected migrations")
+	ErrBlockingOperation                    = errors.New("migration contains blocking operations; pass AllowBlocking to run them")
+	ErrInvalidMaintenanceWindow             = errors.New("invalid maintenance window")
+	ErrOutsideMaintenanceWindow             = errors.New("blocking or destructive migrations are only allowed inside a maintenance window")
+	ErrStatementRejected                    = errors.New("statement rejected by interceptor")
+	ErrFailedToUpdateSchemaVersion          = errors.New("failed to update schema_version table")
+	ErrVersionTableDisabled                 = errors.New("schema_version table is not enabled")
+	ErrFailedToWriteIntent                  = errors.New("failed to write migration intent")
+	ErrFailedToCaptureColumnState           = errors.New("failed to capture column state")
+	ErrIndexNotFound                        = errors.New("index not found")
+	ErrSharedHistoryTable                   = errors.New("history table is shared with other namespaces")
+	ErrLegacyHistoryTable                   = errors.New("history table still uses the legacy primary key on id")
+	ErrUnboundedChunk                       = errors.New("chunked statement has no LIMIT")
+	ErrChunkLimitExceeded                   = errors.New("chunked statement did not finish within the chunk limit")
+	ErrUnappliedBelowBaseline               = errors.New("registered migrations below the prune baseline were never applied")
 )
@@ -0,0 +1,69 @@
+package migrator
+
+import (
+	"time"
+)
+
+const eventBufferSize = 256
+
+type EventKind string
+
+const (
+	EventMigrationStarted  EventKind = "migration_started"
+	EventStatementStarted  EventKind = "statement_started"
+	EventStatementExecuted EventKind = "statement_executed"
+	EventBatchCommitted    EventKind = "batch_committed"
+	EventBatchRolledBack   EventKind = "batch_rolled_back"
+	EventThrottled         EventKind = "throttled"
+)
+
+type Event struct {
+	Kind           EventKind
+	Direction      Direction
+	Batch          int
+	MigrationID    string
+	Statement      string
+	StatementIndex int
+	StatementTotal int
+	RowsAffected   int64
+	Duration       time.Duration
+	Err            error
+	Time           time.Time
+}
+
+func (r *Migrator) Events() <-chan Event {
+	r.eventsMu.Lock()
+	defer r.eventsMu.Unlock()
+
+	if r.events == nil {
+		r.events = make(chan Event, eventBufferSize)
+	}
+	return r.events
+}
+
+func (r *Migrator) emit(event Event) {
+	r.eventsMu.Lock()
+	defer r.eventsMu.Unlock()
+
+	if r.events == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	select {
+	case r.events <- event:
+	default:
+	}
+}
+
+func newStatementEvent(direction Direction, batch int, migrationID, query string, started time.Time, err error) Event {
+	return Event{
+		Kind:        EventStatementExecuted,
+		Direction:   direction,
+		Batch:       batch,
+		MigrationID: migrationID,
+		Statement:   query,
+		Duration:    time.Since(started),
+		Err:         err,
+	}
+}
@@ -0,0 +1,117 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func drainEvents(events <-chan Event) []Event {
+	var drained []Event
+	for {
+		select {
+		case event := <-events:
+			drained = append(drained, event)
+		default:
+			return drained
+		}
+	}
+}
+
+func TestMigrator_Events(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	migrator := New(db)
+	events := migrator.Events()
+	migrator.Register(&mockMigration{
+		id:          "1",
+		description: "create users table",
+		upQueries:   []string{"CREATE TABLE users (id INTEGER PRIMARY KEY)", "CREATE INDEX idx_users_id ON users (id)"},
+	})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	expected := []EventKind{
+		EventMigrationStarted,
+		EventStatementStarted,
+		EventStatementExecuted,
+		EventStatementStarted,
+		EventStatementExecuted,
+		EventBatchCommitted,
+	}
+	received := drainEvents(events)
+	if len(received) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expected), len(received), received)
+	}
+	for i, kind := range expected {
+		if received[i].Kind != kind {
+			t.Errorf("expected event %d to be %s, got %s", i, kind, received[i].Kind)
+		}
+		if received[i].Batch != 1 || received[i].Direction != DirectionUp {
+			t.Errorf("expected event %d to belong to up batch 1, got %+v", i, received[i])
+		}
+	}
+	if received[2].Statement != "CREATE TABLE users (id INTEGER PRIMARY KEY)" || received[2].MigrationID != "1" {
+		t.Errorf("unexpected statement event: %+v", received[2])
+	}
+	if received[3].StatementIndex != 2 || received[3].StatementTotal != 2 || received[3].Statement != "CREATE INDEX idx_users_id ON users (id)" {
+		t.Errorf("unexpected statement started event: %+v", received[3])
+	}
+}
+
+func TestMigrator_Events_RolledBack(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	migrator := New(db)
+	events := migrator.Events()
+	migrator.Register(&mockMigration{
+		id:          "1",
+		description: "broken",
+		upQueries:   []string{"INVALID SQL STATEMENT"},
+	})
+
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	received := drainEvents(events)
+	if len(received) == 0 {
+		t.Fatal("expected events, got none")
+	}
+
+	last := received[len(received)-1]
+	if last.Kind != EventBatchRolledBack || last.MigrationID != "1" {
+		t.Errorf("expected rollback event for migration 1, got %+v", last)
+	}
+	if received[len(received)-2].Err == nil {
+		t.Error("expected failed statement event to carry the error")
+	}
+}
+
+func TestMigrator_emit_WithoutSubscriber(t *testing.T) {
+	t.Parallel()
+
+	migrator := &Migrator{}
+	migrator.emit(Event{Kind: EventBatchCommitted})
+
+	if migrator.events != nil {
+		t.Error("expected no channel to be allocated without a subscriber")
+	}
+}
@@ -0,0 +1,153 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	PhaseExpand   = "expand"
+	PhaseBackfill = "backfill"
+	PhaseContract = "contract"
+)
+
+type ColumnChange struct {
+	Table         string
+	Column        string
+	NewName       string
+	Definition    string
+	Using         string
+	OldDefinition string
+}
+
+func ExpandContractColumn(id string, dialect Dialect, change ColumnChange) ([]Migration, error) {
+	if change.Table == "" || change.Column == "" || change.Definition == "" {
+		return nil, fmt.Errorf("%w: table, column and definition are required", ErrInvalidColumnChange)
+	}
+	if change.NewName == change.Column {
+		return nil, fmt.Errorf("%w: new name must differ from the column name", ErrInvalidColumnChange)
+	}
+
+	target := change.NewName
+	if target == "" {
+		target = change.Column + "_new"
+	}
+	using := change.Using
+	if using == "" {
+		using = change.Column
+	}
+
+	table := identifier(change.Table)
+	trigger, err := syncTrigger(dialect, table, change.Column, target, using)
+	if err != nil {
+		return nil, err
+	}
+
+	expand := CreateMigration(id+"_1_expand", fmt.Sprintf("expand %s.%s into %s", change.Table, change.Column, target)).
+		AddColumn(change.Table, target+" "+change.Definition).
+		Tags(PhaseExpand)
+	rawUp(expand, trigger.up)
+	rawDown(expand, trigger.down)
+
+	backfill := CreateMigration(id+"_2_backfill", fmt.Sprintf("backfill %s.%s", change.Table, target)).
+		Raw(
+			fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IS NULL;", table, target, using, target),
+			fmt.Sprintf("-- Backfill of %s.%s is undone by dropping the column", change.Table, target),
+		).
+		Tags(PhaseBackfill)
+
+	contract := CreateMigration(id+"_3_contract", fmt.Sprintf("contract %s.%s", change.Table, change.Column)).
+		Tags(PhaseContract)
+	rawUp(contract, trigger.down)
+	contract.RawUp(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, change.Column))
+	if change.NewName == "" {
+		contract.RawUp(fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", table, target, change.Column))
+	}
+	rawDown(contract, contractDown(change, target, trigger.up))
+
+	return []Migration{expand.Build(), backfill.Build(), contract.Build()}, nil
+}
+
+func contractDown(change ColumnChange, target string, triggerUp []string) []string {
+	if change.OldDefinition == "" {
+		return []string{fmt.Sprintf("-- Cannot restore %s.%s without its old definition", change.Table, change.Column)}
+	}
+
+	table := identifier(change.Table)
+	var queries []string
+	if change.NewName == "" {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", table, change.Column, target))
+	}
+	queries = append(queries,
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, change.Column, change.OldDefinition),
+		fmt.Sprintf("UPDATE %s SET %s = %s;", table, change.Column, target),
+	)
+	return append(queries, triggerUp...)
+}
+
+func rawUp(b *MigrationBuilder, queries []string) {
+	for _, query := range queries {
+		b.RawUp(query)
+	}
+}
+
+func rawDown(b *MigrationBuilder, queries []string) {
+	for i := len(queries) - 1; i >= 0; i-- {
+		b.RawDown(queries[i])
+	}
+}
+
+type syncTriggerSQL struct {
+	up   []string
+	down []string
+}
+
+func syncTrigger(dialect Dialect, table, source, target, using string) (syncTriggerSQL, error) {
+	name := fmt.Sprintf("%s_%s_sync", table, target)
+
+	switch dialect {
+	case DialectPostgres:
+		return syncTriggerSQL{
+			up: []string{
+				fmt.Sprintf("CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$ BEGIN NEW.%s := %s; RETURN NEW; END; $$ LANGUAGE plpgsql;",
+					name, target, qualifyNew(using, source)),
+				fmt.Sprintf("CREATE TRIGGER %s BEFORE INSERT OR UPDATE ON %s FOR EACH ROW EXECUTE FUNCTION %s();", name, table, name),
+			},
+			down: []string{
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;", name, table),
+				fmt.Sprintf("DROP FUNCTION IF EXISTS %s();", name),
+			},
+		}, nil
+	case DialectMySQL:
+		return syncTriggerSQL{
+			up: []string{
+				fmt.Sprintf("CREATE TRIGGER %s_insert BEFORE INSERT ON %s FOR EACH ROW SET NEW.%s = %s;", name, table, target, qualifyNew(using, source)),
+				fmt.Sprintf("CREATE TRIGGER %s_update BEFORE UPDATE ON %s FOR EACH ROW SET NEW.%s = %s;", name, table, target, qualifyNew(using, source)),
+			},
+			down: []string{
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_insert;", name),
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_update;", name),
+			},
+		}, nil
+	case DialectSQLite:
+		return syncTriggerSQL{
+			up: []string{
+				fmt.Sprintf("CREATE TRIGGER %s_insert AFTER INSERT ON %s BEGIN UPDATE %s SET %s = %s WHERE rowid = NEW.rowid; END;",
+					name, table, table, target, using),
+				fmt.Sprintf("CREATE TRIGGER %s_update AFTER UPDATE OF %s ON %s BEGIN UPDATE %s SET %s = %s WHERE rowid = NEW.rowid; END;",
+					name, source, table, table, target, using),
+			},
+			down: []string{
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_insert;", name),
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_update;", name),
+			},
+		}, nil
+	default:
+		return syncTriggerSQL{}, ErrUnsupportedDialect
+	}
+}
+
+func qualifyNew(expression, column string) string {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(column) + `\b`)
+	return pattern.ReplaceAllString(expression, "NEW."+column)
+}
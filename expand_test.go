@@ -0,0 +1,128 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExpandContractColumn_Rename(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO users (id, name) VALUES (1, 'old row')"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	migrations, err := ExpandContractColumn("002", DialectSQLite, ColumnChange{
+		Table:         "users",
+		Column:        "name",
+		NewName:       "full_name",
+		Definition:    "TEXT",
+		OldDefinition: "TEXT",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("expected 3 phases, got %d", len(migrations))
+	}
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(migrations...)
+
+	if _, err := migrator.Up(WithTags("!" + PhaseContract)); err != nil {
+		t.Fatalf("expected expand and backfill to apply, got %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO users (id, name) VALUES (2, 'dual write')"); err != nil {
+		t.Fatalf("failed to insert through old column: %v", err)
+	}
+
+	var names []string
+	rows, err := db.Query("SELECT full_name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("failed to query new column: %v", err)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		names = append(names, name)
+	}
+	_ = rows.Close()
+	if strings.Join(names, ",") != "old row,dual write" {
+		t.Errorf("expected backfilled and trigger-synced values, got %v", names)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected contract to apply, got %v", err)
+	}
+	if _, err := db.Exec("SELECT name FROM users"); err == nil {
+		t.Error("expected old column to be dropped")
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected contract to be reversible, got %v", err)
+	}
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = 1").Scan(&name); err != nil || name != "old row" {
+		t.Errorf("expected old column to be restored, got %q (%v)", name, err)
+	}
+}
+
+func TestExpandContractColumn_TypeChange(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := ExpandContractColumn("003", DialectPostgres, ColumnChange{
+		Table:      "orders",
+		Column:     "amount",
+		Definition: "NUMERIC(12,2)",
+		Using:      "amount::NUMERIC(12,2)",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ids := []string{migrations[0].ID(), migrations[1].ID(), migrations[2].ID()}
+	if strings.Join(ids, ",") != "003_1_expand,003_2_backfill,003_3_contract" {
+		t.Errorf("unexpected phase ids: %v", ids)
+	}
+
+	expand := strings.Join(migrations[0].Up(), "\n")
+	if !strings.Contains(expand, "ADD COLUMN amount_new NUMERIC(12,2)") || !strings.Contains(expand, "NEW.amount_new := NEW.amount::NUMERIC(12,2)") {
+		t.Errorf("unexpected expand phase:\n%s", expand)
+	}
+
+	contract := migrations[2].Up()
+	if contract[len(contract)-1] != "ALTER TABLE orders RENAME COLUMN amount_new TO amount;" {
+		t.Errorf("expected contract to swap the new column in, got %q", contract)
+	}
+	if isReversible(migrations[2]) {
+		t.Error("expected contract without old definition to be irreversible")
+	}
+}
+
+func TestExpandContractColumn_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ExpandContractColumn("1", DialectSQLite, ColumnChange{Table: "t"}); !errors.Is(err, ErrInvalidColumnChange) {
+		t.Errorf("expected ErrInvalidColumnChange, got %v", err)
+	}
+	change := ColumnChange{Table: "t", Column: "a", Definition: "TEXT"}
+	if _, err := ExpandContractColumn("1", DialectGeneric, change); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
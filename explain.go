@@ -0,0 +1,226 @@
+package migrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type StatementEstimate struct {
+	MigrationID string
+	Statement   string
+	Rows        int64
+	Cost        float64
+	FullScan    bool
+	Err         error
+}
+
+func (e StatementEstimate) exceeds(limit int64) bool {
+	if e.Err != nil {
+		return false
+	}
+	if e.Rows < 0 {
+		return e.FullScan
+	}
+	return e.Rows > limit
+}
+
+func AllowLargeRewrites() RunOption {
+	return func(c *runConfig) {
+		c.allowLargeRewrites = true
+	}
+}
+
+func (r *Migrator) Explain(ctx context.Context) ([]StatementEstimate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	return r.explain(ctx, r.pendingMigrations(applied))
+}
+
+func (r *Migrator) explain(ctx context.Context, migrations []Migration) ([]StatementEstimate, error) {
+	if r.dialect == DialectGeneric {
+		return nil, ErrUnsupportedDialect
+	}
+
+	var estimates []StatementEstimate
+	for _, migration := range migrations {
+		queries, err := r.upQueries(migration)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, query := range queries {
+			if !isDataModifying(query) {
+				continue
+			}
+
+			estimate := StatementEstimate{MigrationID: migration.ID(), Statement: query, Rows: -1}
+			estimate.Err = r.estimate(ctx, &estimate)
+			estimates = append(estimates, estimate)
+		}
+	}
+	return estimates, nil
+}
+
+func (r *Migrator) checkEstimates(ctx context.Context, migrations []Migration) error {
+	estimates, err := r.explain(ctx, migrations)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, estimate := range estimates {
+		if estimate.exceeds(r.explainLimit) {
+			errs = append(errs, fmt.Errorf("%w: %s: %s (rows: %d, full scan: %t)",
+				ErrEstimateExceeded, estimate.MigrationID, estimate.Statement, estimate.Rows, estimate.FullScan))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isDataModifying(query string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(query))
+	return strings.HasPrefix(upper, "UPDATE") || strings.HasPrefix(upper, "DELETE")
+}
+
+func (r *Migrator) estimate(ctx context.Context, estimate *StatementEstimate) error {
+	statement := strings.TrimSuffix(strings.TrimSpace(estimate.Statement), ";")
+
+	switch r.dialect {
+	case DialectPostgres:
+		plan, err := r.explainJSON(ctx, "EXPLAIN (FORMAT JSON) "+statement)
+		if err != nil {
+			return err
+		}
+		return parsePostgresPlan(plan, estimate)
+	case DialectMySQL:
+		plan, err := r.explainJSON(ctx, "EXPLAIN FORMAT=JSON "+statement)
+		if err != nil {
+			return err
+		}
+		return parseMySQLPlan(plan, estimate)
+	case DialectSQLite:
+		return r.explainSQLite(ctx, statement, estimate)
+	default:
+		return ErrUnsupportedDialect
+	}
+}
+
+func (r *Migrator) explainJSON(ctx context.Context, query string) ([]byte, error) {
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var plan []byte
+	if rows.Next() {
+		if err := rows.Scan(&plan); err != nil {
+			return nil, err
+		}
+	}
+	return plan, rows.Err()
+}
+
+type postgresPlanNode struct {
+	NodeType  string             `json:"Node Type"`
+	PlanRows  float64            `json:"Plan Rows"`
+	TotalCost float64            `json:"Total Cost"`
+	Plans     []postgresPlanNode `json:"Plans"`
+}
+
+func (n postgresPlanNode) seqScan() bool {
+	if n.NodeType == "Seq Scan" {
+		return true
+	}
+	for _, child := range n.Plans {
+		if child.seqScan() {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePostgresPlan(plan []byte, estimate *StatementEstimate) error {
+	var explained []struct {
+		Plan postgresPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return err
+	}
+	if len(explained) == 0 {
+		return nil
+	}
+
+	root := explained[0].Plan
+	node := root
+	for node.PlanRows == 0 && len(node.Plans) == 1 {
+		node = node.Plans[0]
+	}
+	estimate.Rows = int64(node.PlanRows)
+	estimate.Cost = root.TotalCost
+	estimate.FullScan = root.seqScan()
+	return nil
+}
+
+func parseMySQLPlan(plan []byte, estimate *StatementEstimate) error {
+	var explained struct {
+		QueryBlock struct {
+			CostInfo struct {
+				QueryCost string `json:"query_cost"`
+			} `json:"cost_info"`
+			Table struct {
+				AccessType          string `json:"access_type"`
+				RowsExaminedPerScan int64  `json:"rows_examined_per_scan"`
+			} `json:"table"`
+		} `json:"query_block"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return err
+	}
+
+	block := explained.QueryBlock
+	estimate.Rows = block.Table.RowsExaminedPerScan
+	estimate.FullScan = block.Table.AccessType == "ALL"
+	if block.CostInfo.QueryCost != "" {
+		cost, err := strconv.ParseFloat(block.CostInfo.QueryCost, 64)
+		if err != nil {
+			return err
+		}
+		estimate.Cost = cost
+	}
+	return nil
+}
+
+func (r *Migrator) explainSQLite(ctx context.Context, statement string, estimate *StatementEstimate) error {
+	rows, err := r.conn.Query(ctx, "EXPLAIN QUERY PLAN "+statement)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return err
+		}
+		if strings.HasPrefix(detail, "SCAN ") {
+			estimate.FullScan = true
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,125 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Explain(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, active INTEGER)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{
+		"CREATE INDEX idx_users_active ON users (active)",
+		"UPDATE users SET active = 0;",
+		"DELETE FROM users WHERE id = 1",
+	}})
+
+	estimates, err := migrator.Explain(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(estimates) != 2 {
+		t.Fatalf("expected 2 data-modifying statements, got %d", len(estimates))
+	}
+	if !estimates[0].FullScan || estimates[0].Rows != -1 {
+		t.Errorf("expected full scan with unknown rows for unbounded update, got %+v", estimates[0])
+	}
+	if estimates[1].FullScan {
+		t.Errorf("expected primary key delete not to be a full scan, got %+v", estimates[1])
+	}
+}
+
+func TestMigrator_WithExplainLimit(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, active INTEGER)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	migrator := New(db, WithDialect(DialectSQLite), WithExplainLimit(1000))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"UPDATE users SET active = 0"}})
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrEstimateExceeded) {
+		t.Fatalf("expected ErrEstimateExceeded, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+
+	if _, err := migrator.Up(AllowLargeRewrites()); err != nil {
+		t.Fatalf("expected override to apply the migration, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
+
+func TestMigrator_Explain_UnsupportedDialect(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := New(db).Explain(context.Background()); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
+
+func TestParsePostgresPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := `[{"Plan": {"Node Type": "ModifyTable", "Plan Rows": 0, "Total Cost": 2041.5,
+		"Plans": [{"Node Type": "Seq Scan", "Plan Rows": 120000, "Total Cost": 2041.5}]}}]`
+
+	var estimate StatementEstimate
+	if err := parsePostgresPlan([]byte(plan), &estimate); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if estimate.Rows != 120000 || estimate.Cost != 2041.5 || !estimate.FullScan {
+		t.Errorf("unexpected estimate: %+v", estimate)
+	}
+	if !estimate.exceeds(1000) || estimate.exceeds(200000) {
+		t.Errorf("unexpected threshold check for %+v", estimate)
+	}
+}
+
+func TestParseMySQLPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "12.75"},
+		"table": {"update": true, "table_name": "users", "access_type": "ALL", "rows_examined_per_scan": 42}}}`
+
+	var estimate StatementEstimate
+	if err := parseMySQLPlan([]byte(plan), &estimate); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if estimate.Rows != 42 || estimate.Cost != 12.75 || !estimate.FullScan {
+		t.Errorf("unexpected estimate: %+v", estimate)
+	}
+}
@@ -0,0 +1,219 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type FixtureDecoder func(data []byte, v any) error
+
+type fixtureFile struct {
+	table string
+	name  string
+}
+
+func (r *Migrator) LoadFixtures(ctx context.Context, fsys fs.FS, dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files, err := r.fixtureFiles(fsys, dir)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadFixtures, err)
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToBeginTransaction, err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	for _, file := range slices.Backward(files) {
+		if _, err := tx.Exec(ctx, "DELETE FROM "+file.table); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrFailedToLoadFixtures, file.table, err)
+		}
+	}
+
+	for _, file := range files {
+		rows, err := r.decodeFixture(fsys, file.name)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrFailedToLoadFixtures, file.name, err)
+		}
+		for _, row := range rows {
+			if err := r.insertFixtureRow(ctx, tx, file.table, row); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrFailedToLoadFixtures, file.name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Join(ErrFailedToLoadFixtures, err)
+	}
+	tx = nil
+	return nil
+}
+
+func (r *Migrator) TruncateFixtures(ctx context.Context, fsys fs.FS, dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files, err := r.fixtureFiles(fsys, dir)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadFixtures, err)
+	}
+
+	for _, file := range slices.Backward(files) {
+		if _, err := r.conn.Exec(ctx, "DELETE FROM "+file.table); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrFailedToLoadFixtures, file.table, err)
+		}
+	}
+	return nil
+}
+
+func (r *Migrator) fixtureFiles(fsys fs.FS, dir string) ([]fixtureFile, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []fixtureFile
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".csv" && r.fixtureDecoder(ext) == nil) {
+			continue
+		}
+		files = append(files, fixtureFile{
+			table: fixtureTable(strings.TrimSuffix(entry.Name(), ext)),
+			name:  path.Join(dir, entry.Name()),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+	return files, nil
+}
+
+func fixtureTable(base string) string {
+	if prefix, table, ok := strings.Cut(base, "_"); ok && prefix != "" && strings.Trim(prefix, "0123456789") == "" {
+		return table
+	}
+	return base
+}
+
+func (r *Migrator) fixtureDecoder(ext string) FixtureDecoder {
+	if decoder, ok := r.fixtureDecoders[ext]; ok {
+		return decoder
+	}
+	if ext == ".json" {
+		return decodeJSONFixture
+	}
+	return nil
+}
+
+func decodeJSONFixture(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func (r *Migrator) decodeFixture(fsys fs.FS, name string) ([]map[string]any, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if path.Ext(name) == ".csv" {
+		return decodeCSVFixture(data)
+	}
+
+	var decoded any
+	if err := r.fixtureDecoder(path.Ext(name))(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	list, ok := decoded.([]any)
+	if !ok {
+		return nil, ErrInvalidFixture
+	}
+	rows := make([]map[string]any, len(list))
+	for i, item := range list {
+		row, ok := item.(map[string]any)
+		if !ok {
+			return nil, ErrInvalidFixture
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func decodeCSVFixture(data []byte) ([]map[string]any, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+
+	header := records[0]
+	rows := make([]map[string]any, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]any, len(header))
+		for i, column := range header {
+			if record[i] == `\N` {
+				row[column] = nil
+				continue
+			}
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r *Migrator) insertFixtureRow(ctx context.Context, tx Executor, table string, row map[string]any) error {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		value, err := fixtureValue(row[column])
+		if err != nil {
+			return err
+		}
+		args[i] = value
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+	_, err := tx.Exec(ctx, r.dialect.rebind(query), args...)
+	return err
+}
+
+func fixtureValue(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		return v.Float64()
+	case map[string]any, []any:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,85 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrator_LoadFixtures(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithFixtureDecoder(".yaml", json.Unmarshal))
+	migrator.Register(CreateMigration("1", "schema").
+		CreateTable("users", "id INTEGER PRIMARY KEY", "name TEXT", "settings TEXT").
+		CreateTable("orders", "id INTEGER PRIMARY KEY", "user_id INTEGER REFERENCES users (id)", "note TEXT").
+		CreateTable("tags", "name TEXT").
+		Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"fixtures/01_users.json": {Data: []byte(`[{"id": 1, "name": "alice", "settings": {"theme": "dark"}}, {"id": 2, "name": "bob"}]`)},
+		"fixtures/02_orders.csv": {Data: []byte("id,user_id,note\n10,1,first\n11,2,\\N\n")},
+		"fixtures/tags.yaml":     {Data: []byte(`[{"name": "vip"}]`)},
+		"fixtures/README.md":     {Data: []byte("ignored")},
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if err := migrator.LoadFixtures(ctx, fsys, "fixtures"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if n := countRows(t, db, "users"); n != 2 {
+		t.Errorf("expected 2 users after reloading, got %d", n)
+	}
+	if n := countRows(t, db, "orders"); n != 2 {
+		t.Errorf("expected 2 orders after reloading, got %d", n)
+	}
+	if n := countRows(t, db, "tags"); n != 1 {
+		t.Errorf("expected 1 tag from the custom decoder, got %d", n)
+	}
+
+	var settings string
+	if err := db.QueryRow("SELECT settings FROM users WHERE id = 1").Scan(&settings); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if settings != `{"theme":"dark"}` {
+		t.Errorf("expected nested object to be stored as JSON, got %q", settings)
+	}
+
+	var nulls int
+	if err := db.QueryRow("SELECT COUNT(*) FROM orders WHERE note IS NULL").Scan(&nulls); err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if nulls != 1 {
+		t.Errorf("expected \\N to load as NULL, got %d", nulls)
+	}
+
+	if err := migrator.TruncateFixtures(ctx, fsys, "fixtures"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "users"); n != 0 {
+		t.Errorf("expected users to be truncated, got %d", n)
+	}
+
+	invalid := fstest.MapFS{"fixtures/users.json": {Data: []byte(`{"id": 1}`)}}
+	if err := migrator.LoadFixtures(ctx, invalid, "fixtures"); !errors.Is(err, ErrInvalidFixture) {
+		t.Errorf("expected ErrInvalidFixture, got %v", err)
+	}
+}
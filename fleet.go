@@ -0,0 +1,177 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type Shard struct {
+	Name string
+	DB   DBTX
+}
+
+type ShardResult struct {
+	Name        string
+	Version     string
+	Applied     []string
+	Result      *Result
+	RolledBack  bool
+	RollbackErr error
+	Err         error
+}
+
+type FleetReport struct {
+	Shards []ShardResult
+}
+
+func (r *FleetReport) ByVersion() map[string][]string {
+	versions := make(map[string][]string)
+	for _, shard := range r.Shards {
+		if shard.Err != nil {
+			continue
+		}
+		versions[shard.Version] = append(versions[shard.Version], shard.Name)
+	}
+	return versions
+}
+
+func (r *FleetReport) Err() error {
+	var errs []error
+	for _, shard := range r.Shards {
+		if shard.Err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", shard.Name, shard.Err))
+		}
+		if shard.RollbackErr != nil {
+			errs = append(errs, fmt.Errorf("shard %s rollback: %w", shard.Name, shard.RollbackErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type Fleet struct {
+	shards      []Shard
+	opts        []Option
+	migrations  []Migration
+	parallelism int
+	failFast    bool
+	coordinated bool
+}
+
+func NewFleet(shards []Shard, opts ...Option) *Fleet {
+	return &Fleet{
+		shards:      shards,
+		opts:        opts,
+		parallelism: 1,
+	}
+}
+
+func (f *Fleet) Parallelism(n int) *Fleet {
+	if n < 1 {
+		n = 1
+	}
+	f.parallelism = n
+	return f
+}
+
+func (f *Fleet) FailFast() *Fleet {
+	f.failFast = true
+	return f
+}
+
+func (f *Fleet) Coordinated() *Fleet {
+	f.coordinated = true
+	return f
+}
+
+func (f *Fleet) Register(migration ...Migration) {
+	f.migrations = append(f.migrations, migration...)
+}
+
+func (f *Fleet) Up(ctx context.Context, opts ...RunOption) (*FleetReport, error) {
+	if f.coordinated {
+		return f.coordinatedUp(ctx, newRunConfig(opts))
+	}
+	return f.run(ctx, func(ctx context.Context, m *Migrator, result *ShardResult) error {
+		var err error
+		result.Result, err = m.upContext(ctx, opts...)
+		return err
+	})
+}
+
+func (f *Fleet) Down(ctx context.Context, steps int, opts ...RunOption) (*FleetReport, error) {
+	return f.run(ctx, func(ctx context.Context, m *Migrator, result *ShardResult) error {
+		var err error
+		result.Result, err = m.downContext(ctx, steps, opts...)
+		return err
+	})
+}
+
+func (f *Fleet) Status(ctx context.Context) (*FleetReport, error) {
+	return f.run(ctx, func(context.Context, *Migrator, *ShardResult) error {
+		return nil
+	})
+}
+
+func (f *Fleet) newMigrator(shard Shard) *Migrator {
+	m := New(shard.DB, f.opts...)
+	m.Register(f.migrations...)
+	return m
+}
+
+func (f *Fleet) run(ctx context.Context, fn func(context.Context, *Migrator, *ShardResult) error) (*FleetReport, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]ShardResult, len(f.shards))
+	sem := make(chan struct{}, f.parallelism)
+
+	var wg sync.WaitGroup
+	for i, shard := range f.shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = f.runShard(ctx, cancel, sem, shard, fn)
+		}()
+	}
+	wg.Wait()
+
+	report := &FleetReport{Shards: results}
+	return report, report.Err()
+}
+
+func (f *Fleet) runShard(ctx context.Context, cancel context.CancelFunc, sem chan struct{}, shard Shard, fn func(context.Context, *Migrator, *ShardResult) error) ShardResult {
+	result := ShardResult{Name: shard.Name}
+
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+	case <-ctx.Done():
+		result.Err = errors.Join(ErrShardSkipped, ctx.Err())
+		return result
+	}
+
+	if err := ctx.Err(); err != nil {
+		result.Err = errors.Join(ErrShardSkipped, err)
+		return result
+	}
+
+	m := f.newMigrator(shard)
+
+	if err := fn(ctx, m, &result); err != nil {
+		if f.failFast || f.coordinated {
+			cancel()
+		}
+		result.Err = err
+	}
+
+	applied, err := m.Status()
+	if err != nil {
+		result.Err = errors.Join(result.Err, err)
+		return result
+	}
+	result.Version = currentVersion(applied)
+
+	return result
+}
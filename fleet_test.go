@@ -0,0 +1,153 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func openFleetShard(t *testing.T, name string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), name+".db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+func closedShard(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	_ = db.Close()
+	return db
+}
+
+func TestFleet_Up_Success(t *testing.T) {
+	t.Parallel()
+
+	fleet := NewFleet([]Shard{
+		{Name: "eu-1", DB: openFleetShard(t, "eu-1")},
+		{Name: "us-1", DB: openFleetShard(t, "us-1")},
+		{Name: "us-2", DB: openFleetShard(t, "us-2")},
+	}).Parallelism(2)
+	fleet.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"CREATE TABLE b (id INTEGER)"}},
+	)
+
+	report, err := fleet.Up(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	versions := report.ByVersion()
+	if len(versions) != 1 || len(versions["2"]) != 3 {
+		t.Errorf("expected all shards at version 2, got %v", versions)
+	}
+	for _, shard := range report.Shards {
+		if shard.Result == nil || len(shard.Result.Migrations) != 2 {
+			t.Errorf("expected shard %s result with 2 migrations, got %+v", shard.Name, shard.Result)
+		}
+	}
+}
+
+func TestFleet_Up_ContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	fleet := NewFleet([]Shard{
+		{Name: "broken", DB: closedShard(t)},
+		{Name: "healthy", DB: openFleetShard(t, "healthy")},
+	})
+	fleet.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	report, err := fleet.Up(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if report.Shards[0].Err == nil {
+		t.Error("expected broken shard to fail")
+	}
+	if report.Shards[1].Err != nil || report.Shards[1].Version != "1" {
+		t.Errorf("expected healthy shard at version 1, got %+v", report.Shards[1])
+	}
+}
+
+func TestFleet_Up_FailFast(t *testing.T) {
+	t.Parallel()
+
+	fleet := NewFleet([]Shard{
+		{Name: "broken-1", DB: closedShard(t)},
+		{Name: "broken-2", DB: closedShard(t)},
+	}).Parallelism(1).FailFast()
+	fleet.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	report, err := fleet.Up(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	failed, skipped := 0, 0
+	for _, shard := range report.Shards {
+		switch {
+		case errors.Is(shard.Err, ErrShardSkipped):
+			skipped++
+		case shard.Err != nil:
+			failed++
+		}
+	}
+	if failed != 1 || skipped != 1 {
+		t.Errorf("expected the failure to stop the fleet, got %+v", report.Shards)
+	}
+}
+
+func TestFleet_Up_FailFastCancelsRunningShards(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	calls := 0
+	started := make(chan struct{})
+	interceptor := func(ctx context.Context, _, query string) (string, error) {
+		if query != "SELECT 1" {
+			return query, nil
+		}
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+
+		if !first {
+			<-started
+			return "", errors.New("boom")
+		}
+		close(started)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return "", errors.New("shard was not cancelled")
+		}
+	}
+
+	fleet := NewFleet([]Shard{
+		{Name: "eu-1", DB: openFleetShard(t, "eu-1")},
+		{Name: "us-1", DB: openFleetShard(t, "us-1")},
+	}, WithInterceptor(interceptor)).Parallelism(2).FailFast()
+	fleet.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	if _, err := fleet.Up(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the running shard to be cancelled, got %v", err)
+	}
+}
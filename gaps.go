@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type OutOfOrderPolicy int
+
+const (
+	OutOfOrderAllow OutOfOrderPolicy = iota
+	OutOfOrderError
+)
+
+func (r *Migrator) Gaps(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	return r.findGaps(applied, r.pendingMigrations(applied)), nil
+}
+
+func (r *Migrator) findGaps(applied []MigrationStatus, pending []Migration) []string {
+	repeatable := r.repeatableIDs()
+	newest := ""
+	for _, migration := range applied {
+		if repeatable[migration.ID] {
+			continue
+		}
+		if newest == "" || r.compareIDs(migration.ID, newest) > 0 {
+			newest = migration.ID
+		}
+	}
+
+	var gaps []string
+	for _, migration := range pending {
+		if !isRepeatable(migration) && r.compareIDs(migration.ID(), newest) < 0 {
+			gaps = append(gaps, migration.ID())
+		}
+	}
+	return gaps
+}
+
+func (r *Migrator) checkOutOfOrder(applied []MigrationStatus, pending []Migration) error {
+	if r.outOfOrder == OutOfOrderAllow {
+		return nil
+	}
+
+	gaps := r.findGaps(applied, pending)
+	if len(gaps) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrMigrationGap, strings.Join(gaps, ", "))
+}
@@ -0,0 +1,56 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Gaps(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	first := New(db)
+	first.Register(
+		&mockMigration{id: "001", description: "first", upQueries: []string{"SELECT 1"}},
+		&mockMigration{id: "003", description: "third", upQueries: []string{"SELECT 3"}},
+	)
+	if _, err := first.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	merged := New(db, WithOutOfOrder(OutOfOrderError))
+	merged.Register(
+		&mockMigration{id: "001", description: "first", upQueries: []string{"SELECT 1"}},
+		&mockMigration{id: "002", description: "second", upQueries: []string{"SELECT 2"}},
+		&mockMigration{id: "003", description: "third", upQueries: []string{"SELECT 3"}},
+		&mockMigration{id: "004", description: "fourth", upQueries: []string{"SELECT 4"}},
+	)
+
+	gaps, err := merged.Gaps(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(gaps) != 1 || gaps[0] != "002" {
+		t.Errorf("expected gap 002, got %v", gaps)
+	}
+
+	if _, err := merged.Up(); !errors.Is(err, ErrMigrationGap) {
+		t.Errorf("expected ErrMigrationGap, got %v", err)
+	}
+
+	allowing := New(db)
+	allowing.Register(&mockMigration{id: "002", description: "second", upQueries: []string{"SELECT 2"}})
+	if _, err := allowing.Up(); err != nil {
+		t.Errorf("expected gap to be applied by default, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package migrator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func GenerateSource(w io.Writer, pkg string, files []SQLFile) error {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by migrator-gen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	buf.WriteString("import \"github.com/shuldan/migrator\"\n\n")
+
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = goIdentifier(file)
+
+		fmt.Fprintf(&buf, "var %s = migrator.CreateMigration(%s, %s).\n",
+			names[i], strconv.Quote(file.ID), strconv.Quote(file.Description))
+		for _, query := range SplitStatements(file.Up) {
+			fmt.Fprintf(&buf, "\tRawUp(%s).\n", goString(query))
+		}
+
+		down := SplitStatements(file.Down)
+		for j := len(down) - 1; j >= 0; j-- {
+			fmt.Fprintf(&buf, "\tRawDown(%s).\n", goString(down[j]))
+		}
+		if file.Repeatable {
+			buf.WriteString("\tRepeatable().\n")
+		}
+		buf.WriteString("\tBuild()\n\n")
+	}
+
+	buf.WriteString("func All() []migrator.Migration {\n\treturn []migrator.Migration{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t\t%s,\n", name)
+	}
+	buf.WriteString("\t}\n}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(source)
+	return err
+}
+
+func goIdentifier(file SQLFile) string {
+	var b strings.Builder
+	b.WriteString("Migration")
+
+	upperNext := true
+	for _, ch := range file.ID + "_" + file.Description {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			ch = unicode.ToUpper(ch)
+			upperNext = false
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+func goString(s string) string {
+	if strings.Contains(s, "`") || !strconv.CanBackquote(strings.ReplaceAll(s, "\n", "")) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,55 @@
+package migrator
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSource(t *testing.T) {
+	t.Parallel()
+
+	files := []SQLFile{
+		{
+			ID:          "001",
+			Description: "create users",
+			Up:          "CREATE TABLE users (id INTEGER);\nCREATE INDEX idx_users_id ON users (id);",
+			Down:        "DROP INDEX idx_users_id;\nDROP TABLE users;",
+		},
+		{
+			ID:          "002",
+			Description: "add `quoted` note",
+			Up:          "ALTER TABLE users ADD COLUMN note TEXT",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateSource(&buf, "migrations", files); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	source := buf.String()
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", source, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, source)
+	}
+
+	for _, expected := range []string{
+		"// Code generated by migrator-gen. DO NOT EDIT.",
+		"package migrations",
+		"var Migration001CreateUsers = migrator.CreateMigration(\"001\", \"create users\")",
+		"var Migration002AddQuotedNote",
+		"func All() []migrator.Migration",
+	} {
+		if !strings.Contains(source, expected) {
+			t.Errorf("expected generated source to contain %q\n%s", expected, source)
+		}
+	}
+
+	dropIndex := strings.Index(source, "RawDown(`DROP INDEX idx_users_id`)")
+	dropTable := strings.Index(source, "RawDown(`DROP TABLE users`)")
+	if dropIndex == -1 || dropTable == -1 || dropTable > dropIndex {
+		t.Errorf("expected down statements to be emitted in reverse order\n%s", source)
+	}
+}
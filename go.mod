@@ -2,4 +2,10 @@ module github.com/shuldan/migrator
 
 go 1.24.2
 
-require github.com/mattn/go-sqlite3 v1.14.32
+require (
+	github.com/go-sql-driver/mysql v1.10.1
+	github.com/lib/pq v1.12.3
+	github.com/mattn/go-sqlite3 v1.14.32
+)
+
+require filippo.io/edwards25519 v1.2.0 // indirect
@@ -0,0 +1,97 @@
+package migrator
+
+import (
+	"context"
+	"slices"
+)
+
+const groupSeparator = "/"
+
+type Grouped interface {
+	Group() string
+}
+
+func groupOf(migration Migration) string {
+	if grouped, ok := migration.(Grouped); ok {
+		return grouped.Group()
+	}
+	return ""
+}
+
+func (r *Migrator) Groups() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var groups []string
+	for _, migration := range r.migrations {
+		if group := groupOf(migration); group != "" && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func (r *Migrator) UpGroup(group string, opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx := context.Background()
+	config := newRunConfig(opts)
+
+	var result *Result
+	err := r.withGroup(group, func() error {
+		var err error
+		result, err = r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+			return r.up(ctx, config, result)
+		})
+		return err
+	})
+	return result, err
+}
+
+func (r *Migrator) DownGroup(group string, steps int, opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx := context.Background()
+	config := newRunConfig(opts)
+
+	var result *Result
+	err := r.withGroup(group, func() error {
+		var err error
+		result, err = r.runWithResult(ctx, DirectionDown, func(ctx context.Context, result *Result) error {
+			return r.down(ctx, steps, config, result)
+		})
+		return err
+	})
+	return result, err
+}
+
+func (r *Migrator) StatusGroup(group string) ([]MigrationStatus, error) {
+	return r.readView(group).getHistory(context.Background())
+}
+
+func (r *Migrator) withGroup(group string, fn func() error) error {
+	active := r.group
+	defer func() {
+		r.group = active
+	}()
+
+	r.group = group
+	return fn()
+}
+
+func (r *Migrator) module() string {
+	if r.group == "" {
+		return r.namespace
+	}
+	return r.namespace + groupSeparator + r.group
+}
+
+func (r *Migrator) activeMigrations() []Migration {
+	var migrations []Migration
+	for _, migration := range r.migrations {
+		if groupOf(migration) == r.group {
+			migrations = append(migrations, migration)
+		}
+	}
+	return migrations
+}
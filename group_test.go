@@ -0,0 +1,74 @@
+package migrator
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestMigrator_Groups(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build(),
+		CreateMigration("001", "seed users").RawUp("INSERT INTO users (id) VALUES (1)").RawDown("DELETE FROM users").Group("data").Build(),
+		CreateMigration("002", "seed more users").RawUp("INSERT INTO users (id) VALUES (2)").RawDown("DELETE FROM users WHERE id = 2").Group("data").Build(),
+	)
+
+	if groups := migrator.Groups(); !reflect.DeepEqual(groups, []string{"data"}) {
+		t.Errorf("expected groups [data], got %v", groups)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply default group: %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001")
+	if count := countRows(t, db, "users"); count != 0 {
+		t.Fatalf("expected data group to be left alone, got %d users", count)
+	}
+
+	result, err := migrator.UpGroup("data")
+	if err != nil {
+		t.Fatalf("failed to apply data group: %v", err)
+	}
+	if len(result.Migrations) != 2 || result.Batch != 1 {
+		t.Errorf("expected data group result with 2 migrations in batch 1, got %+v", result)
+	}
+	data, err := migrator.StatusGroup("data")
+	if err != nil {
+		t.Fatalf("failed to get data group status: %v", err)
+	}
+	if len(data) != 2 || data[0].Batch != 1 || data[1].Batch != 1 {
+		t.Errorf("expected data group to start its own batch sequence, got %+v", data)
+	}
+
+	migrator.Register(CreateMigration("002", "create posts").RawUp("CREATE TABLE posts (id INTEGER)").RawDown("DROP TABLE posts").Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply default group: %v", err)
+	}
+	history, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(history) != 2 || history[1].ID != "002" || history[1].Batch != 2 {
+		t.Errorf("expected default group to continue with batch 2, got %+v", history)
+	}
+
+	if _, err := migrator.DownGroup("data", 1); err != nil {
+		t.Fatalf("failed to roll back data group: %v", err)
+	}
+	if count := countRows(t, db, "users"); count != 1 {
+		t.Errorf("expected only the last data migration to be rolled back, got %d users", count)
+	}
+	assertAppliedIDs(t, migrator, "001", "002")
+}
@@ -0,0 +1,82 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Guardrail int
+
+const (
+	GuardDown Guardrail = 1 << iota
+	GuardDestructiveUp
+	GuardBlockingUp
+)
+
+var protectedEnvironments = map[string]bool{
+	"production": true,
+	"prod":       true,
+}
+
+func AllowDestructive() RunOption {
+	return func(c *runConfig) {
+		c.allowDestructive = true
+	}
+}
+
+func AllowBlocking() RunOption {
+	return func(c *runConfig) {
+		c.allowBlocking = true
+	}
+}
+
+func (r *Migrator) guardEnvironment(guardrail Guardrail, config *runConfig, build func() (*Plan, error)) error {
+	if r.guardrails&guardrail == 0 || config.allowDestructive {
+		return nil
+	}
+	if r.confirm == nil {
+		return fmt.Errorf("%w: %s", ErrProtectedEnvironment, r.environment)
+	}
+
+	plan, err := build()
+	if err != nil {
+		return err
+	}
+	return r.confirmProtected(config, plan)
+}
+
+func (r *Migrator) confirmProtected(config *runConfig, plan *Plan) error {
+	if r.confirm == nil {
+		return fmt.Errorf("%w: %s", ErrProtectedEnvironment, r.environment)
+	}
+	if err := r.confirmPlan(config, plan); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrProtectedEnvironment, r.environment, err)
+	}
+	return nil
+}
+
+func (r *Migrator) guardOperations(config *runConfig, build func() (*Plan, error)) error {
+	destructive := r.guardrails&GuardDestructiveUp != 0 && !config.allowDestructive
+	blocking := r.guardrails&GuardBlockingUp != 0 && !config.allowBlocking
+	if !destructive && !blocking {
+		return nil
+	}
+
+	plan, err := build()
+	if err != nil {
+		return err
+	}
+	if destructive && plan.Destructive() {
+		if err := r.confirmProtected(config, plan); err != nil {
+			return err
+		}
+	}
+	if blocking && plan.Blocking() {
+		var statements []string
+		for _, operation := range plan.Operations(OperationBlocking) {
+			statements = append(statements, firstLine(strings.TrimSpace(operation.Statement)))
+		}
+		return fmt.Errorf("%w: %s", ErrBlockingOperation, strings.Join(statements, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_ProductionGuardrail(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithEnvironment("production"))
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected Up to be allowed in production, got %v", err)
+	}
+	if _, err := migrator.Down(0); !errors.Is(err, ErrProtectedEnvironment) {
+		t.Fatalf("expected ErrProtectedEnvironment, got %v", err)
+	}
+	if _, err := migrator.Reset(); !errors.Is(err, ErrProtectedEnvironment) {
+		t.Fatalf("expected Reset to be guarded, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001")
+
+	if _, err := migrator.Down(0, AllowDestructive()); err != nil {
+		t.Fatalf("expected explicit AllowDestructive to roll back, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+func TestMigrator_ProductionGuardrailAsksConfirm(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var prompts []Plan
+	approve := false
+	migrator := New(db, WithDialect(DialectSQLite), WithEnvironment("production"), WithConfirm(func(plan Plan) (bool, error) {
+		prompts = append(prompts, plan)
+		return approve, nil
+	}))
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected Up to be allowed in production, got %v", err)
+	}
+
+	if _, err := migrator.Down(0); !errors.Is(err, ErrProtectedEnvironment) || !errors.Is(err, ErrNotConfirmed) {
+		t.Fatalf("expected refused Down to be blocked, got %v", err)
+	}
+	if _, err := migrator.Fresh(); !errors.Is(err, ErrProtectedEnvironment) || !errors.Is(err, ErrNotConfirmed) {
+		t.Fatalf("expected refused Fresh to be blocked, got %v", err)
+	}
+	if exists, _ := migrator.HasTable(context.Background(), "users"); !exists {
+		t.Fatal("expected refused Fresh to keep tables")
+	}
+	assertAppliedIDs(t, migrator, "001")
+	if len(prompts) != 2 || prompts[1].Migrations[0].ID != "fresh" || !prompts[1].Destructive() {
+		t.Fatalf("expected one prompt per attempt with the drop list for Fresh, got %+v", prompts)
+	}
+
+	approve = true
+	if _, err := migrator.Down(0); err != nil {
+		t.Fatalf("expected confirmed Down to roll back, got %v", err)
+	}
+	if len(prompts) != 3 {
+		t.Errorf("expected confirmed Down to prompt exactly once, got %d prompts", len(prompts))
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+func TestMigrator_GuardDestructiveUp(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	confirmed := false
+	migrator := New(db, WithEnvironment("staging", GuardDown, GuardDestructiveUp))
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, legacy TEXT)").Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected non-destructive Up to pass, got %v", err)
+	}
+
+	migrator.Register(CreateMigration("002", "drop legacy").RawUp("ALTER TABLE users DROP COLUMN legacy").Build())
+	if _, err := migrator.Up(); !errors.Is(err, ErrProtectedEnvironment) {
+		t.Fatalf("expected ErrProtectedEnvironment, got %v", err)
+	}
+
+	truncating := New(db, WithEnvironment("staging", GuardDestructiveUp))
+	truncating.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, legacy TEXT)").Build())
+	truncating.Register(CreateMigration("002", "clear users").TruncateTable("users", false).Build())
+	if _, err := truncating.Up(); !errors.Is(err, ErrProtectedEnvironment) {
+		t.Fatalf("expected TruncateTable to be guarded, got %v", err)
+	}
+
+	confirming := New(db, WithEnvironment("staging", GuardDestructiveUp), WithConfirm(func(Plan) (bool, error) {
+		confirmed = true
+		return true, nil
+	}))
+	confirming.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, legacy TEXT)").Build())
+	confirming.Register(CreateMigration("002", "drop legacy").RawUp("ALTER TABLE users DROP COLUMN legacy").Build())
+	if _, err := confirming.Up(); err != nil || !confirmed {
+		t.Fatalf("expected confirmation callback to unlock destructive Up, got %v (confirmed=%v)", err, confirmed)
+	}
+	assertAppliedIDs(t, confirming, "001", "002")
+}
@@ -0,0 +1,40 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+func (r *Migrator) PendingCount(ctx context.Context) (int, error) {
+	view := r.readView("")
+
+	groups := []string{""}
+	for _, migration := range view.migrations {
+		if group := groupOf(migration); !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+
+	pending := 0
+	for _, group := range groups {
+		view.group = group
+		applied, err := view.getAppliedMigrations(ctx)
+		if err != nil {
+			return 0, errors.Join(ErrFailedToGetAppliedMigrations, err)
+		}
+		pending += len(view.pendingMigrations(applied))
+	}
+	return pending, nil
+}
+
+func (r *Migrator) Healthy(ctx context.Context) error {
+	pending, err := r.PendingCount(ctx)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return ErrPendingMigrations
+	}
+	return nil
+}
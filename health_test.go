@@ -0,0 +1,103 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_PendingCount(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"CREATE TABLE b (id INTEGER)"}},
+	)
+
+	pending, err := migrator.PendingCount(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pending != 2 {
+		t.Errorf("expected 2 pending migrations, got %d", pending)
+	}
+
+	if err := migrator.Healthy(context.Background()); !errors.Is(err, ErrPendingMigrations) {
+		t.Errorf("expected ErrPendingMigrations, got %v", err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	if err := migrator.Healthy(context.Background()); err != nil {
+		t.Errorf("expected healthy database, got %v", err)
+	}
+}
+
+func TestMigrator_Healthy_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	_ = db.Close()
+
+	err = New(db).Healthy(context.Background())
+	if !errors.Is(err, ErrFailedToGetAppliedMigrations) {
+		t.Errorf("expected ErrFailedToGetAppliedMigrations, got %v", err)
+	}
+}
+
+func TestMigrator_PendingCount_GroupsAndProviders(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Use(auditProvider{})
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build(),
+		CreateMigration("001", "create reports").RawUp("CREATE TABLE reports (id INTEGER)").Group("reporting").Build(),
+	)
+
+	ctx := context.Background()
+	if pending, err := migrator.PendingCount(ctx); err != nil || pending != 3 {
+		t.Errorf("expected 3 pending migrations across groups, got %d (%v)", pending, err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if pending, err := migrator.PendingCount(ctx); err != nil || pending != 1 {
+		t.Errorf("expected the reporting group to stay pending, got %d (%v)", pending, err)
+	}
+	if err := migrator.Healthy(ctx); !errors.Is(err, ErrPendingMigrations) {
+		t.Errorf("expected ErrPendingMigrations, got %v", err)
+	}
+
+	if _, err := migrator.UpGroup("reporting"); err != nil {
+		t.Fatalf("failed to apply reporting group: %v", err)
+	}
+	if err := migrator.Healthy(ctx); err != nil {
+		t.Errorf("expected healthy database, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package migrator
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const historyInsertChunkSize = 100
+
+type historyRecord struct {
+	id          string
+	description string
+	batch       int
+	checksum    string
+	duration    time.Duration
+	appliedAt   time.Time
+	status      MigrationState
+}
+
+func (r *Migrator) insertHistory(ctx context.Context, tx Executor, records []historyRecord) error {
+	columns := "module, id, description, batch, checksum, duration_ms, status"
+	placeholders := "(?, ?, ?, ?, ?, ?, ?)"
+	if r.clock != nil {
+		columns += ", applied_at"
+		placeholders = "(?, ?, ?, ?, ?, ?, ?, ?)"
+	}
+
+	for start := 0; start < len(records); start += historyInsertChunkSize {
+		chunk := records[start:min(start+historyInsertChunkSize, len(records))]
+		if err := r.clearUnappliedHistory(ctx, tx, chunk); err != nil {
+			return err
+		}
+
+		values := make([]string, len(chunk))
+		var args []any
+		for i, record := range chunk {
+			status := record.status
+			if status == "" {
+				status = StateApplied
+			}
+			values[i] = placeholders
+			args = append(args, r.module(), record.id, record.description, record.batch, record.checksum, record.duration.Milliseconds(), string(status))
+			if r.clock != nil {
+				args = append(args, record.appliedAt)
+			}
+		}
+
+		query := "INSERT INTO %s (" + columns + ") VALUES " + strings.Join(values, ", ")
+		if _, err := tx.Exec(ctx, r.query(query), args...); err != nil {
+			return err
+		}
+	}
+	return r.syncVersion(ctx, tx)
+}
+
+func (r *Migrator) clearUnappliedHistory(ctx context.Context, tx Executor, records []historyRecord) error {
+	repeatable := r.repeatableIDs()
+
+	var unapplied, replaced []any
+	for _, record := range records {
+		if repeatable[record.id] {
+			replaced = append(replaced, record.id)
+		} else {
+			unapplied = append(unapplied, record.id)
+		}
+	}
+
+	if len(unapplied) > 0 {
+		query := "DELETE FROM %s WHERE module = ? AND status <> ? AND id IN (" + placeholderList(len(unapplied)) + ")"
+		if _, err := tx.Exec(ctx, r.query(query), append([]any{r.module(), string(StateApplied)}, unapplied...)...); err != nil {
+			return err
+		}
+	}
+	if len(replaced) > 0 {
+		query := "DELETE FROM %s WHERE module = ? AND id IN (" + placeholderList(len(replaced)) + ")"
+		if _, err := tx.Exec(ctx, r.query(query), append([]any{r.module()}, replaced...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func placeholderList(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func (r *Migrator) markRolledBack(ctx context.Context, tx Executor, migrationID string) error {
+	_, err := tx.Exec(ctx, r.query("UPDATE %s SET status = ? WHERE module = ? AND id = ?"),
+		string(StateRolledBack), r.module(), migrationID)
+	if err != nil {
+		return err
+	}
+	return r.syncVersion(ctx, tx)
+}
+
+func (r *Migrator) recordFailure(ctx context.Context, migration Migration, batch int, started time.Time) error {
+	checksum, err := r.checksum(migration)
+	if err != nil {
+		return err
+	}
+
+	return r.insertHistory(ctx, r.conn, []historyRecord{{
+		id:          migration.ID(),
+		description: migration.Description(),
+		batch:       batch,
+		checksum:    checksum,
+		duration:    time.Since(started),
+		appliedAt:   r.now(),
+		status:      StateFailed,
+	}})
+}
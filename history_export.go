@@ -0,0 +1,119 @@
+package migrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const historyExportVersion = 1
+
+type HistoryExport struct {
+	Version int                  `json:"version"`
+	Records []HistoryExportEntry `json:"records"`
+}
+
+type HistoryExportEntry struct {
+	Module      string         `json:"module"`
+	ID          string         `json:"id"`
+	Description string         `json:"description"`
+	Batch       int            `json:"batch"`
+	Checksum    string         `json:"checksum"`
+	DurationMs  int64          `json:"duration_ms"`
+	AppliedAt   time.Time      `json:"applied_at"`
+	Status      MigrationState `json:"status"`
+}
+
+func (r *Migrator) ExportHistory(ctx context.Context, w io.Writer) error {
+	if err := r.createMigrationTable(); err != nil {
+		return err
+	}
+
+	rows, err := r.conn.Query(ctx,
+		r.query("SELECT module, id, description, batch, checksum, duration_ms, applied_at, status FROM %s ORDER BY module, batch, id"))
+	if err != nil {
+		return errors.Join(ErrFailedToExportHistory, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	export := HistoryExport{Version: historyExportVersion, Records: []HistoryExportEntry{}}
+	for rows.Next() {
+		var entry HistoryExportEntry
+		if err := rows.Scan(&entry.Module, &entry.ID, &entry.Description, &entry.Batch, &entry.Checksum, &entry.DurationMs, &entry.AppliedAt, &entry.Status); err != nil {
+			return errors.Join(ErrFailedToExportHistory, err)
+		}
+		export.Records = append(export.Records, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Join(ErrFailedToExportHistory, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return errors.Join(ErrFailedToExportHistory, err)
+	}
+	return nil
+}
+
+func (r *Migrator) ImportHistory(ctx context.Context, reader io.Reader) error {
+	var export HistoryExport
+	if err := json.NewDecoder(reader).Decode(&export); err != nil {
+		return errors.Join(ErrInvalidHistoryExport, err)
+	}
+	if export.Version != historyExportVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidHistoryExport, export.Version)
+	}
+	for _, entry := range export.Records {
+		if entry.ID == "" {
+			return fmt.Errorf("%w: record without id", ErrInvalidHistoryExport)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.createMigrationTable(); err != nil {
+		return err
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToBeginTransaction, err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, r.query("DELETE FROM %s")); err != nil {
+		return errors.Join(ErrFailedToImportHistory, err)
+	}
+	for _, entry := range export.Records {
+		status := entry.Status
+		if status == "" {
+			status = StateApplied
+		}
+		_, err := tx.Exec(ctx,
+			r.query("INSERT INTO %s (module, id, description, batch, checksum, duration_ms, applied_at, status) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"),
+			entry.Module, entry.ID, entry.Description, entry.Batch, entry.Checksum, entry.DurationMs, entry.AppliedAt, string(status))
+		if err != nil {
+			return errors.Join(ErrFailedToImportHistory, err)
+		}
+	}
+
+	if err := r.syncVersion(ctx, tx); err != nil {
+		return errors.Join(ErrFailedToImportHistory, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Join(ErrFailedToImportHistory, err)
+	}
+	tx = nil
+	return nil
+}
@@ -0,0 +1,88 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_ExportImportHistory(t *testing.T) {
+	t.Parallel()
+
+	source, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = source.Close()
+	}()
+	source.SetMaxOpenConns(1)
+
+	target, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = target.Close()
+	}()
+	target.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	migrations := []Migration{
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build(),
+		CreateMigration("002", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").RawDown("ALTER TABLE users DROP COLUMN email").Build(),
+	}
+
+	original := New(source)
+	original.Register(migrations...)
+	if _, err := original.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := original.Down(1); err != nil {
+		t.Fatalf("failed to rollback migration: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := original.ExportHistory(ctx, &buf); err != nil {
+		t.Fatalf("failed to export history: %v", err)
+	}
+
+	if _, err := target.Exec("CREATE TABLE users (id INTEGER)"); err != nil {
+		t.Fatalf("failed to copy schema: %v", err)
+	}
+	restored := New(target)
+	restored.Register(migrations...)
+	if err := restored.ImportHistory(ctx, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("failed to import history: %v", err)
+	}
+
+	expected, err := original.Status()
+	if err != nil {
+		t.Fatalf("failed to get source status: %v", err)
+	}
+	actual, err := restored.Status()
+	if err != nil {
+		t.Fatalf("failed to get restored status: %v", err)
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d history rows, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if actual[i].ID != expected[i].ID || actual[i].Batch != expected[i].Batch || actual[i].Checksum != expected[i].Checksum ||
+			actual[i].Status != expected[i].Status || !actual[i].AppliedAt.Equal(*expected[i].AppliedAt) {
+			t.Errorf("row %d differs: expected %+v, got %+v", i, expected[i], actual[i])
+		}
+	}
+
+	if pending, err := restored.PendingCount(ctx); err != nil || pending != 1 {
+		t.Errorf("expected only the rolled back migration to be pending, got %d (%v)", pending, err)
+	}
+
+	err = restored.ImportHistory(ctx, strings.NewReader(`{"version": 1, "records": [{"module": ""}]}`))
+	if !errors.Is(err, ErrInvalidHistoryExport) {
+		t.Errorf("expected ErrInvalidHistoryExport, got %v", err)
+	}
+}
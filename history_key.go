@@ -0,0 +1,104 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var moduleKeyQueries = map[Dialect]string{
+	DialectPostgres: `SELECT COUNT(*) FROM information_schema.key_column_usage k
+JOIN information_schema.table_constraints c
+    ON c.constraint_name = k.constraint_name AND c.table_schema = k.table_schema AND c.table_name = k.table_name
+WHERE c.table_schema = current_schema() AND c.table_name = ? AND c.constraint_type = 'PRIMARY KEY' AND k.column_name = 'module'`,
+	DialectMySQL: `SELECT COUNT(*) FROM information_schema.key_column_usage
+WHERE table_schema = DATABASE() AND table_name = ? AND constraint_name = 'PRIMARY' AND column_name = 'module'`,
+	DialectSQLite: `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'module' AND pk > 0`,
+}
+
+func (r *Migrator) upgradePrimaryKey(ctx context.Context, addedModule bool) error {
+	query, ok := moduleKeyQueries[r.dialect]
+	if !ok {
+		if addedModule && r.namespace != "" {
+			return fmt.Errorf("%w: %s (set a dialect to migrate it)", ErrLegacyHistoryTable, r.table)
+		}
+		return nil
+	}
+
+	keyed, err := r.exists(ctx, query, r.table)
+	if err != nil {
+		return errors.Join(ErrFailedToUpgradeSchemaMigrationsTable, err)
+	}
+	if keyed {
+		return nil
+	}
+
+	if err := r.rekeyHistoryTable(ctx); err != nil {
+		return errors.Join(ErrFailedToUpgradeSchemaMigrationsTable, err)
+	}
+	return nil
+}
+
+func (r *Migrator) rekeyHistoryTable(ctx context.Context) error {
+	switch r.dialect {
+	case DialectMySQL:
+		_, err := r.conn.Exec(ctx, r.query("ALTER TABLE %s DROP PRIMARY KEY, ADD PRIMARY KEY (module, id)"))
+		return err
+	case DialectPostgres:
+		rows, err := r.conn.Query(ctx, r.dialect.rebind(`SELECT constraint_name FROM information_schema.table_constraints
+WHERE table_schema = current_schema() AND table_name = ? AND constraint_type = 'PRIMARY KEY'`), r.table)
+		if err != nil {
+			return err
+		}
+		var constraint string
+		if rows.Next() {
+			err = rows.Scan(&constraint)
+		}
+		_ = rows.Close()
+		if err != nil {
+			return err
+		}
+
+		query := "ALTER TABLE %s ADD PRIMARY KEY (module, id)"
+		if constraint != "" {
+			query = "ALTER TABLE %s DROP CONSTRAINT " + r.dialect.quoteIdent(constraint) + ", ADD PRIMARY KEY (module, id)"
+		}
+		_, err = r.conn.Exec(ctx, r.query(query))
+		return err
+	default:
+		return r.rebuildHistoryTable(ctx)
+	}
+}
+
+func (r *Migrator) rebuildHistoryTable(ctx context.Context) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rebuilt := r.table + "_rekey"
+	columns := "module, id, description, applied_at, batch, checksum, duration_ms, status"
+	queries := []string{
+		fmt.Sprintf(migrationTableTemplate, rebuilt),
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", rebuilt, columns, columns, r.table),
+		fmt.Sprintf("DROP TABLE %s", r.table),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", rebuilt, r.table),
+		r.query(migrationTableIndexTemplate),
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	tx = nil
+	return nil
+}
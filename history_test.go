@@ -0,0 +1,130 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type historyCountingConn struct {
+	Conn
+	inserts int
+}
+
+func (c *historyCountingConn) Begin(ctx context.Context) (Tx, error) {
+	tx, err := c.Conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &historyCountingTx{Tx: tx, conn: c}, nil
+}
+
+type historyCountingTx struct {
+	Tx
+	conn *historyCountingConn
+}
+
+func (t *historyCountingTx) Exec(ctx context.Context, query string, args ...any) (ExecResult, error) {
+	if strings.HasPrefix(query, "INSERT INTO schema_migrations ") {
+		t.conn.inserts++
+	}
+	return t.Tx.Exec(ctx, query, args...)
+}
+
+func TestMigrator_BatchInsertHistory(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	conn := &historyCountingConn{Conn: stdConn{db: db}}
+	migrator := NewWithConn(conn)
+	for i := 0; i < 250; i++ {
+		migrator.Register(&mockMigration{id: fmt.Sprintf("%04d", i), description: "noop", upQueries: []string{"SELECT 1"}})
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if conn.inserts != 3 {
+		t.Errorf("expected history rows to be inserted in 3 statements, got %d", conn.inserts)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(status) != 250 || status[249].ID != "0249" || status[0].Checksum != mustChecksum(t, &mockMigration{upQueries: []string{"SELECT 1"}}) {
+		t.Errorf("expected all history rows to be recorded, got %d", len(status))
+	}
+}
+
+func TestMigrator_HistoryStatus(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	broken := &nonTransactionalMigration{mockMigration{id: "2", upQueries: []string{"CREATE INDEX idx ON missing (id)"}, downQueries: []string{"DROP INDEX idx"}}}
+	migrator := New(db)
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}}, broken)
+
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected the non-transactional migration to fail")
+	}
+	assertHistoryStatuses(t, migrator, map[string]MigrationState{"1": StateApplied, "2": StateFailed})
+
+	rows, err := migrator.FullStatus(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get full status: %v", err)
+	}
+	if len(rows) != 2 || rows[1].State != StateFailed || rows[1].AppliedAt == nil {
+		t.Errorf("expected failed attempt in full status, got %+v", rows)
+	}
+
+	broken.upQueries = []string{"CREATE INDEX idx ON users (id)"}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to retry migration: %v", err)
+	}
+	assertHistoryStatuses(t, migrator, map[string]MigrationState{"1": StateApplied, "2": StateApplied})
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	assertHistoryStatuses(t, migrator, map[string]MigrationState{"1": StateApplied, "2": StateRolledBack})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to re-apply rolled back migration: %v", err)
+	}
+	assertHistoryStatuses(t, migrator, map[string]MigrationState{"1": StateApplied, "2": StateApplied})
+}
+
+func assertHistoryStatuses(t *testing.T, migrator *Migrator, expected map[string]MigrationState) {
+	t.Helper()
+
+	history, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	actual := make(map[string]MigrationState, len(history))
+	for _, status := range history {
+		actual[status.ID] = status.Status
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("expected history %v, got %v", expected, actual)
+	}
+}
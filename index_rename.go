@@ -0,0 +1,91 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+type indexRename struct {
+	from  string
+	to    string
+	index int
+	down  bool
+}
+
+type indexRenamer interface {
+	indexRenames() []indexRename
+}
+
+func (r *Migrator) resolveIndexRenames(ctx context.Context, tx Executor, migration Migration, direction Direction, queries []string) ([]string, error) {
+	renamer, ok := migration.(indexRenamer)
+	if !ok || (r.dialect != DialectMySQL && r.dialect != DialectSQLite) {
+		return queries, nil
+	}
+
+	var resolved []string
+	for _, rename := range renamer.indexRenames() {
+		index := rename.index
+		if rename.down {
+			index = len(queries) - 1 - rename.index
+		}
+		if rename.down != (direction == DirectionDown) || index < 0 || index >= len(queries) {
+			continue
+		}
+
+		query, err := r.renameIndexSQL(ctx, tx, r.tablePrefix+rename.from, r.tablePrefix+rename.to)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil {
+			resolved = make([]string, len(queries))
+			copy(resolved, queries)
+		}
+		resolved[index] = query
+	}
+	if resolved == nil {
+		return queries, nil
+	}
+	return resolved, nil
+}
+
+func (r *Migrator) renameIndexSQL(ctx context.Context, tx Executor, from, to string) (string, error) {
+	lookup := "SELECT DISTINCT table_name FROM information_schema.statistics WHERE table_schema = DATABASE() AND index_name = ?"
+	if r.dialect == DialectSQLite {
+		lookup = "SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ? AND sql IS NOT NULL"
+	}
+
+	rows, err := tx.Query(ctx, lookup, from)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var found string
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s", ErrIndexNotFound, from)
+	}
+	if err := rows.Scan(&found); err != nil {
+		return "", err
+	}
+
+	if r.dialect == DialectMySQL {
+		return fmt.Sprintf("ALTER TABLE %s RENAME INDEX %s TO %s;", found, from, to), nil
+	}
+
+	name := regexp.MustCompile(`\b` + regexp.QuoteMeta(from) + `\b`)
+	renamed := false
+	definition := name.ReplaceAllStringFunc(found, func(match string) string {
+		if renamed {
+			return match
+		}
+		renamed = true
+		return to
+	})
+	return fmt.Sprintf("DROP INDEX %s; %s;", from, definition), nil
+}
@@ -0,0 +1,64 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrationBuilder_RenameIndex(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "rename index").RenameIndex("idx_users_mail", "idx_users_email").Build()
+
+	if migration.Up()[0] != "ALTER INDEX idx_users_mail RENAME TO idx_users_email;" {
+		t.Errorf("unexpected up query %q", migration.Up()[0])
+	}
+	if migration.Down()[0] != "ALTER INDEX idx_users_email RENAME TO idx_users_mail;" {
+		t.Errorf("unexpected down query %q", migration.Down()[0])
+	}
+}
+
+func TestMigrator_RenameIndexRecreatesOnSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(CreateMigration("001", "create users").
+		CreateTable("users", "id INTEGER PRIMARY KEY", "email TEXT").
+		CreateUniqueIndex("idx_users_mail", "users", "email").
+		Build())
+	migrator.Register(CreateMigration("002", "rename index").RenameIndex("idx_users_mail", "idx_users_email").Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if exists, _ := migrator.HasIndex(ctx, "users", "idx_users_email"); !exists {
+		t.Error("expected index to be renamed")
+	}
+	if _, err := db.Exec("INSERT INTO users (email) VALUES ('a'), ('a')"); err == nil {
+		t.Error("expected recreated index to stay unique")
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back rename: %v", err)
+	}
+	if exists, _ := migrator.HasIndex(ctx, "users", "idx_users_mail"); !exists {
+		t.Error("expected index to get its old name back")
+	}
+
+	migrator.Register(CreateMigration("003", "rename missing index").RenameIndex("idx_missing", "idx_other").Build())
+	if _, err := migrator.Up(); !errors.Is(err, ErrIndexNotFound) {
+		t.Errorf("expected ErrIndexNotFound, got %v", err)
+	}
+}
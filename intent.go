@@ -0,0 +1,143 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const intentTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_intent (
+    module VARCHAR(255) NOT NULL,
+    direction VARCHAR(16) NOT NULL,
+    batch INTEGER NOT NULL,
+    ids TEXT NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    started_at BIGINT NOT NULL,
+    finished_at BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    PRIMARY KEY (module)
+);
+`
+
+type Intent struct {
+	Direction  Direction
+	Batch      int
+	IDs        []string
+	Owner      string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Error      string
+}
+
+func (i *Intent) Unfinished() bool {
+	return i.FinishedAt == nil
+}
+
+func (r *Migrator) LastIntent(ctx context.Context) (*Intent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.loadIntent(ctx)
+}
+
+func (r *Migrator) loadIntent(ctx context.Context) (*Intent, error) {
+	if _, err := r.conn.Exec(ctx, r.query(intentTableTemplate)); err != nil {
+		return nil, errors.Join(ErrFailedToWriteIntent, err)
+	}
+
+	rows, err := r.conn.Query(ctx, r.query("SELECT direction, batch, ids, owner, started_at, finished_at, error FROM %s_intent WHERE module = ?"), r.module())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	var intent Intent
+	var ids string
+	var startedAt, finishedAt int64
+	var errText *string
+	if err := rows.Scan(&intent.Direction, &intent.Batch, &ids, &intent.Owner, &startedAt, &finishedAt, &errText); err != nil {
+		return nil, err
+	}
+	if ids != "" {
+		intent.IDs = strings.Split(ids, ",")
+	}
+	intent.StartedAt = time.UnixMilli(startedAt).UTC()
+	if finishedAt > 0 {
+		finished := time.UnixMilli(finishedAt).UTC()
+		intent.FinishedAt = &finished
+	}
+	if errText != nil {
+		intent.Error = *errText
+	}
+	return &intent, rows.Err()
+}
+
+func (r *Migrator) beginIntent(ctx context.Context, direction Direction, batch int, ids []string, result *Result) error {
+	if !r.intentJournal {
+		return nil
+	}
+
+	previous, err := r.loadIntent(ctx)
+	if err != nil {
+		return err
+	}
+	if previous != nil && previous.Unfinished() {
+		result.Interrupted = previous
+		if r.logger != nil {
+			r.logger.LogAttrs(ctx, slog.LevelWarn, "previous migration run did not finish",
+				slog.String("direction", string(previous.Direction)),
+				slog.Int("batch", previous.Batch),
+				slog.String("ids", strings.Join(previous.IDs, ",")),
+				slog.String("owner", previous.Owner),
+				slog.Time("started_at", previous.StartedAt))
+		}
+	}
+
+	if _, err := r.conn.Exec(ctx, r.query("DELETE FROM %s_intent WHERE module = ?"), r.module()); err != nil {
+		return errors.Join(ErrFailedToWriteIntent, err)
+	}
+	_, err = r.conn.Exec(ctx, r.query("INSERT INTO %s_intent (module, direction, batch, ids, owner, started_at) VALUES (?, ?, ?, ?, ?, ?)"),
+		r.module(), string(direction), batch, strings.Join(ids, ","), r.intentOwner(), r.now().UnixMilli())
+	if err != nil {
+		return errors.Join(ErrFailedToWriteIntent, err)
+	}
+	return nil
+}
+
+func (r *Migrator) finishIntent(ctx context.Context, runErr error) error {
+	if !r.intentJournal {
+		return nil
+	}
+
+	var errText *string
+	if runErr != nil {
+		text := runErr.Error()
+		errText = &text
+	}
+	_, err := r.conn.Exec(ctx, r.query("UPDATE %s_intent SET finished_at = ?, error = ? WHERE module = ?"),
+		r.now().UnixMilli(), errText, r.module())
+	if err != nil {
+		return errors.Join(ErrFailedToWriteIntent, err)
+	}
+	return nil
+}
+
+func (r *Migrator) intentOwner() string {
+	switch {
+	case r.actor != "":
+		return r.actor
+	case r.lockOwner != "":
+		return r.lockOwner
+	default:
+		return defaultLockOwner()
+	}
+}
@@ -0,0 +1,62 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+func TestMigrator_IntentJournal(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	migrator := New(db, WithIntentJournal(), WithAuditLog("deployer"))
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build())
+
+	result, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if result.Interrupted != nil {
+		t.Errorf("expected no interrupted run on a fresh database, got %+v", result.Interrupted)
+	}
+
+	intent, err := migrator.LastIntent(ctx)
+	if err != nil {
+		t.Fatalf("failed to load intent: %v", err)
+	}
+	if intent == nil || intent.Unfinished() || intent.Owner != "deployer" || !slices.Equal(intent.IDs, []string{"001"}) {
+		t.Fatalf("expected a finished intent for 001, got %+v", intent)
+	}
+
+	if _, err := db.Exec("UPDATE schema_migrations_intent SET finished_at = 0, ids = '002,003'"); err != nil {
+		t.Fatalf("failed to simulate a crashed run: %v", err)
+	}
+	if intent, err := migrator.LastIntent(ctx); err != nil || !intent.Unfinished() {
+		t.Fatalf("expected unfinished intent to be surfaced, got %+v (%v)", intent, err)
+	}
+
+	migrator.Register(CreateMigration("002", "broken").RawUp("NOT SQL").Build())
+	result, err = migrator.Up()
+	if err == nil {
+		t.Fatal("expected broken migration to fail")
+	}
+	if result.Interrupted == nil || !slices.Equal(result.Interrupted.IDs, []string{"002", "003"}) {
+		t.Errorf("expected interrupted run to be reported, got %+v", result.Interrupted)
+	}
+
+	intent, err = migrator.LastIntent(ctx)
+	if err != nil || intent.Unfinished() || intent.Error == "" {
+		t.Errorf("expected failed run to be finalized with its error, got %+v (%v)", intent, err)
+	}
+}
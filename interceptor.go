@@ -0,0 +1,19 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+)
+
+type Interceptor func(ctx context.Context, migrationID, query string) (string, error)
+
+func (r *Migrator) intercept(ctx context.Context, migrationID, query string) (string, error) {
+	for _, interceptor := range r.interceptors {
+		rewritten, err := interceptor(ctx, migrationID, query)
+		if err != nil {
+			return "", errors.Join(ErrStatementRejected, err)
+		}
+		query = rewritten
+	}
+	return query, nil
+}
@@ -0,0 +1,54 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMigrator_WithInterceptor(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var mu sync.Mutex
+	var executed []string
+	migrator := New(db,
+		WithInterceptor(func(_ context.Context, migrationID, query string) (string, error) {
+			return "/* migration:" + migrationID + " */ " + query, nil
+		}),
+		WithInterceptor(func(_ context.Context, _, query string) (string, error) {
+			if strings.Contains(strings.ToUpper(query), "DROP TABLE") {
+				return "", errors.New("dropping tables is not allowed")
+			}
+			mu.Lock()
+			executed = append(executed, query)
+			mu.Unlock()
+			return query, nil
+		}),
+	)
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if len(executed) != 1 || executed[0] != "/* migration:001 */ CREATE TABLE users (id INTEGER)" {
+		t.Errorf("expected interceptors to run in order, got %v", executed)
+	}
+
+	migrator.Register(CreateMigration("002", "drop users").RawUp("DROP TABLE users").Build())
+	if _, err := migrator.Up(); !errors.Is(err, ErrStatementRejected) {
+		t.Fatalf("expected ErrStatementRejected, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001")
+}
@@ -0,0 +1,87 @@
+package migrator
+
+import (
+	"context"
+)
+
+type introspectionQueries struct {
+	table       string
+	column      string
+	index       string
+	constraint  string
+	tables      string
+	columnState string
+}
+
+var introspection = map[Dialect]introspectionQueries{
+	DialectPostgres: {
+		table: `SELECT COUNT(*) FROM information_schema.tables
+WHERE table_schema = current_schema() AND table_name = ?`,
+		column: `SELECT COUNT(*) FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`,
+		index: `SELECT COUNT(*) FROM pg_indexes
+WHERE schemaname = current_schema() AND tablename = ? AND indexname = ?`,
+		constraint: `SELECT COUNT(*) FROM information_schema.table_constraints
+WHERE table_schema = current_schema() AND table_name = ? AND constraint_name = ?`,
+		tables: `SELECT table_name FROM information_schema.tables
+WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'`,
+		columnState: `SELECT column_default, is_nullable = 'YES' FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`,
+	},
+	DialectMySQL: {
+		table: `SELECT COUNT(*) FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_name = ?`,
+		column: `SELECT COUNT(*) FROM information_schema.columns
+WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`,
+		index: `SELECT COUNT(*) FROM information_schema.statistics
+WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?`,
+		constraint: `SELECT COUNT(*) FROM information_schema.table_constraints
+WHERE table_schema = DATABASE() AND table_name = ? AND constraint_name = ?`,
+		tables: `SELECT table_name FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'`,
+	},
+	DialectSQLite: {
+		table:  `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+		column: `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`,
+		index:  `SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?`,
+		tables: `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`,
+	},
+}
+
+func (r *Migrator) HasTable(ctx context.Context, table string) (bool, error) {
+	return r.exists(ctx, introspection[r.dialect].table, table)
+}
+
+func (r *Migrator) HasColumn(ctx context.Context, table, column string) (bool, error) {
+	return r.exists(ctx, introspection[r.dialect].column, table, column)
+}
+
+func (r *Migrator) HasIndex(ctx context.Context, table, index string) (bool, error) {
+	return r.exists(ctx, introspection[r.dialect].index, table, index)
+}
+
+func (r *Migrator) HasConstraint(ctx context.Context, table, constraint string) (bool, error) {
+	return r.exists(ctx, introspection[r.dialect].constraint, table, constraint)
+}
+
+func (r *Migrator) exists(ctx context.Context, query string, args ...any) (bool, error) {
+	if query == "" {
+		return false, ErrUnsupportedDialect
+	}
+
+	rows, err := r.conn.Query(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+	return count > 0, rows.Err()
+}
@@ -0,0 +1,65 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Introspection_SQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT); CREATE INDEX idx_users_email ON users (email);")
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	ctx := context.Background()
+
+	checks := []struct {
+		name     string
+		check    func() (bool, error)
+		expected bool
+	}{
+		{name: "existing table", check: func() (bool, error) { return migrator.HasTable(ctx, "users") }, expected: true},
+		{name: "missing table", check: func() (bool, error) { return migrator.HasTable(ctx, "posts") }, expected: false},
+		{name: "existing column", check: func() (bool, error) { return migrator.HasColumn(ctx, "users", "email") }, expected: true},
+		{name: "missing column", check: func() (bool, error) { return migrator.HasColumn(ctx, "users", "name") }, expected: false},
+		{name: "existing index", check: func() (bool, error) { return migrator.HasIndex(ctx, "users", "idx_users_email") }, expected: true},
+		{name: "missing index", check: func() (bool, error) { return migrator.HasIndex(ctx, "users", "idx_users_name") }, expected: false},
+	}
+
+	for _, c := range checks {
+		got, err := c.check()
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", c.name, err)
+		}
+		if got != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+
+	if _, err := migrator.HasConstraint(ctx, "users", "pk_users"); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect for constraints on sqlite, got %v", err)
+	}
+}
+
+func TestMigrator_Introspection_GenericDialect(t *testing.T) {
+	t.Parallel()
+
+	migrator := &Migrator{}
+	if _, err := migrator.HasTable(context.Background(), "users"); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
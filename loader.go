@@ -0,0 +1,156 @@
+package migrator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+	gzipSuffix = ".gz"
+)
+
+type SQLFile struct {
+	ID          string
+	Description string
+	Up          string
+	Down        string
+	Repeatable  bool
+}
+
+func ReadSQLFiles(fsys fs.FS, dir string) ([]SQLFile, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*SQLFile)
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name, compressed := strings.CutSuffix(entry.Name(), gzipSuffix)
+		base, up := strings.CutSuffix(name, upSuffix)
+		if !up {
+			var down bool
+			if base, down = strings.CutSuffix(name, downSuffix); !down {
+				continue
+			}
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateMigrationID, name)
+		}
+		seen[name] = true
+
+		content, err := readSQLFile(fsys, path.Join(dir, entry.Name()), compressed)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := sqlFileFor(files, base)
+		if err != nil {
+			return nil, err
+		}
+		if up {
+			file.Up = content
+		} else {
+			file.Down = content
+		}
+	}
+
+	result := make([]SQLFile, 0, len(files))
+	for _, file := range files {
+		result = append(result, *file)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	for i := 1; i < len(result); i++ {
+		if result[i].ID == result[i-1].ID {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateMigrationID, result[i].ID)
+		}
+	}
+	return result, nil
+}
+
+func readSQLFile(fsys fs.FS, name string, compressed bool) (string, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", err
+	}
+	if !compressed {
+		return string(content), nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return string(decompressed), nil
+}
+
+func sqlFileFor(files map[string]*SQLFile, base string) (*SQLFile, error) {
+	if file, ok := files[base]; ok {
+		return file, nil
+	}
+
+	if name, ok := strings.CutPrefix(base, repeatablePrefix); ok {
+		if name == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMigrationFileName, base)
+		}
+		file := &SQLFile{ID: base, Description: strings.ReplaceAll(name, "_", " "), Repeatable: true}
+		files[base] = file
+		return file, nil
+	}
+
+	id, description, ok := strings.Cut(base, "_")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMigrationFileName, base)
+	}
+
+	file := &SQLFile{ID: id, Description: strings.ReplaceAll(description, "_", " ")}
+	files[base] = file
+	return file, nil
+}
+
+func (f SQLFile) Migration() Migration {
+	return &baseMigration{
+		id:          f.ID,
+		description: f.Description,
+		upQueries:   SplitStatements(f.Up),
+		downQueries: SplitStatements(f.Down),
+		repeatable:  f.Repeatable,
+	}
+}
+
+func LoadFS(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := ReadSQLFiles(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, len(files))
+	for i, file := range files {
+		migrations[i] = file.Migration()
+	}
+	return migrations, nil
+}
@@ -0,0 +1,120 @@
+package migrator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadSQLFiles(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"migrations/002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD COLUMN email TEXT;")},
+		"migrations/001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id INTEGER);\nCREATE INDEX idx_users_id ON users (id);")},
+		"migrations/001_create_users.down.sql": {Data: []byte("DROP INDEX idx_users_id;\nDROP TABLE users;")},
+		"migrations/README.md":                 {Data: []byte("ignored")},
+	}
+
+	files, err := ReadSQLFiles(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+	if files[0].ID != "001" || files[0].Description != "create users" {
+		t.Errorf("unexpected first file: %+v", files[0])
+	}
+	if files[1].ID != "002" || files[1].Down != "" {
+		t.Errorf("unexpected second file: %+v", files[1])
+	}
+
+	migration := files[0].Migration()
+	if len(migration.Up()) != 2 || len(migration.Down()) != 2 {
+		t.Errorf("expected 2 up and 2 down statements, got %q and %q", migration.Up(), migration.Down())
+	}
+	if migration.Down()[0] != "DROP INDEX idx_users_id" {
+		t.Errorf("expected down statements in file order, got %q", migration.Down())
+	}
+}
+
+func TestReadSQLFiles_Gzip(t *testing.T) {
+	t.Parallel()
+
+	up := "CREATE TABLE seeds (id INTEGER);\nINSERT INTO seeds VALUES (1);"
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(up)); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+
+	files, err := ReadSQLFiles(fstest.MapFS{
+		"m/001_seed.up.sql.gz": {Data: compressed.Bytes()},
+		"m/001_seed.down.sql":  {Data: []byte("DROP TABLE seeds;")},
+	}, "m")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(files) != 1 || files[0].Up != up || files[0].Down != "DROP TABLE seeds;" {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+
+	plain := SQLFile{ID: "001", Description: "seed", Up: up}
+	if mustChecksum(t, files[0].Migration()) != mustChecksum(t, plain.Migration()) {
+		t.Errorf("expected checksum of decompressed content to match the plain file")
+	}
+
+	_, err = ReadSQLFiles(fstest.MapFS{
+		"m/001_seed.up.sql":    {Data: []byte(up)},
+		"m/001_seed.up.sql.gz": {Data: compressed.Bytes()},
+	}, "m")
+	if !errors.Is(err, ErrDuplicateMigrationID) {
+		t.Errorf("expected ErrDuplicateMigrationID, got %v", err)
+	}
+
+	_, err = ReadSQLFiles(fstest.MapFS{
+		"m/001_seed.up.sql.gz": {Data: []byte("not gzip")},
+	}, "m")
+	if err == nil {
+		t.Error("expected error for corrupt gzip file")
+	}
+}
+
+func TestReadSQLFiles_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadSQLFiles(fstest.MapFS{
+		"m/nodescription.up.sql": {Data: []byte("SELECT 1")},
+	}, "m")
+	if !errors.Is(err, ErrInvalidMigrationFileName) {
+		t.Errorf("expected ErrInvalidMigrationFileName, got %v", err)
+	}
+
+	_, err = ReadSQLFiles(fstest.MapFS{
+		"m/001_a.up.sql": {Data: []byte("SELECT 1")},
+		"m/001_b.up.sql": {Data: []byte("SELECT 2")},
+	}, "m")
+	if !errors.Is(err, ErrDuplicateMigrationID) {
+		t.Errorf("expected ErrDuplicateMigrationID, got %v", err)
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := LoadFS(fstest.MapFS{
+		"m/001_init.up.sql": {Data: []byte("CREATE TABLE t (id INTEGER)")},
+	}, "m")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(migrations) != 1 || migrations[0].ID() != "001" {
+		t.Errorf("unexpected migrations: %v", migrations)
+	}
+}
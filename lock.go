@@ -0,0 +1,137 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+const lockTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_lock (
+    module VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (module)
+);
+`
+
+const maxLockPollInterval = time.Second
+
+type lease struct {
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func defaultLockOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+func (r *Migrator) acquireLock(ctx context.Context) (*lease, error) {
+	if r.lockLease <= 0 {
+		return nil, nil
+	}
+
+	if _, err := r.conn.Exec(ctx, r.query(lockTableTemplate)); err != nil {
+		return nil, errors.Join(ErrFailedToAcquireLock, err)
+	}
+
+	poll := min(r.lockLease/5, maxLockPollInterval)
+	for {
+		acquired, err := r.tryLock(ctx)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToAcquireLock, err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(ErrFailedToAcquireLock, ctx.Err())
+		case <-time.After(poll):
+		}
+	}
+
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+	l := &lease{cancel: cancel}
+	l.done.Add(1)
+	go func() {
+		defer l.done.Done()
+		r.heartbeat(heartbeatCtx)
+	}()
+	return l, nil
+}
+
+func (r *Migrator) tryLock(ctx context.Context) (bool, error) {
+	now := r.now()
+	expires := now.Add(r.lockLease).UnixMilli()
+
+	_, err := r.conn.Exec(ctx,
+		r.query("INSERT INTO %s_lock (module, owner, acquired_at, expires_at) VALUES (?, ?, ?, ?)"),
+		r.module(), r.lockOwner, now.UnixMilli(), expires)
+	if err == nil {
+		return true, nil
+	}
+
+	res, err := r.conn.Exec(ctx,
+		r.query("UPDATE %s_lock SET owner = ?, acquired_at = ?, expires_at = ? WHERE module = ? AND (expires_at < ? OR owner = ?)"),
+		r.lockOwner, now.UnixMilli(), expires, r.module(), now.UnixMilli(), r.lockOwner)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *Migrator) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(r.lockLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		res, err := r.conn.Exec(ctx,
+			r.query("UPDATE %s_lock SET expires_at = ? WHERE module = ? AND owner = ?"),
+			r.now().Add(r.lockLease).UnixMilli(), r.module(), r.lockOwner)
+		if err == nil {
+			var affected int64
+			if affected, err = res.RowsAffected(); err == nil && affected == 0 {
+				err = ErrLockLost
+			}
+		}
+		if err != nil && ctx.Err() == nil && r.logger != nil {
+			r.logger.LogAttrs(ctx, slog.LevelError, "failed to renew migration lock",
+				slog.String("owner", r.lockOwner), slog.Any("error", err))
+		}
+	}
+}
+
+func (r *Migrator) releaseLock(ctx context.Context, l *lease) error {
+	if l == nil {
+		return nil
+	}
+	l.cancel()
+	l.done.Wait()
+
+	_, err := r.conn.Exec(ctx, r.query("DELETE FROM %s_lock WHERE module = ? AND owner = ?"), r.module(), r.lockOwner)
+	if err != nil {
+		return errors.Join(ErrFailedToReleaseLock, err)
+	}
+	return nil
+}
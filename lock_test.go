@@ -0,0 +1,161 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newLockTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func TestMigrator_LockTable(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("deployer-1", time.Minute))
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "schema_migrations_lock"); n != 0 {
+		t.Errorf("expected lock to be released, got %d rows", n)
+	}
+}
+
+func TestMigrator_LockTableWaitsForHolder(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("deployer-2", time.Minute))
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	if _, err := db.Exec(lockTableSQL()); err != nil {
+		t.Fatalf("failed to create lock table: %v", err)
+	}
+	expires := time.Now().Add(time.Hour).UnixMilli()
+	if _, err := db.Exec("INSERT INTO schema_migrations_lock VALUES ('', 'deployer-1', 0, ?)", expires); err != nil {
+		t.Fatalf("failed to insert lock: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := migrator.Up()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected Up to wait for the lock holder, got %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if _, err := db.Exec("DELETE FROM schema_migrations_lock"); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Up to proceed after the lock was released")
+	}
+}
+
+func TestMigrator_LockTableReclaimsStaleLease(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("deployer-2", time.Minute))
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	if _, err := db.Exec(lockTableSQL()); err != nil {
+		t.Fatalf("failed to create lock table: %v", err)
+	}
+	expired := time.Now().Add(-time.Minute).UnixMilli()
+	if _, err := db.Exec("INSERT INTO schema_migrations_lock VALUES ('', 'crashed', 0, ?)", expired); err != nil {
+		t.Fatalf("failed to insert lock: %v", err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected stale lease to be reclaimed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
+
+func TestMigrator_LockHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("deployer-1", 90*time.Millisecond))
+
+	ctx := context.Background()
+	lock, err := migrator.acquireLock(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var first int64
+	if err := db.QueryRow("SELECT expires_at FROM schema_migrations_lock").Scan(&first); err != nil {
+		t.Fatalf("failed to read lease: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	var renewed int64
+	if err := db.QueryRow("SELECT expires_at FROM schema_migrations_lock").Scan(&renewed); err != nil {
+		t.Fatalf("failed to read lease: %v", err)
+	}
+	if renewed <= first {
+		t.Errorf("expected heartbeat to extend the lease, got %d then %d", first, renewed)
+	}
+
+	if err := migrator.releaseLock(ctx, lock); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	other := New(db, WithLockTable("deployer-2", time.Minute))
+	if _, err := db.Exec("INSERT INTO schema_migrations_lock VALUES ('', 'deployer-1', 0, ?)", time.Now().Add(time.Hour).UnixMilli()); err != nil {
+		t.Fatalf("failed to insert lock: %v", err)
+	}
+	if _, err := other.acquireLock(cancelled); !errors.Is(err, ErrFailedToAcquireLock) {
+		t.Errorf("expected ErrFailedToAcquireLock, got %v", err)
+	}
+}
+
+func lockTableSQL() string {
+	return New(nil).query(lockTableTemplate)
+}
+
+func TestMigrator_LockTableSurvivesFresh(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithDialect(DialectSQLite), WithLockTable("deployer-1", time.Minute))
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE users (id INTEGER)"}})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := migrator.Fresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
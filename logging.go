@@ -0,0 +1,37 @@
+package migrator
+
+import (
+	"context"
+	"log/slog"
+)
+
+func (r *Migrator) logStatement(ctx context.Context, event Event, res ExecResult) {
+	if r.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("migration_id", event.MigrationID),
+		slog.String("direction", string(event.Direction)),
+		slog.Int("batch", event.Batch),
+		slog.String("statement", event.Statement),
+		slog.Duration("duration", event.Duration),
+	}
+
+	if event.Err != nil {
+		attrs = append(attrs, slog.Any("error", event.Err))
+		r.logger.LogAttrs(ctx, slog.LevelError, "migration statement failed", attrs...)
+		return
+	}
+
+	if res != nil {
+		if affected, err := res.RowsAffected(); err == nil {
+			attrs = append(attrs, slog.Int64("rows_affected", affected))
+		}
+	}
+	if event.RowsAffected == 0 {
+		r.logger.LogAttrs(ctx, slog.LevelWarn, "migration statement affected no rows", attrs...)
+		return
+	}
+	r.logger.LogAttrs(ctx, slog.LevelDebug, "migration statement executed", attrs...)
+}
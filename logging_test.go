@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	migrator := New(db, WithLogger(logger))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{
+		"CREATE TABLE a (id INTEGER)",
+		"INSERT INTO a (id) VALUES (1), (2)",
+	}})
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 statement log lines, got:\n%s", buf.String())
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("failed to decode log line: %v", err)
+	}
+	if record["migration_id"] != "1" || record["rows_affected"] != float64(2) || record["level"] != "DEBUG" {
+		t.Errorf("unexpected log record: %v", record)
+	}
+	if _, ok := record["duration"]; !ok {
+		t.Errorf("expected duration field, got %v", record)
+	}
+
+	buf.Reset()
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"INSERT INTO missing VALUES (1)"}})
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected migration to fail")
+	}
+	if !strings.Contains(buf.String(), `"level":"ERROR"`) || !strings.Contains(buf.String(), "no such table") {
+		t.Errorf("expected failed statement to be logged as error, got:\n%s", buf.String())
+	}
+}
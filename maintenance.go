@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type MaintenanceWindow struct {
+	Days     []time.Weekday
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+func ParseMaintenanceWindow(spec string, location *time.Location) (MaintenanceWindow, error) {
+	window := MaintenanceWindow{Location: location}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 2 {
+		for _, name := range strings.Split(fields[0], ",") {
+			day, ok := weekdays[strings.ToUpper(name)]
+			if !ok {
+				return window, fmt.Errorf("%w: unknown day %q", ErrInvalidMaintenanceWindow, name)
+			}
+			window.Days = append(window.Days, day)
+		}
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return window, fmt.Errorf("%w: expected \"[days] HH:MM-HH:MM\", got %q", ErrInvalidMaintenanceWindow, spec)
+	}
+
+	start, end, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return window, fmt.Errorf("%w: expected a time range, got %q", ErrInvalidMaintenanceWindow, fields[0])
+	}
+	var err error
+	if window.Start, err = parseClockTime(start); err != nil {
+		return window, err
+	}
+	if window.End, err = parseClockTime(end); err != nil {
+		return window, err
+	}
+	return window, nil
+}
+
+func parseClockTime(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time %q", ErrInvalidMaintenanceWindow, value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func (w MaintenanceWindow) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+func (w MaintenanceWindow) onDay(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, day)
+}
+
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	local := t.In(w.location())
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	offset := local.Sub(midnight)
+
+	if w.Start < w.End {
+		return w.onDay(local.Weekday()) && offset >= w.Start && offset < w.End
+	}
+	return (w.onDay(local.Weekday()) && offset >= w.Start) ||
+		(w.onDay(midnight.AddDate(0, 0, -1).Weekday()) && offset < w.End)
+}
+
+func (w MaintenanceWindow) Next(t time.Time) time.Time {
+	local := t.In(w.location())
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	for day := 0; day <= 7; day++ {
+		date := midnight.AddDate(0, 0, day)
+		start := date.Add(w.Start)
+		if w.onDay(date.Weekday()) && start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+func IgnoreMaintenanceWindow() RunOption {
+	return func(c *runConfig) {
+		c.ignoreMaintenanceWindow = true
+	}
+}
+
+func (r *Migrator) checkMaintenanceWindow(config runConfig, build func() (*Plan, error)) error {
+	if len(r.maintenanceWindows) == 0 || config.ignoreMaintenanceWindow {
+		return nil
+	}
+
+	now := r.now()
+	var next time.Time
+	for _, window := range r.maintenanceWindows {
+		if window.Contains(now) {
+			return nil
+		}
+		if start := window.Next(now); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+
+	plan, err := build()
+	if err != nil {
+		return err
+	}
+	if !plan.Blocking() && !plan.Destructive() {
+		return nil
+	}
+	if next.IsZero() {
+		return ErrOutsideMaintenanceWindow
+	}
+	return fmt.Errorf("%w: next window opens at %s", ErrOutsideMaintenanceWindow, next.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,75 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	window, err := ParseMaintenanceWindow("Sat,Sun 22:00-04:00", time.UTC)
+	if err != nil {
+		t.Fatalf("failed to parse window: %v", err)
+	}
+
+	saturdayNight := time.Date(2026, time.October, 17, 23, 0, 0, 0, time.UTC)
+	sundayEarly := time.Date(2026, time.October, 18, 3, 0, 0, 0, time.UTC)
+	mondayEarly := time.Date(2026, time.October, 19, 3, 0, 0, 0, time.UTC)
+	saturdayNoon := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	if !window.Contains(saturdayNight) || !window.Contains(sundayEarly) || !window.Contains(mondayEarly) {
+		t.Error("expected overnight window to include the night after each listed day")
+	}
+	if window.Contains(saturdayNoon) {
+		t.Error("expected noon to be outside the window")
+	}
+	if next := window.Next(saturdayNoon); !next.Equal(time.Date(2026, time.October, 17, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next window start: %s", next)
+	}
+
+	for _, spec := range []string{"Funday 01:00-02:00", "01:00", "25:00-26:00"} {
+		if _, err := ParseMaintenanceWindow(spec, nil); !errors.Is(err, ErrInvalidMaintenanceWindow) {
+			t.Errorf("%s: expected ErrInvalidMaintenanceWindow, got %v", spec, err)
+		}
+	}
+}
+
+func TestMigrator_MaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	window, err := ParseMaintenanceWindow("02:00-05:00", time.UTC)
+	if err != nil {
+		t.Fatalf("failed to parse window: %v", err)
+	}
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	migrator := New(db, WithMaintenanceWindow(window), WithClock(ClockFunc(func() time.Time { return now })))
+	migrator.Register(CreateMigration("001", "create users").CreateTable("users", "id INTEGER", "email TEXT").Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected safe migration to run outside the window, got %v", err)
+	}
+
+	migrator.Register(CreateMigration("002", "index email").CreateIndex("idx_users_email", "users", "email").Build())
+	_, err = migrator.Up()
+	if !errors.Is(err, ErrOutsideMaintenanceWindow) || !strings.Contains(err.Error(), "2026-10-17T02:00:00Z") {
+		t.Fatalf("expected ErrOutsideMaintenanceWindow with the next window, got %v", err)
+	}
+
+	if _, err := migrator.Up(IgnoreMaintenanceWindow()); err != nil {
+		t.Fatalf("expected override to run the migration, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "002")
+}
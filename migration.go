@@ -2,6 +2,8 @@ package migrator
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 )
@@ -18,6 +20,9 @@ type MigrationStatus struct {
 	Description string
 	AppliedAt   *time.Time
 	Batch       int
+	Checksum    string
+	Duration    time.Duration
+	Status      MigrationState
 }
 
 type baseMigration struct {
@@ -25,6 +30,18 @@ type baseMigration struct {
 	description string
 	upQueries   []string
 	downQueries []string
+	tags        []string
+	concurrent  []string
+	chunked     []int
+	depends     []string
+	checks      []Check
+	repeatable  bool
+	protected   bool
+	group       string
+	columns     []columnChange
+	variants    []dialectVariant
+	renames     []indexRename
+	unsupported []Dialect
 }
 
 func (m *baseMigration) ID() string {
@@ -36,11 +53,70 @@ func (m *baseMigration) Description() string {
 }
 
 func (m *baseMigration) Up() []string {
-	return m.upQueries
+	return stripIdentifiers(m.upQueries)
 }
 
 func (m *baseMigration) Down() []string {
-	return m.downQueries
+	return stripIdentifiers(m.downQueries)
+}
+
+func (m *baseMigration) markedQueries(direction Direction) []string {
+	if direction == DirectionDown {
+		return m.downQueries
+	}
+	return m.upQueries
+}
+
+func (m *baseMigration) NoTransaction() bool {
+	return len(m.concurrent) > 0 || len(m.chunked) > 0
+}
+
+func (m *baseMigration) Repeatable() bool {
+	return m.repeatable
+}
+
+func (m *baseMigration) Protected() bool {
+	return m.protected
+}
+
+func (m *baseMigration) Group() string {
+	return m.group
+}
+
+func (m *baseMigration) chunkedQueries() []int {
+	return m.chunked
+}
+
+func (m *baseMigration) concurrentIndexes() []string {
+	return m.concurrent
+}
+
+func (m *baseMigration) DependsOn() []string {
+	return m.depends
+}
+
+func (m *baseMigration) Checks() []Check {
+	return m.checks
+}
+
+func (m *baseMigration) columnChanges() []columnChange {
+	return m.columns
+}
+
+func (m *baseMigration) dialectVariants() []dialectVariant {
+	return m.variants
+}
+
+func (m *baseMigration) unsupportedDialects() []Dialect {
+	return m.unsupported
+}
+
+func (m *baseMigration) indexRenames() []indexRename {
+	return m.renames
+}
+
+func (m *baseMigration) Tags() []string {
+	return m.tags
 }
 
 func (m *baseMigration) AddUp(query string) *baseMigration {
@@ -53,6 +129,65 @@ func (m *baseMigration) AddDown(query string) *baseMigration {
 	return m
 }
 
+const copyChunkSize = 1000
+
+type TableOptions struct {
+	Engine     string
+	Charset    string
+	Collation  string
+	RowFormat  string
+	Tablespace string
+	Storage    map[string]string
+}
+
+func (o TableOptions) storageClause() string {
+	var clause strings.Builder
+	if len(o.Storage) > 0 {
+		clause.WriteString(" WITH (" + storageParameters(o.Storage) + ")")
+	}
+	if o.Tablespace != "" {
+		clause.WriteString(" TABLESPACE " + o.Tablespace)
+	}
+	return clause.String()
+}
+
+func storageParameters(parameters map[string]string) string {
+	names := slices.Sorted(maps.Keys(parameters))
+	assignments := make([]string, len(names))
+	for i, name := range names {
+		assignments[i] = name + " = " + parameters[name]
+	}
+	return strings.Join(assignments, ", ")
+}
+
+func (o TableOptions) tableClause() string {
+	var clause strings.Builder
+	if o.Engine != "" {
+		clause.WriteString(" ENGINE=" + o.Engine)
+	}
+	if o.Charset != "" {
+		clause.WriteString(" DEFAULT CHARSET=" + o.Charset)
+	}
+	if o.Collation != "" {
+		clause.WriteString(" COLLATE=" + o.Collation)
+	}
+	if o.RowFormat != "" {
+		clause.WriteString(" ROW_FORMAT=" + o.RowFormat)
+	}
+	return clause.String()
+}
+
+func (o TableOptions) columnClause() string {
+	var clause strings.Builder
+	if o.Charset != "" {
+		clause.WriteString(" CHARACTER SET " + o.Charset)
+	}
+	if o.Collation != "" {
+		clause.WriteString(" COLLATE " + o.Collation)
+	}
+	return clause.String()
+}
+
 type MigrationBuilder struct {
 	migration *baseMigration
 }
@@ -70,62 +205,245 @@ func CreateMigration(id, description string) *MigrationBuilder {
 
 func (b *MigrationBuilder) CreateTable(tableName string, columns ...string) *MigrationBuilder {
 	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n    %s\n);",
-		tableName, strings.Join(columns, ",\n    "))
+		identifier(tableName), strings.Join(columns, ",\n    "))
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("DROP TABLE IF EXISTS %s;", tableName))
+	b.migration.AddDown(fmt.Sprintf("DROP TABLE IF EXISTS %s;", identifier(tableName)))
 	return b
 }
 
 func (b *MigrationBuilder) DropTable(tableName string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("DROP TABLE IF EXISTS %s;", tableName))
+	b.migration.AddUp(fmt.Sprintf("DROP TABLE IF EXISTS %s;", identifier(tableName)))
 	b.migration.AddDown(fmt.Sprintf("-- Cannot restore dropped table %s", tableName))
 	return b
 }
 
+func (b *MigrationBuilder) CreateTableWithOptions(tableName string, options TableOptions, columns ...string) *MigrationBuilder {
+	b.CreateTable(tableName, columns...)
+	b.withOptionVariants(options.storageClause(), options.tableClause())
+	return b
+}
+
+func (b *MigrationBuilder) withOptionVariants(postgres, mysql string) {
+	index := len(b.migration.upQueries) - 1
+	plain := b.migration.upQueries[index]
+	b.migration.upQueries[index] = strings.TrimSuffix(plain, ";") + postgres + ";"
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: index, dialect: DialectMySQL, query: strings.TrimSuffix(plain, ";") + mysql + ";"},
+		dialectVariant{index: index, dialect: DialectSQLite, query: plain},
+	)
+}
+
+func (b *MigrationBuilder) CreateTableLike(newTable, sourceTable string, includingIndexes bool) *MigrationBuilder {
+	copyEmpty := fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s WHERE 1 = 0;", identifier(newTable), identifier(sourceTable))
+	postgres := fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL);", identifier(newTable), identifier(sourceTable))
+	mysql := fmt.Sprintf("CREATE TABLE %s LIKE %s;", identifier(newTable), identifier(sourceTable))
+	if !includingIndexes {
+		postgres = fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS);", identifier(newTable), identifier(sourceTable))
+		mysql = copyEmpty
+	}
+
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectMySQL, query: mysql},
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectSQLite, query: copyEmpty},
+	)
+	b.migration.AddUp(postgres)
+	b.migration.AddDown(fmt.Sprintf("DROP TABLE IF EXISTS %s;", identifier(newTable)))
+	return b
+}
+
+func (b *MigrationBuilder) TruncateTable(tableName string, cascade bool) *MigrationBuilder {
+	query := fmt.Sprintf("TRUNCATE TABLE %s;", identifier(tableName))
+	if cascade {
+		query = fmt.Sprintf("TRUNCATE TABLE %s CASCADE;", identifier(tableName))
+	}
+	b.migration.AddUp(query)
+	b.migration.AddDown(fmt.Sprintf("-- Cannot restore rows removed by truncating %s", tableName))
+	return b
+}
+
+func (b *MigrationBuilder) SwapTables(tableA, tableB string) *MigrationBuilder {
+	temporary := identifier(tableA + "_swap")
+	tableA, tableB = identifier(tableA), identifier(tableB)
+	query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s; ALTER TABLE %s RENAME TO %s; ALTER TABLE %s RENAME TO %s;",
+		tableA, temporary, tableB, tableA, temporary, tableB)
+	mysql := fmt.Sprintf("RENAME TABLE %s TO %s, %s TO %s, %s TO %s;", tableA, temporary, tableB, tableA, temporary, tableB)
+
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectMySQL, query: mysql},
+		dialectVariant{index: len(b.migration.downQueries), down: true, dialect: DialectMySQL, query: mysql},
+	)
+	b.migration.AddUp(query)
+	b.migration.AddDown(query)
+	return b
+}
+
 func (b *MigrationBuilder) AddColumn(tableName, columnDef string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", tableName, columnDef))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", identifier(tableName), columnDef))
 
 	columnName := strings.Fields(columnDef)[0]
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, columnName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", identifier(tableName), columnName))
 	return b
 }
 
+func (b *MigrationBuilder) AddGeometryColumn(tableName, columnName, geometryType string, srid int) *MigrationBuilder {
+	return b.addSpatialColumn(tableName, columnName, "geometry", geometryType, srid)
+}
+
+func (b *MigrationBuilder) AddGeographyColumn(tableName, columnName, geometryType string, srid int) *MigrationBuilder {
+	return b.addSpatialColumn(tableName, columnName, "geography", geometryType, srid)
+}
+
+func (b *MigrationBuilder) addSpatialColumn(tableName, columnName, kind, geometryType string, srid int) *MigrationBuilder {
+	b.requirePostGIS()
+	b.AddColumn(tableName, fmt.Sprintf("%s %s(%s, %d)", columnName, kind, geometryType, srid))
+	b.migration.variants = append(b.migration.variants, dialectVariant{
+		index:   len(b.migration.upQueries) - 1,
+		dialect: DialectMySQL,
+		query:   fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s SRID %d;", identifier(tableName), columnName, strings.ToUpper(geometryType), srid),
+	})
+	return b
+}
+
+func (b *MigrationBuilder) requirePostGIS() {
+	const extension = "CREATE EXTENSION IF NOT EXISTS postgis;"
+	b.unsupportedOn(DialectSQLite)
+	if slices.Contains(b.migration.upQueries, extension) {
+		return
+	}
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectMySQL},
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectSQLite},
+	)
+	b.migration.AddUp(extension)
+}
+
 func (b *MigrationBuilder) DropColumn(tableName, columnName string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, columnName))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", identifier(tableName), columnName))
 	b.migration.AddDown(fmt.Sprintf("-- Cannot restore dropped column %s.%s without definition", tableName, columnName))
 	return b
 }
 
 func (b *MigrationBuilder) RenameColumn(tableName, oldName, newName string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", tableName, oldName, newName))
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", tableName, newName, oldName))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", identifier(tableName), oldName, newName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", identifier(tableName), newName, oldName))
 	return b
 }
 
 func (b *MigrationBuilder) ChangeColumn(tableName, columnName, newDefinition string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", tableName, columnName, newDefinition))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", identifier(tableName), columnName, newDefinition))
 	b.migration.AddDown(fmt.Sprintf("-- Cannot reverse column change for %s.%s", tableName, columnName))
 	return b
 }
 
+func (b *MigrationBuilder) ChangeColumnWithOptions(tableName, columnName, newDefinition string, options TableOptions) *MigrationBuilder {
+	b.ChangeColumn(tableName, columnName, newDefinition)
+	query := fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s%s;", identifier(tableName), columnName, newDefinition, options.columnClause())
+	b.migration.variants = append(b.migration.variants, dialectVariant{index: len(b.migration.upQueries) - 1, dialect: DialectMySQL, query: query})
+	return b
+}
+
+func (b *MigrationBuilder) SetColumnDefault(tableName, columnName, expression string) *MigrationBuilder {
+	return b.alterColumn(tableName, columnName, columnDefault, "SET DEFAULT "+expression, "DROP DEFAULT")
+}
+
+func (b *MigrationBuilder) DropColumnDefault(tableName, columnName string) *MigrationBuilder {
+	return b.alterColumn(tableName, columnName, columnDefault, "DROP DEFAULT", "SET DEFAULT NULL")
+}
+
+func (b *MigrationBuilder) SetNotNull(tableName, columnName string) *MigrationBuilder {
+	return b.alterColumn(tableName, columnName, columnNullable, "SET NOT NULL", "DROP NOT NULL")
+}
+
+func (b *MigrationBuilder) DropNotNull(tableName, columnName string) *MigrationBuilder {
+	return b.alterColumn(tableName, columnName, columnNullable, "DROP NOT NULL", "SET NOT NULL")
+}
+
+func (b *MigrationBuilder) alterColumn(tableName, columnName string, attribute columnAttribute, up, down string) *MigrationBuilder {
+	b.unsupportedOn(DialectMySQL, DialectSQLite)
+	return b.columnStatement(tableName, columnName, attribute, up, down)
+}
+
+func (b *MigrationBuilder) columnStatement(tableName, columnName string, attribute columnAttribute, up, down string) *MigrationBuilder {
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", identifier(tableName), columnName, up))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", identifier(tableName), columnName, down))
+	b.migration.columns = append(b.migration.columns, columnChange{
+		table:     tableName,
+		column:    columnName,
+		attribute: attribute,
+		position:  len(b.migration.downQueries) - 1,
+	})
+	return b
+}
+
+func (b *MigrationBuilder) unsupportedOn(dialects ...Dialect) {
+	for _, dialect := range dialects {
+		if !slices.Contains(b.migration.unsupported, dialect) {
+			b.migration.unsupported = append(b.migration.unsupported, dialect)
+		}
+	}
+}
+
 func (b *MigrationBuilder) CreateIndex(indexName, tableName string, columns ...string) *MigrationBuilder {
 	query := fmt.Sprintf("CREATE INDEX %s ON %s (%s);",
-		indexName, tableName, strings.Join(columns, ", "))
+		identifier(indexName), identifier(tableName), strings.Join(columns, ", "))
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName))
+	b.migration.AddDown(fmt.Sprintf("DROP INDEX IF EXISTS %s;", identifier(indexName)))
 	return b
 }
 
 func (b *MigrationBuilder) CreateUniqueIndex(indexName, tableName string, columns ...string) *MigrationBuilder {
 	query := fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);",
-		indexName, tableName, strings.Join(columns, ", "))
+		identifier(indexName), identifier(tableName), strings.Join(columns, ", "))
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName))
+	b.migration.AddDown(fmt.Sprintf("DROP INDEX IF EXISTS %s;", identifier(indexName)))
+	return b
+}
+
+func (b *MigrationBuilder) CreateIndexConcurrently(indexName, tableName string, columns ...string) *MigrationBuilder {
+	query := fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s);",
+		identifier(indexName), identifier(tableName), strings.Join(columns, ", "))
+	b.migration.AddUp(query)
+	b.migration.AddDown(fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s;", identifier(indexName)))
+	b.migration.concurrent = append(b.migration.concurrent, indexName)
+	return b
+}
+
+func (b *MigrationBuilder) RenameIndex(oldName, newName string) *MigrationBuilder {
+	b.migration.renames = append(b.migration.renames,
+		indexRename{from: oldName, to: newName, index: len(b.migration.upQueries)},
+		indexRename{from: newName, to: oldName, index: len(b.migration.downQueries), down: true},
+	)
+	b.migration.AddUp(fmt.Sprintf("ALTER INDEX %s RENAME TO %s;", identifier(oldName), identifier(newName)))
+	b.migration.AddDown(fmt.Sprintf("ALTER INDEX %s RENAME TO %s;", identifier(newName), identifier(oldName)))
+	return b
+}
+
+func (b *MigrationBuilder) CreateIndexWithOptions(indexName, tableName string, options TableOptions, columns ...string) *MigrationBuilder {
+	b.CreateIndex(indexName, tableName, columns...)
+	b.withOptionVariants(options.storageClause(), "")
+	return b
+}
+
+func (b *MigrationBuilder) SetStorageParameters(tableName string, parameters map[string]string) *MigrationBuilder {
+	names := slices.Sorted(maps.Keys(parameters))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s SET (%s);", identifier(tableName), storageParameters(parameters)))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s RESET (%s);", identifier(tableName), strings.Join(names, ", ")))
+	return b
+}
+
+func (b *MigrationBuilder) CreateSpatialIndex(indexName, tableName, columnName string) *MigrationBuilder {
+	b.unsupportedOn(DialectSQLite)
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectMySQL, query: fmt.Sprintf("CREATE SPATIAL INDEX %s ON %s (%s);", identifier(indexName), identifier(tableName), columnName)},
+		dialectVariant{index: len(b.migration.downQueries), down: true, dialect: DialectMySQL, query: fmt.Sprintf("DROP INDEX %s ON %s;", identifier(indexName), identifier(tableName))},
+	)
+	b.migration.AddUp(fmt.Sprintf("CREATE INDEX %s ON %s USING GIST (%s);", identifier(indexName), identifier(tableName), columnName))
+	b.migration.AddDown(fmt.Sprintf("DROP INDEX IF EXISTS %s;", identifier(indexName)))
 	return b
 }
 
 func (b *MigrationBuilder) DropIndex(indexName string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName))
+	b.migration.AddUp(fmt.Sprintf("DROP INDEX IF EXISTS %s;", identifier(indexName)))
 	b.migration.AddDown(fmt.Sprintf("-- Cannot restore dropped index %s without definition", indexName))
 	return b
 }
@@ -133,39 +451,86 @@ func (b *MigrationBuilder) DropIndex(indexName string) *MigrationBuilder {
 func (b *MigrationBuilder) AddForeignKey(tableName, columnName, refTable, refColumn string) *MigrationBuilder {
 	constraintName := fmt.Sprintf("fk_%s_%s", tableName, columnName)
 	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s);",
-		tableName, constraintName, columnName, refTable, refColumn)
+		identifier(tableName), constraintName, columnName, identifier(refTable), refColumn)
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", tableName, constraintName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
 	return b
 }
 
 func (b *MigrationBuilder) AddForeignKeyWithName(tableName, constraintName, columnName, refTable, refColumn string) *MigrationBuilder {
 	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s);",
-		tableName, constraintName, columnName, refTable, refColumn)
+		identifier(tableName), constraintName, columnName, identifier(refTable), refColumn)
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", tableName, constraintName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
 	return b
 }
 
 func (b *MigrationBuilder) DropForeignKey(tableName, constraintName string) *MigrationBuilder {
-	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", tableName, constraintName))
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
 	b.migration.AddDown(fmt.Sprintf("-- Cannot restore dropped foreign key %s", constraintName))
 	return b
 }
 
 func (b *MigrationBuilder) AddPrimaryKey(tableName, constraintName string, columns ...string) *MigrationBuilder {
 	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);",
-		tableName, constraintName, strings.Join(columns, ", "))
+		identifier(tableName), constraintName, strings.Join(columns, ", "))
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", tableName, constraintName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
 	return b
 }
 
 func (b *MigrationBuilder) AddCheck(tableName, constraintName, condition string) *MigrationBuilder {
 	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);",
-		tableName, constraintName, condition)
+		identifier(tableName), constraintName, condition)
+	b.migration.AddUp(query)
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
+	return b
+}
+
+func (b *MigrationBuilder) AddCheckNotValid(tableName, constraintName, condition string) *MigrationBuilder {
+	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s) NOT VALID;",
+		identifier(tableName), constraintName, condition)
+	b.migration.AddUp(query)
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
+	return b
+}
+
+func (b *MigrationBuilder) AddForeignKeyNotValid(tableName, constraintName, columnName, refTable, refColumn string) *MigrationBuilder {
+	query := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s) NOT VALID;",
+		identifier(tableName), constraintName, columnName, identifier(refTable), refColumn)
 	b.migration.AddUp(query)
-	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", tableName, constraintName))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", identifier(tableName), constraintName))
+	return b
+}
+
+func (b *MigrationBuilder) ValidateConstraint(tableName, constraintName string) *MigrationBuilder {
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s;", identifier(tableName), constraintName))
+	b.migration.AddDown(fmt.Sprintf("-- Constraint %s stays validated", constraintName))
+	return b
+}
+
+func (b *MigrationBuilder) EnableRowLevelSecurity(tableName string) *MigrationBuilder {
+	b.migration.AddUp(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;", identifier(tableName)))
+	b.migration.AddDown(fmt.Sprintf("ALTER TABLE %s DISABLE ROW LEVEL SECURITY;", identifier(tableName)))
+	return b
+}
+
+func (b *MigrationBuilder) CreatePolicy(tableName, policyName, using, withCheck string) *MigrationBuilder {
+	query := fmt.Sprintf("CREATE POLICY %s ON %s", policyName, identifier(tableName))
+	if using != "" {
+		query += fmt.Sprintf(" USING (%s)", using)
+	}
+	if withCheck != "" {
+		query += fmt.Sprintf(" WITH CHECK (%s)", withCheck)
+	}
+	b.migration.AddUp(query + ";")
+	b.migration.AddDown(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", policyName, identifier(tableName)))
+	return b
+}
+
+func (b *MigrationBuilder) DropPolicy(tableName, policyName string) *MigrationBuilder {
+	b.migration.AddUp(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", policyName, identifier(tableName)))
+	b.migration.AddDown(fmt.Sprintf("-- Cannot restore dropped policy %s", policyName))
 	return b
 }
 
@@ -174,17 +539,115 @@ func (b *MigrationBuilder) RawUp(query string) *MigrationBuilder {
 	return b
 }
 
+func (b *MigrationBuilder) RawReversible(query string) *MigrationBuilder {
+	b.migration.AddUp(query)
+	b.migration.downQueries = append(GenerateDown(DialectGeneric, query), b.migration.downQueries...)
+	return b
+}
+
 func (b *MigrationBuilder) RawDown(query string) *MigrationBuilder {
 	b.migration.AddDown(query)
 	return b
 }
 
+func (b *MigrationBuilder) Chunked(query string) *MigrationBuilder {
+	b.migration.chunked = append(b.migration.chunked, len(b.migration.upQueries))
+	b.migration.AddUp(query)
+	return b
+}
+
+func (b *MigrationBuilder) CopyTable(src, dst string, columns []string, where string) *MigrationBuilder {
+	key := columns[0]
+	src, dst = identifier(src), identifier(dst)
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		selected[i] = "s." + column
+	}
+
+	filter := ""
+	if where != "" {
+		filter = fmt.Sprintf("(%s) AND ", where)
+	}
+
+	b.migration.chunked = append(b.migration.chunked, len(b.migration.upQueries))
+	b.migration.AddUp(fmt.Sprintf(
+		"INSERT INTO %s (%s) SELECT %s FROM %s s WHERE %sNOT EXISTS (SELECT 1 FROM %s d WHERE d.%s = s.%s) ORDER BY s.%s LIMIT %d;",
+		dst, strings.Join(columns, ", "), strings.Join(selected, ", "), src, filter, dst, key, key, key, copyChunkSize))
+
+	if where != "" {
+		where = " WHERE " + where
+	}
+	b.migration.AddDown(fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT %s FROM %s%s);", dst, key, key, src, where))
+	return b
+}
+
+func (b *MigrationBuilder) AddColumnWithBackfill(tableName, keyColumn, columnDef, backfillExpr string) *MigrationBuilder {
+	columnName := strings.Fields(columnDef)[0]
+	table := identifier(tableName)
+	b.AddColumn(tableName, columnDef)
+	b.unsupportedOn(DialectSQLite)
+
+	b.migration.chunked = append(b.migration.chunked, len(b.migration.upQueries))
+	b.migration.variants = append(b.migration.variants, dialectVariant{
+		index:   len(b.migration.upQueries),
+		dialect: DialectMySQL,
+		query: fmt.Sprintf("UPDATE %s t JOIN (SELECT %s FROM %s WHERE %s IS NULL LIMIT %d) chunk ON t.%s = chunk.%s SET t.%s = %s;",
+			table, keyColumn, table, columnName, copyChunkSize, keyColumn, keyColumn, columnName, backfillExpr),
+	})
+	b.migration.AddUp(fmt.Sprintf(
+		"UPDATE %s SET %s = %s WHERE %s IN (SELECT %s FROM %s WHERE %s IS NULL LIMIT %d);",
+		table, columnName, backfillExpr, keyColumn, keyColumn, table, columnName, copyChunkSize))
+	b.migration.AddDown(fmt.Sprintf("-- Backfill of %s.%s is undone by dropping the column", tableName, columnName))
+
+	b.columnStatement(tableName, columnName, columnDefault, "SET DEFAULT "+backfillExpr, "DROP DEFAULT")
+	b.migration.variants = append(b.migration.variants,
+		dialectVariant{index: len(b.migration.upQueries), dialect: DialectMySQL, query: fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s NOT NULL DEFAULT %s;", table, columnDef, backfillExpr)},
+		dialectVariant{index: len(b.migration.downQueries), down: true, dialect: DialectMySQL, query: fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s DEFAULT %s;", table, columnDef, backfillExpr)},
+	)
+	return b.columnStatement(tableName, columnName, columnNullable, "SET NOT NULL", "DROP NOT NULL")
+}
+
 func (b *MigrationBuilder) Raw(upQuery, downQuery string) *MigrationBuilder {
 	b.migration.AddUp(upQuery)
 	b.migration.AddDown(downQuery)
 	return b
 }
 
+func (b *MigrationBuilder) DependsOn(ids ...string) *MigrationBuilder {
+	b.migration.depends = append(b.migration.depends, ids...)
+	return b
+}
+
+func (b *MigrationBuilder) Check(name, query string) *MigrationBuilder {
+	b.migration.checks = append(b.migration.checks, Check{Name: name, Query: query})
+	return b
+}
+
+func (b *MigrationBuilder) CheckValue(name, query, want string) *MigrationBuilder {
+	b.migration.checks = append(b.migration.checks, Check{Name: name, Query: query, Want: want})
+	return b
+}
+
+func (b *MigrationBuilder) Repeatable() *MigrationBuilder {
+	b.migration.repeatable = true
+	return b
+}
+
+func (b *MigrationBuilder) Protected() *MigrationBuilder {
+	b.migration.protected = true
+	return b
+}
+
+func (b *MigrationBuilder) Group(name string) *MigrationBuilder {
+	b.migration.group = name
+	return b
+}
+
+func (b *MigrationBuilder) Tags(tags ...string) *MigrationBuilder {
+	b.migration.tags = append(b.migration.tags, tags...)
+	return b
+}
+
 func (b *MigrationBuilder) Build() Migration {
 	return b.migration
 }
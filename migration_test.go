@@ -1,6 +1,9 @@
 package migrator
 
 import (
+	"database/sql"
+	"errors"
+	"slices"
 	"testing"
 )
 
@@ -70,6 +73,191 @@ func TestMigrationBuilder_DropTable(t *testing.T) {
 	}
 }
 
+func TestMigrationBuilder_CreateTableLike(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect          Dialect
+		includingIndexes bool
+		expected         string
+	}{
+		{DialectPostgres, true, "CREATE TABLE users_archive (LIKE users INCLUDING ALL);"},
+		{DialectPostgres, false, "CREATE TABLE users_archive (LIKE users INCLUDING DEFAULTS INCLUDING CONSTRAINTS);"},
+		{DialectMySQL, true, "CREATE TABLE users_archive LIKE users;"},
+		{DialectMySQL, false, "CREATE TABLE users_archive AS SELECT * FROM users WHERE 1 = 0;"},
+		{DialectSQLite, true, "CREATE TABLE users_archive AS SELECT * FROM users WHERE 1 = 0;"},
+	}
+	for _, tt := range tests {
+		migration := CreateMigration("1", "archive users").CreateTableLike("users_archive", "users", tt.includingIndexes).Build()
+
+		up, down, err := New(nil, WithDialect(tt.dialect)).Render(migration)
+		if err != nil {
+			t.Fatalf("failed to render migration: %v", err)
+		}
+		if len(up) != 1 || up[0] != tt.expected {
+			t.Errorf("%s (indexes=%v): expected up %q, got %q", tt.dialect, tt.includingIndexes, tt.expected, up)
+		}
+		if !slices.Equal(down, []string{"DROP TABLE IF EXISTS users_archive;"}) {
+			t.Errorf("%s: expected table to be dropped on down, got %q", tt.dialect, down)
+		}
+	}
+}
+
+func TestMigrationBuilder_TruncateTable(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "clear sessions").
+		TruncateTable("sessions", false).
+		TruncateTable("users", true).
+		Build()
+
+	expectedUp := []string{"TRUNCATE TABLE sessions;", "TRUNCATE TABLE users CASCADE;"}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+	if isReversible(migration) {
+		t.Errorf("expected truncate to be irreversible, got down %q", migration.Down())
+	}
+	for _, query := range migration.Up() {
+		if class := classifyStatement(query); class != OperationDestructive {
+			t.Errorf("%s: expected %s, got %s", query, OperationDestructive, class)
+		}
+	}
+}
+
+func TestMigrationBuilder_SwapTables(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "swap rewritten users").
+		CreateTableLike("users_new", "users", true).
+		SwapTables("users", "users_new").
+		Build()
+
+	postgres := "ALTER TABLE users RENAME TO users_swap; ALTER TABLE users_new RENAME TO users; ALTER TABLE users_swap RENAME TO users_new;"
+	if migration.Up()[1] != postgres || migration.Down()[0] != postgres {
+		t.Errorf("expected symmetric rename chain %q, got up %q and down %q", postgres, migration.Up(), migration.Down())
+	}
+
+	up, down, err := New(nil, WithDialect(DialectMySQL)).Render(migration)
+	if err != nil {
+		t.Fatalf("failed to render migration: %v", err)
+	}
+	mysql := "RENAME TABLE users TO users_swap, users_new TO users, users_swap TO users_new;"
+	if up[1] != mysql || down[0] != mysql {
+		t.Errorf("expected single RENAME TABLE %q, got up %q and down %q", mysql, up, down)
+	}
+	if down[1] != "DROP TABLE IF EXISTS users_new;" {
+		t.Errorf("expected other down statements to be kept, got %q", down)
+	}
+}
+
+func TestMigrator_SwapTables(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(CreateMigration("001", "create tables").
+		RawUp("CREATE TABLE users (name TEXT); INSERT INTO users VALUES ('old');").
+		RawUp("CREATE TABLE users_new (name TEXT); INSERT INTO users_new VALUES ('new');").
+		Build())
+	migrator.Register(CreateMigration("002", "swap users").SwapTables("users", "users_new").Build())
+
+	name := func() string {
+		var name string
+		if err := db.QueryRow("SELECT name FROM users").Scan(&name); err != nil {
+			t.Fatalf("failed to read users: %v", err)
+		}
+		return name
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if got := name(); got != "new" {
+		t.Errorf("expected users to hold the rewritten table, got %q", got)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back swap: %v", err)
+	}
+	if got := name(); got != "old" {
+		t.Errorf("expected swap to be reversed, got %q", got)
+	}
+}
+
+func TestMigrationBuilder_MySQLTableOptions(t *testing.T) {
+	t.Parallel()
+
+	options := TableOptions{Engine: "InnoDB", Charset: "utf8mb4", Collation: "utf8mb4_0900_ai_ci", RowFormat: "DYNAMIC"}
+	migration := CreateMigration("1", "create users").
+		CreateTableWithOptions("users", options, "id BIGINT PRIMARY KEY", "name VARCHAR(255)").
+		ChangeColumnWithOptions("users", "name", "VARCHAR(500)", options).
+		Build()
+
+	mysql, _, err := New(nil, WithDialect(DialectMySQL)).Render(migration)
+	if err != nil {
+		t.Fatalf("failed to render migration: %v", err)
+	}
+	expectedMySQL := []string{
+		"CREATE TABLE IF NOT EXISTS users (\n    id BIGINT PRIMARY KEY,\n    name VARCHAR(255)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci ROW_FORMAT=DYNAMIC;",
+		"ALTER TABLE users MODIFY COLUMN name VARCHAR(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci;",
+	}
+	if !slices.Equal(mysql, expectedMySQL) {
+		t.Errorf("expected mysql queries %q, got %q", expectedMySQL, mysql)
+	}
+
+	postgres, _, err := New(nil, WithDialect(DialectPostgres)).Render(migration)
+	if err != nil {
+		t.Fatalf("failed to render migration: %v", err)
+	}
+	expectedPostgres := []string{
+		"CREATE TABLE IF NOT EXISTS users (\n    id BIGINT PRIMARY KEY,\n    name VARCHAR(255)\n);",
+		"ALTER TABLE users ALTER COLUMN name VARCHAR(500);",
+	}
+	if !slices.Equal(postgres, expectedPostgres) {
+		t.Errorf("expected options to be ignored outside mysql, got %q", postgres)
+	}
+}
+
+func TestMigrationBuilder_StorageOptions(t *testing.T) {
+	t.Parallel()
+
+	options := TableOptions{Tablespace: "fast_ssd", Storage: map[string]string{"fillfactor": "70", "autovacuum_vacuum_scale_factor": "0.01"}}
+	migration := CreateMigration("1", "tuned events").
+		CreateTableWithOptions("events", options, "id BIGINT PRIMARY KEY").
+		CreateIndexWithOptions("idx_events_id", "events", TableOptions{Storage: map[string]string{"fillfactor": "90"}}, "id").
+		SetStorageParameters("users", map[string]string{"fillfactor": "80", "autovacuum_enabled": "off"}).
+		Build()
+
+	expectedUp := []string{
+		"CREATE TABLE IF NOT EXISTS events (\n    id BIGINT PRIMARY KEY\n) WITH (autovacuum_vacuum_scale_factor = 0.01, fillfactor = 70) TABLESPACE fast_ssd;",
+		"CREATE INDEX idx_events_id ON events (id) WITH (fillfactor = 90);",
+		"ALTER TABLE users SET (autovacuum_enabled = off, fillfactor = 80);",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+	if migration.Down()[0] != "ALTER TABLE users RESET (autovacuum_enabled, fillfactor);" {
+		t.Errorf("expected storage parameters to be reset on down, got %q", migration.Down()[0])
+	}
+
+	sqlite, _, err := New(nil, WithDialect(DialectSQLite)).Render(migration)
+	if err != nil {
+		t.Fatalf("failed to render migration: %v", err)
+	}
+	if sqlite[0] != "CREATE TABLE IF NOT EXISTS events (\n    id BIGINT PRIMARY KEY\n);" || sqlite[1] != "CREATE INDEX idx_events_id ON events (id);" {
+		t.Errorf("expected storage options to be dropped outside postgres, got %q", sqlite)
+	}
+}
+
 func TestMigrationBuilder_AddColumn(t *testing.T) {
 	t.Parallel()
 
@@ -358,6 +546,182 @@ func TestMigrationBuilder_AddCheck(t *testing.T) {
 	}
 }
 
+func TestMigrationBuilder_NotValidConstraints(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "add constraints without a full scan").
+		AddCheckNotValid("orders", "chk_total", "total >= 0").
+		AddForeignKeyNotValid("orders", "fk_orders_user", "user_id", "users", "id").
+		ValidateConstraint("orders", "chk_total").
+		ValidateConstraint("orders", "fk_orders_user").
+		Build()
+
+	expectedUp := []string{
+		"ALTER TABLE orders ADD CONSTRAINT chk_total CHECK (total >= 0) NOT VALID;",
+		"ALTER TABLE orders ADD CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users(id) NOT VALID;",
+		"ALTER TABLE orders VALIDATE CONSTRAINT chk_total;",
+		"ALTER TABLE orders VALIDATE CONSTRAINT fk_orders_user;",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+
+	expectedDown := []string{
+		"-- Constraint fk_orders_user stays validated",
+		"-- Constraint chk_total stays validated",
+		"ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_user;",
+		"ALTER TABLE orders DROP CONSTRAINT IF EXISTS chk_total;",
+	}
+	if !slices.Equal(migration.Down(), expectedDown) {
+		t.Errorf("expected down queries %q, got %q", expectedDown, migration.Down())
+	}
+
+	for _, query := range migration.Up() {
+		if class := classifyStatement(query); class != OperationSafe {
+			t.Errorf("%s: expected %s, got %s", query, OperationSafe, class)
+		}
+	}
+}
+
+func TestMigrationBuilder_AddColumnWithBackfill(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "add required status").
+		AddColumnWithBackfill("users", "user_id", "status TEXT", "'active'").
+		Build()
+
+	expectedUp := []string{
+		"ALTER TABLE users ADD COLUMN status TEXT;",
+		"UPDATE users SET status = 'active' WHERE user_id IN (SELECT user_id FROM users WHERE status IS NULL LIMIT 1000);",
+		"ALTER TABLE users ALTER COLUMN status SET DEFAULT 'active';",
+		"ALTER TABLE users ALTER COLUMN status SET NOT NULL;",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+
+	expectedDown := []string{
+		"ALTER TABLE users ALTER COLUMN status DROP NOT NULL;",
+		"ALTER TABLE users ALTER COLUMN status DROP DEFAULT;",
+		"-- Backfill of users.status is undone by dropping the column",
+		"ALTER TABLE users DROP COLUMN status;",
+	}
+	if !slices.Equal(migration.Down(), expectedDown) {
+		t.Errorf("expected down queries %q, got %q", expectedDown, migration.Down())
+	}
+
+	expectedMySQLUp := []string{
+		"ALTER TABLE users ADD COLUMN status TEXT;",
+		"UPDATE users t JOIN (SELECT user_id FROM users WHERE status IS NULL LIMIT 1000) chunk ON t.user_id = chunk.user_id SET t.status = 'active';",
+		"ALTER TABLE users ALTER COLUMN status SET DEFAULT 'active';",
+		"ALTER TABLE users MODIFY COLUMN status TEXT NOT NULL DEFAULT 'active';",
+	}
+	if up := stripIdentifiers(queriesForDialect(migration, DialectMySQL, DirectionUp)); !slices.Equal(up, expectedMySQLUp) {
+		t.Errorf("expected mysql up queries %q, got %q", expectedMySQLUp, up)
+	}
+	expectedMySQLDown := []string{
+		"ALTER TABLE users MODIFY COLUMN status TEXT DEFAULT 'active';",
+		"ALTER TABLE users ALTER COLUMN status DROP DEFAULT;",
+		"-- Backfill of users.status is undone by dropping the column",
+		"ALTER TABLE users DROP COLUMN status;",
+	}
+	if down := stripIdentifiers(queriesForDialect(migration, DialectMySQL, DirectionDown)); !slices.Equal(down, expectedMySQLDown) {
+		t.Errorf("expected mysql down queries %q, got %q", expectedMySQLDown, down)
+	}
+	if err := checkDialect(migration, DialectMySQL); err != nil {
+		t.Errorf("expected backfill to be supported on mysql, got %v", err)
+	}
+
+	if chunked := chunkedQueries(migration); !chunked[1] || len(chunked) != 1 {
+		t.Errorf("expected only the backfill to be chunked, got %v", chunked)
+	}
+	if transactional, ok := migration.(NonTransactional); !ok || !transactional.NoTransaction() {
+		t.Error("expected backfill migration to run outside a transaction")
+	}
+}
+
+func TestMigrationBuilder_RowLevelSecurity(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "isolate tenants").
+		EnableRowLevelSecurity("orders").
+		CreatePolicy("orders", "tenant_isolation", "tenant_id = current_setting('app.tenant_id')::bigint", "tenant_id = current_setting('app.tenant_id')::bigint").
+		CreatePolicy("orders", "read_only", "true", "").
+		DropPolicy("orders", "legacy_access").
+		Build()
+
+	expectedUp := []string{
+		"ALTER TABLE orders ENABLE ROW LEVEL SECURITY;",
+		"CREATE POLICY tenant_isolation ON orders USING (tenant_id = current_setting('app.tenant_id')::bigint) WITH CHECK (tenant_id = current_setting('app.tenant_id')::bigint);",
+		"CREATE POLICY read_only ON orders USING (true);",
+		"DROP POLICY IF EXISTS legacy_access ON orders;",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+
+	expectedDown := []string{
+		"-- Cannot restore dropped policy legacy_access",
+		"DROP POLICY IF EXISTS read_only ON orders;",
+		"DROP POLICY IF EXISTS tenant_isolation ON orders;",
+		"ALTER TABLE orders DISABLE ROW LEVEL SECURITY;",
+	}
+	if !slices.Equal(migration.Down(), expectedDown) {
+		t.Errorf("expected down queries %q, got %q", expectedDown, migration.Down())
+	}
+}
+
+func TestMigrationBuilder_SpatialColumns(t *testing.T) {
+	t.Parallel()
+
+	migration := CreateMigration("1", "add locations").
+		AddGeometryColumn("places", "location", "Point", 4326).
+		AddGeographyColumn("places", "area", "Polygon", 4326).
+		CreateSpatialIndex("idx_places_location", "places", "location").
+		Build()
+
+	expectedUp := []string{
+		"CREATE EXTENSION IF NOT EXISTS postgis;",
+		"ALTER TABLE places ADD COLUMN location geometry(Point, 4326);",
+		"ALTER TABLE places ADD COLUMN area geography(Polygon, 4326);",
+		"CREATE INDEX idx_places_location ON places USING GIST (location);",
+	}
+	if !slices.Equal(migration.Up(), expectedUp) {
+		t.Errorf("expected up queries %q, got %q", expectedUp, migration.Up())
+	}
+
+	expectedDown := []string{
+		"DROP INDEX IF EXISTS idx_places_location;",
+		"ALTER TABLE places DROP COLUMN area;",
+		"ALTER TABLE places DROP COLUMN location;",
+	}
+	if !slices.Equal(migration.Down(), expectedDown) {
+		t.Errorf("expected down queries %q, got %q", expectedDown, migration.Down())
+	}
+
+	up, down, err := New(nil, WithDialect(DialectMySQL)).Render(migration)
+	if err != nil {
+		t.Fatalf("failed to render migration: %v", err)
+	}
+	expectedMySQL := []string{
+		"",
+		"ALTER TABLE places ADD COLUMN location POINT SRID 4326;",
+		"ALTER TABLE places ADD COLUMN area POLYGON SRID 4326;",
+		"CREATE SPATIAL INDEX idx_places_location ON places (location);",
+	}
+	if !slices.Equal(up, expectedMySQL) {
+		t.Errorf("expected mysql queries %q, got %q", expectedMySQL, up)
+	}
+	if down[0] != "DROP INDEX idx_places_location ON places;" {
+		t.Errorf("expected mysql spatial index drop, got %q", down[0])
+	}
+
+	index := CreateMigration("2", "spatial index").CreateSpatialIndex("idx_places_location", "places", "location").Build()
+	if _, _, err := New(nil, WithDialect(DialectSQLite)).Render(index); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect on sqlite, got %v", err)
+	}
+}
+
 func TestMigrationBuilder_Raw(t *testing.T) {
 	t.Parallel()
 
@@ -2,146 +2,515 @@ package migrator
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
-const migrationTableSQL = `
-CREATE TABLE IF NOT EXISTS schema_migrations (
-    id VARCHAR(255) PRIMARY KEY,
+const defaultTableName = "schema_migrations"
+
+const migrationTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s (
+    module VARCHAR(255) NOT NULL DEFAULT '',
+    id VARCHAR(255) NOT NULL,
     description TEXT NOT NULL,
     applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    batch INTEGER NOT NULL
+    batch INTEGER NOT NULL,
+    checksum VARCHAR(64) NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'applied',
+    PRIMARY KEY (module, id)
 );
 `
 
-const migrationTableIndexSQL = `
-CREATE INDEX IF NOT EXISTS idx_schema_migrations_batch ON schema_migrations(batch);
+const migrationTableIndexTemplate = `
+CREATE INDEX IF NOT EXISTS idx_%[1]s_batch ON %[1]s(batch);
 `
 
+var (
+	migrationTableSQL      = fmt.Sprintf(migrationTableTemplate, defaultTableName)
+	migrationTableIndexSQL = fmt.Sprintf(migrationTableIndexTemplate, defaultTableName)
+)
+
+var migrationTableColumns = []struct {
+	name       string
+	definition string
+}{
+	{name: "module", definition: "module VARCHAR(255) NOT NULL DEFAULT ''"},
+	{name: "checksum", definition: "checksum VARCHAR(64) NOT NULL DEFAULT ''"},
+	{name: "duration_ms", definition: "duration_ms BIGINT NOT NULL DEFAULT 0"},
+	{name: "status", definition: "status VARCHAR(16) NOT NULL DEFAULT 'applied'"},
+}
+
 type Migrator struct {
-	db         *sql.DB
-	mu         sync.Mutex
-	migrations []Migration
+	conn               Conn
+	dialect            Dialect
+	tablePrefix        string
+	table              string
+	namespace          string
+	group              string
+	notifiers          []Notifier
+	notifyChannel      string
+	auditLog           bool
+	actor              string
+	eventsMu           sync.Mutex
+	events             chan Event
+	progress           ProgressFunc
+	statementProgress  StatementProgressFunc
+	outOfOrder         OutOfOrderPolicy
+	ordering           Ordering
+	orphans            OrphanPolicy
+	strictDown         bool
+	onError            OnErrorPolicy
+	errorPrompt        ErrorPrompt
+	templateData       map[string]any
+	lag                LagFunc
+	maxLag             time.Duration
+	throttleInterval   time.Duration
+	maxChunks          int
+	explainLimit       int64
+	baseline           string
+	clock              Clock
+	logger             *slog.Logger
+	skip               map[string]bool
+	lockOwner          string
+	lockLease          time.Duration
+	limiter            Limiter
+	fixtureDecoders    map[string]FixtureDecoder
+	schemaDumper       SchemaDumpFunc
+	checks             []Check
+	afterMigrate       []string
+	preflight          *PreflightConfig
+	backupHook         BackupHook
+	confirm            ConfirmFunc
+	environment        string
+	guardrails         Guardrail
+	maintenanceWindows []MaintenanceWindow
+	sqlValidator       SQLValidator
+	interceptors       []Interceptor
+	executed           map[string]int
+	statements         map[string][]StatementResult
+	mu                 sync.Mutex
+	stateMu            sync.RWMutex
+	migrations         []Migration
+	registryErr        error
+	providers          []string
+	versionTable       bool
+	intentJournal      bool
 }
 
-func New(db *sql.DB) *Migrator {
-	return &Migrator{db: db}
+func New(db DBTX, opts ...Option) *Migrator {
+	return NewWithConn(stdConn{db: db}, opts...)
+}
+
+func NewWithConn(conn Conn, opts ...Option) *Migrator {
+	m := &Migrator{conn: conn, throttleInterval: defaultThrottleInterval, maxChunks: defaultMaxChunks}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.table = m.tablePrefix + defaultTableName
+	return m
 }
 
 func (m *Migrator) Register(migration ...Migration) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.migrations = append(m.migrations, migration...)
+	m.stateMu.Lock()
+	defer m.stateMu.Unlock()
+	m.register(migration)
 }
 
-func (r *Migrator) Up() error {
+func (r *Migrator) Up(opts ...RunOption) (*Result, error) {
+	return r.upContext(context.Background(), opts...)
+}
+
+func (r *Migrator) upContext(ctx context.Context, opts ...RunOption) (*Result, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	ctx := context.Background()
+	config := newRunConfig(opts)
 
-	applied, err := r.getAppliedMigrations(ctx)
+	provided, err := r.upProviders(ctx, config)
 	if err != nil {
-		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+		return provided, err
 	}
 
-	appliedMap := make(map[string]bool)
-	for _, a := range applied {
-		appliedMap[a.ID] = true
-	}
+	result, err := r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		return r.up(ctx, config, result)
+	})
+	result.Migrations = append(provided.Migrations, result.Migrations...)
+	result.Failures = append(provided.Failures, result.Failures...)
+	return result, err
+}
 
-	migrations := r.migrations
+func (r *Migrator) Down(steps int, opts ...RunOption) (*Result, error) {
+	return r.downContext(context.Background(), steps, opts...)
+}
 
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].ID() < migrations[j].ID()
+func (r *Migrator) downContext(ctx context.Context, steps int, opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	config := newRunConfig(opts)
+
+	return r.runWithResult(ctx, DirectionDown, func(ctx context.Context, result *Result) error {
+		return r.down(ctx, steps, config, result)
 	})
+}
 
-	var newMigrations []Migration
-	for _, migration := range migrations {
-		if !appliedMap[migration.ID()] {
-			newMigrations = append(newMigrations, migration)
-		}
+func (r *Migrator) up(ctx context.Context, config runConfig, result *Result) error {
+	if err := r.checkWritable(ctx); err != nil {
+		return err
 	}
 
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	if err := r.checkDirty(ctx); err != nil {
+		return err
+	}
+
+	if err := r.checkOrphans(ctx, applied); err != nil {
+		return err
+	}
+
+	newMigrations, err := r.orderByDependencies(config.filter(r.pendingMigrations(applied)), applied)
+	if err != nil {
+		return err
+	}
+	result.Skipped = r.skippedIDs(config, applied)
 	if len(newMigrations) == 0 {
 		return nil
 	}
 
+	if err := r.Preflight(ctx); err != nil {
+		return err
+	}
+
+	plan := lazyPlan(func() (*Plan, error) {
+		return r.buildPlan(applied, newMigrations)
+	})
+	if err := r.guardUp(ctx, &config, applied, newMigrations, plan); err != nil {
+		return err
+	}
+
+	if err := r.backupDestructive(ctx, plan); err != nil {
+		return err
+	}
+
 	nextBatch := r.getNextBatchNumber(applied)
 
-	return r.executeMigrationBatch(ctx, newMigrations, nextBatch)
+	if err := r.executeMigrationBatch(ctx, newMigrations, nextBatch, result); err != nil {
+		return err
+	}
+
+	return r.runAfterMigrate(ctx, result)
 }
 
-func (r *Migrator) Down(steps int) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	ctx := context.Background()
+func (r *Migrator) guardUp(ctx context.Context, config *runConfig, applied []MigrationStatus, pending []Migration, plan func() (*Plan, error)) error {
+	if err := r.guardOperations(config, plan); err != nil {
+		return err
+	}
+	if err := r.checkMaintenanceWindow(*config, plan); err != nil {
+		return err
+	}
+	if err := r.guardDestructive(config, plan); err != nil {
+		return err
+	}
+	if err := r.checkOutOfOrder(applied, pending); err != nil {
+		return err
+	}
+	if r.explainLimit > 0 && !config.allowLargeRewrites {
+		if err := r.checkEstimates(ctx, pending); err != nil {
+			return err
+		}
+	}
+	if r.onError != OnErrorAbort && !r.dialect.supportsSavepoints() {
+		return ErrUnsupportedDialect
+	}
+	return nil
+}
+
+func (r *Migrator) down(ctx context.Context, steps int, config runConfig, result *Result) error {
+	if err := r.checkWritable(ctx); err != nil {
+		return err
+	}
 
 	applied, err := r.getAppliedMigrations(ctx)
 	if err != nil {
 		return errors.Join(ErrFailedToGetAppliedMigrations, err)
 	}
 
+	if err := r.checkDirty(ctx); err != nil {
+		return err
+	}
+
+	if err := r.checkOrphans(ctx, applied); err != nil {
+		return err
+	}
+
 	if len(applied) == 0 {
 		return ErrNoMigrationsToRollback
 	}
 
-	migrationMap := r.buildMigrationMap(r.migrations)
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
 	rollbackList := r.buildRollbackList(applied, steps)
+	if err := checkProtected(rollbackList, migrationMap); err != nil {
+		return err
+	}
+
+	plan := lazyPlan(func() (*Plan, error) {
+		return r.buildRollbackPlan(rollbackList, migrationMap)
+	})
+	if err := r.guardEnvironment(GuardDown, &config, plan); err != nil {
+		return err
+	}
 
-	return r.executeRollback(ctx, rollbackList, migrationMap)
+	if err := r.Preflight(ctx); err != nil {
+		return err
+	}
+
+	if err := r.checkMaintenanceWindow(config, plan); err != nil {
+		return err
+	}
+
+	if err := r.guardDestructive(&config, plan); err != nil {
+		return err
+	}
+
+	if r.strictDown {
+		if err := checkStrictDown(rollbackList, migrationMap); err != nil {
+			return err
+		}
+	}
+
+	if err := r.backupDestructive(ctx, plan); err != nil {
+		return err
+	}
+
+	return r.executeRollback(ctx, rollbackList, migrationMap, result)
 }
 
 func (r *Migrator) Status() ([]MigrationStatus, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	return r.getAppliedMigrations(context.Background())
+	return r.readView("").getHistory(context.Background())
 }
 
 func (r *Migrator) createMigrationTable() error {
-	_, err := r.db.Exec(migrationTableSQL)
+	ctx := context.Background()
+
+	_, err := r.conn.Exec(ctx, r.query(migrationTableTemplate))
 	if err != nil {
 		return errors.Join(ErrFailedToCreateSchemaMigrationsTable, err)
 	}
 
-	_, err = r.db.Exec(migrationTableIndexSQL)
+	_, err = r.conn.Exec(ctx, r.query(migrationTableIndexTemplate))
 	if err != nil {
 		return errors.Join(ErrFailedToCreateSchemaMigrationsIndex, err)
 	}
 
-	return nil
+	_, err = r.conn.Exec(ctx, r.query(baselineTableTemplate))
+	if err != nil {
+		return errors.Join(ErrFailedToCreateSchemaMigrationsTable, err)
+	}
+
+	_, err = r.conn.Exec(ctx, r.query(dirtyTableTemplate))
+	if err != nil {
+		return errors.Join(ErrFailedToCreateSchemaMigrationsTable, err)
+	}
+
+	if err := r.createVersionTable(ctx); err != nil {
+		return errors.Join(ErrFailedToCreateSchemaMigrationsTable, err)
+	}
+
+	return r.upgradeMigrationTable(ctx)
 }
 
-func (r *Migrator) executeMigrationBatch(ctx context.Context, migrations []Migration, batch int) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *Migrator) upgradeMigrationTable(ctx context.Context) error {
+	addedModule := false
+	for _, column := range migrationTableColumns {
+		rows, err := r.conn.Query(ctx, r.query("SELECT "+column.name+" FROM %s WHERE 1 = 0"))
+		if err == nil {
+			_ = rows.Close()
+			continue
+		}
+
+		if _, err := r.conn.Exec(ctx, r.query("ALTER TABLE %s ADD COLUMN "+column.definition)); err != nil {
+			return errors.Join(ErrFailedToUpgradeSchemaMigrationsTable, err)
+		}
+		addedModule = addedModule || column.name == "module"
+	}
+	return r.upgradePrimaryKey(ctx, addedModule)
+}
+
+func (r *Migrator) executeMigrationBatch(ctx context.Context, migrations []Migration, batch int, result *Result) (runErr error) {
+	result.Batch = batch
+	r.executed = make(map[string]int)
+	defer func() {
+		r.executed = nil
+	}()
+
+	ids := make([]string, len(migrations))
+	for i, migration := range migrations {
+		ids[i] = migration.ID()
+	}
+	if err := r.beginIntent(ctx, DirectionUp, batch, ids, result); err != nil {
+		return err
+	}
+	defer func() {
+		runErr = errors.Join(runErr, r.finishIntent(ctx, runErr))
+	}()
+
+	offset := 0
+	for _, segment := range splitByTransaction(migrations, r.isTransactional) {
+		var err error
+		if r.isTransactional(segment[0]) {
+			err = r.applyInTransaction(ctx, segment, batch, offset, len(migrations), result)
+		} else {
+			err = r.applyWithoutTransaction(ctx, segment, batch, offset, len(migrations), result)
+		}
+		if err != nil {
+			err = r.recordDirty(ctx, r.buildMigrationMap(migrations), DirectionUp, result, err)
+			return r.batchError(migrations, result, err)
+		}
+		offset += len(segment)
+	}
+
+	r.reportProgress(len(migrations), len(migrations), nil)
+	return r.batchError(migrations, result, result.partialError())
+}
+
+func (r *Migrator) applyInTransaction(ctx context.Context, migrations []Migration, batch, offset, total int, result *Result) error {
+	tx, err := r.conn.Begin(ctx)
 	if err != nil {
 		return errors.Join(ErrFailedToBeginTransaction, err)
 	}
 
 	defer func() {
 		if tx != nil {
-			_ = tx.Rollback()
+			_ = tx.Rollback(ctx)
+			r.emit(Event{Kind: EventBatchRolledBack, Direction: DirectionUp, Batch: batch, MigrationID: result.FailedID})
 		}
 	}()
 
-	for _, migration := range migrations {
-		if err := r.executeMigrationUp(ctx, tx, migration, batch); err != nil {
-			return errors.Join(ErrMigrationFailed, err)
-		}
+	executed, err := r.applyMigrations(ctx, tx, migrations, batch, offset, total, result)
+	if err != nil {
+		return err
 	}
 
-	err = tx.Commit()
+	err = tx.Commit(ctx)
 	if err != nil {
 		return err
 	}
 	tx = nil
+
+	result.Migrations = append(result.Migrations, executed...)
+	r.emit(Event{Kind: EventBatchCommitted, Direction: DirectionUp, Batch: batch})
 	return nil
 }
 
+func (r *Migrator) applyMigrations(ctx context.Context, tx Tx, migrations []Migration, batch, offset, total int, result *Result) ([]MigrationResult, error) {
+	var executed []MigrationResult
+	var records []historyRecord
+	for i, migration := range migrations {
+		r.reportProgress(offset+i, total, migration)
+		started := time.Now()
+		r.emit(Event{Kind: EventMigrationStarted, Direction: DirectionUp, Batch: batch, MigrationID: migration.ID()})
+
+		record, failure, err := r.applyMigration(ctx, tx, migration, batch)
+		if err != nil {
+			result.FailedID = migration.ID()
+			return nil, errors.Join(ErrMigrationFailed, err)
+		}
+		if failure != nil {
+			result.Failures = append(result.Failures, *failure)
+			continue
+		}
+
+		records = append(records, record)
+		executed = append(executed, newMigrationResult(migration.ID(), migration.Description(), started))
+	}
+
+	if len(records) > 0 {
+		if err := r.runChecks(ctx, tx, records[len(records)-1].id, r.checks); err != nil {
+			result.FailedID = records[len(records)-1].id
+			return nil, errors.Join(ErrMigrationFailed, err)
+		}
+	}
+
+	if err := r.insertHistory(ctx, tx, records); err != nil {
+		return nil, errors.Join(ErrMigrationFailed, err)
+	}
+	return executed, nil
+}
+
+func (r *Migrator) applyMigration(ctx context.Context, tx Tx, migration Migration, batch int) (historyRecord, *MigrationFailure, error) {
+	if r.onError == OnErrorAbort {
+		record, err := r.runMigrationUp(ctx, tx, migration, batch)
+		return record, nil, err
+	}
+
+	var record historyRecord
+	failure, err := r.withSavepoint(ctx, tx, migration.ID(), func() error {
+		var err error
+		record, err = r.runMigrationUp(ctx, tx, migration, batch)
+		return err
+	})
+	if failure != nil && !r.skipFailure(*failure) {
+		return record, nil, failure.Err
+	}
+	return record, failure, err
+}
+
+func (r *Migrator) pendingMigrations(applied []MigrationStatus) []Migration {
+	var pending []Migration
+	for _, migration := range r.unappliedMigrations(applied) {
+		if !r.skip[migration.ID()] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+func (r *Migrator) skippedIDs(config runConfig, applied []MigrationStatus) []string {
+	var ids []string
+	for _, migration := range config.filter(r.unappliedMigrations(applied)) {
+		if r.skip[migration.ID()] {
+			ids = append(ids, migration.ID())
+		}
+	}
+	return ids
+}
+
+func (r *Migrator) unappliedMigrations(applied []MigrationStatus) []Migration {
+	appliedMap := make(map[string]MigrationStatus)
+	for _, a := range applied {
+		appliedMap[a.ID] = a
+	}
+
+	var newMigrations, repeatable []Migration
+	for _, migration := range r.orderedMigrations() {
+		status, isApplied := appliedMap[migration.ID()]
+		if isRepeatable(migration) {
+			if !isApplied || r.repeatableChanged(migration, status) {
+				repeatable = append(repeatable, migration)
+			}
+			continue
+		}
+		if r.baseline != "" && r.compareIDs(migration.ID(), r.baseline) <= 0 {
+			continue
+		}
+		if !isApplied {
+			newMigrations = append(newMigrations, migration)
+		}
+	}
+	return append(newMigrations, repeatable...)
+}
+
 func (r *Migrator) buildMigrationMap(migrations []Migration) map[string]Migration {
 	migrationMap := make(map[string]Migration)
 	for _, m := range migrations {
@@ -163,82 +532,229 @@ func (r *Migrator) buildRollbackList(applied []MigrationStatus, steps int) []Mig
 	return applied[:steps]
 }
 
-func (r *Migrator) executeRollback(ctx context.Context, rollbackList []MigrationStatus, migrationMap map[string]Migration) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *Migrator) executeRollback(ctx context.Context, rollbackList []MigrationStatus, migrationMap map[string]Migration, result *Result) (runErr error) {
+	if len(rollbackList) > 0 {
+		result.Batch = rollbackList[0].Batch
+	}
+	r.executed = make(map[string]int)
+	defer func() {
+		r.executed = nil
+	}()
+
+	ids := make([]string, len(rollbackList))
+	for i, status := range rollbackList {
+		ids[i] = status.ID
+	}
+	if err := r.beginIntent(ctx, DirectionDown, result.Batch, ids, result); err != nil {
+		return err
+	}
+	defer func() {
+		runErr = errors.Join(runErr, r.finishIntent(ctx, runErr))
+	}()
+
+	transactional := func(status MigrationStatus) bool {
+		migration, exists := migrationMap[status.ID]
+		return !exists || r.isTransactional(migration)
+	}
+
+	offset := 0
+	for _, segment := range splitByTransaction(rollbackList, transactional) {
+		var err error
+		if transactional(segment[0]) {
+			err = r.rollbackInTransaction(ctx, segment, migrationMap, offset, len(rollbackList), result)
+		} else {
+			err = r.rollbackWithoutTransaction(ctx, segment, migrationMap, offset, len(rollbackList), result)
+		}
+		if err != nil {
+			return r.recordDirty(ctx, migrationMap, DirectionDown, result, err)
+		}
+		offset += len(segment)
+	}
+
+	r.reportProgress(len(rollbackList), len(rollbackList), nil)
+	return nil
+}
+
+func (r *Migrator) rollbackInTransaction(ctx context.Context, rollbackList []MigrationStatus, migrationMap map[string]Migration, offset, total int, result *Result) error {
+	tx, err := r.conn.Begin(ctx)
 	if err != nil {
 		return errors.Join(ErrFailedToBeginTransaction, err)
 	}
 
 	defer func() {
 		if tx != nil {
-			_ = tx.Rollback()
+			_ = tx.Rollback(ctx)
+			r.emit(Event{Kind: EventBatchRolledBack, Direction: DirectionDown, Batch: result.Batch, MigrationID: result.FailedID})
 		}
 	}()
 
-	for _, migrationStatus := range rollbackList {
+	var executed []MigrationResult
+	for i, migrationStatus := range rollbackList {
+		r.reportProgress(offset+i, total, migrationMap[migrationStatus.ID])
+		started := time.Now()
+		r.emit(Event{Kind: EventMigrationStarted, Direction: DirectionDown, Batch: migrationStatus.Batch, MigrationID: migrationStatus.ID})
 		if err := r.rollbackSingleMigration(ctx, tx, migrationStatus, migrationMap); err != nil {
+			result.FailedID = migrationStatus.ID
 			return err
 		}
+		executed = append(executed, newMigrationResult(migrationStatus.ID, migrationStatus.Description, started))
 	}
 
-	err = tx.Commit()
+	err = tx.Commit(ctx)
 	if err != nil {
 		return err
 	}
 	tx = nil
+
+	result.Migrations = append(result.Migrations, executed...)
+	r.emit(Event{Kind: EventBatchCommitted, Direction: DirectionDown, Batch: result.Batch})
 	return nil
 }
 
-func (r *Migrator) rollbackSingleMigration(ctx context.Context, tx *sql.Tx, migrationStatus MigrationStatus, migrationMap map[string]Migration) error {
+func (r *Migrator) rollbackSingleMigration(ctx context.Context, tx Executor, migrationStatus MigrationStatus, migrationMap map[string]Migration) error {
 	if migration, exists := migrationMap[migrationStatus.ID]; exists {
-		for _, query := range migration.Down() {
-			trimmedQuery := strings.TrimSpace(query)
-			if trimmedQuery == "" || strings.HasPrefix(trimmedQuery, "--") {
-				continue
-			}
+		queries, err := r.downQueries(migration)
+		if err != nil {
+			return errors.Join(ErrMigrationFailed, err)
+		}
+		if queries, err = r.restoreColumnState(ctx, tx, migration, queries); err != nil {
+			return errors.Join(ErrMigrationFailed, err)
+		}
+		if queries, err = r.resolveIndexRenames(ctx, tx, migration, DirectionDown, queries); err != nil {
+			return errors.Join(ErrMigrationFailed, err)
+		}
 
-			if _, err := tx.ExecContext(ctx, query); err != nil {
+		statements := slices.DeleteFunc(slices.Clone(queries), isNoopQuery)
+		for i, query := range statements {
+			r.reportStatement(DirectionDown, migrationStatus.Batch, migrationStatus.ID, i+1, len(statements), query)
+			if _, err := r.execStatement(ctx, tx, DirectionDown, migrationStatus.Batch, migrationStatus.ID, query); err != nil {
 				return errors.Join(ErrMigrationFailed, err)
 			}
 		}
+		if err := r.forgetColumnState(ctx, tx, migration); err != nil {
+			return errors.Join(ErrMigrationFailed, err)
+		}
 	}
 
-	if err := r.deleteMigrationRecord(ctx, tx, migrationStatus.ID); err != nil {
+	if err := r.markRolledBack(ctx, tx, migrationStatus.ID); err != nil {
 		return errors.Join(ErrMigrationFailed, err)
 	}
 
 	return nil
 }
 
-func (r *Migrator) executeMigrationUp(ctx context.Context, tx *sql.Tx, migration Migration, batch int) error {
-	for _, query := range migration.Up() {
+func (r *Migrator) executeMigrationUp(ctx context.Context, tx Executor, migration Migration, batch int) error {
+	record, err := r.runMigrationUp(ctx, tx, migration, batch)
+	if err != nil {
+		return err
+	}
+	return r.insertHistory(ctx, tx, []historyRecord{record})
+}
+
+func (r *Migrator) runMigrationUp(ctx context.Context, tx Executor, migration Migration, batch int) (historyRecord, error) {
+	if streaming, ok := migration.(Streaming); ok {
+		return r.runStreamingUp(ctx, tx, migration, streaming, batch)
+	}
+	if copying, ok := migration.(*copyMigration); ok {
+		return r.runCopyUp(ctx, tx, copying, batch)
+	}
+
+	started := time.Now()
+	queries, err := r.upQueries(migration)
+	if err != nil {
+		return historyRecord{}, err
+	}
+
+	checksum, err := r.checksum(migration)
+	if err != nil {
+		return historyRecord{}, err
+	}
+
+	if err := r.captureColumnState(ctx, tx, migration); err != nil {
+		return historyRecord{}, err
+	}
+	if queries, err = r.resolveIndexRenames(ctx, tx, migration, DirectionUp, queries); err != nil {
+		return historyRecord{}, err
+	}
+
+	chunked := chunkedQueries(migration)
+	index, total := 0, countStatements(queries)
+	for i, query := range queries {
 		if strings.TrimSpace(query) == "" {
 			continue
 		}
 
-		if _, err := tx.ExecContext(ctx, query); err != nil {
-			return errors.Join(ErrFailedToExecuteQuery, err)
+		index++
+		r.reportStatement(DirectionUp, batch, migration.ID(), index, total, query)
+		if chunked[i] {
+			err = r.execChunked(ctx, tx, batch, migration.ID(), query)
+		} else {
+			_, err = r.execStatement(ctx, tx, DirectionUp, batch, migration.ID(), query)
+		}
+		if err != nil {
+			return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
 		}
 	}
 
-	_, err := tx.ExecContext(ctx,
-		"INSERT INTO schema_migrations (id, description, batch) VALUES (?, ?, ?)",
-		migration.ID(), migration.Description(), batch)
+	if err := r.runChecks(ctx, tx, migration.ID(), checksOf(migration)); err != nil {
+		return historyRecord{}, err
+	}
 
-	return err
+	return historyRecord{
+		id:          migration.ID(),
+		description: migration.Description(),
+		batch:       batch,
+		checksum:    checksum,
+		duration:    time.Since(started),
+		appliedAt:   r.now(),
+	}, nil
 }
 
-func (r *Migrator) deleteMigrationRecord(ctx context.Context, tx *sql.Tx, migrationID string) error {
-	_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE id = ?", migrationID)
-	return err
+func (r *Migrator) execStatement(ctx context.Context, tx Executor, direction Direction, batch int, migrationID, query string) (ExecResult, error) {
+	query, err := r.intercept(ctx, migrationID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.waitForLimiter(ctx); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	res, err := tx.Exec(ctx, query)
+	if err == nil && r.executed != nil {
+		r.executed[migrationID]++
+	}
+
+	event := newStatementEvent(direction, batch, migrationID, query, started, err)
+	event.RowsAffected = rowsAffected(query, res)
+	r.recordStatement(migrationID, event)
+	r.emit(event)
+	r.logStatement(ctx, event, res)
+	return res, err
+}
+
+func (r *Migrator) deleteMigrationRecord(ctx context.Context, tx Executor, migrationID string) error {
+	if _, err := tx.Exec(ctx, r.query("DELETE FROM %s WHERE module = ? AND id = ?"), r.module(), migrationID); err != nil {
+		return err
+	}
+	return r.syncVersion(ctx, tx)
 }
 
 func (r *Migrator) getAppliedMigrations(ctx context.Context) ([]MigrationStatus, error) {
+	return r.queryHistory(ctx, " AND status = '"+string(StateApplied)+"'")
+}
+
+func (r *Migrator) getHistory(ctx context.Context) ([]MigrationStatus, error) {
+	return r.queryHistory(ctx, "")
+}
+
+func (r *Migrator) queryHistory(ctx context.Context, condition string) ([]MigrationStatus, error) {
 	if err := r.createMigrationTable(); err != nil {
 		return nil, err
 	}
-	query := "SELECT id, description, applied_at, batch FROM schema_migrations ORDER BY batch, id"
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.conn.Query(ctx,
+		r.query("SELECT id, description, applied_at, batch, checksum, duration_ms, status FROM %s WHERE module = ?"+condition+" ORDER BY batch, id"), r.module())
 	if err != nil {
 		return nil, err
 	}
@@ -249,17 +765,28 @@ func (r *Migrator) getAppliedMigrations(ctx context.Context) ([]MigrationStatus,
 		}
 	}()
 
+	applied, err := scanMigrationStatuses(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, r.refreshBaseline(ctx)
+}
+
+func scanMigrationStatuses(rows Rows) ([]MigrationStatus, error) {
 	var migrations []MigrationStatus
 	for rows.Next() {
 		var migration MigrationStatus
 		var appliedAt time.Time
+		var durationMs int64
 
-		err := rows.Scan(&migration.ID, &migration.Description, &appliedAt, &migration.Batch)
+		err := rows.Scan(&migration.ID, &migration.Description, &appliedAt, &migration.Batch, &migration.Checksum, &durationMs, &migration.Status)
 		if err != nil {
 			return nil, err
 		}
 
 		migration.AppliedAt = &appliedAt
+		migration.Duration = time.Duration(durationMs) * time.Millisecond
 		migrations = append(migrations, migration)
 	}
 
@@ -275,3 +802,16 @@ func (r *Migrator) getNextBatchNumber(applied []MigrationStatus) int {
 	}
 	return maxBatch + 1
 }
+
+func (r *Migrator) query(format string) string {
+	return r.dialect.rebind(fmt.Sprintf(format, r.table))
+}
+
+func currentVersion(applied []MigrationStatus) string {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].Status == "" || applied[i].Status == StateApplied {
+			return applied[i].ID
+		}
+	}
+	return ""
+}
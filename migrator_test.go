@@ -54,7 +54,7 @@ func TestMigrator_New(t *testing.T) {
 	if migrator == nil {
 		t.Fatal("expected non-nil migrator")
 	}
-	if migrator.db != db {
+	if conn, ok := migrator.conn.(stdConn); !ok || conn.db != db {
 		t.Error("expected db to be set correctly")
 	}
 }
@@ -280,7 +280,7 @@ func TestMigrator_executeMigrationUp_EmptyQuery(t *testing.T) {
 		upQueries:   []string{"", "  ", "\n\t"},
 	}
 
-	err = migrator.executeMigrationUp(context.Background(), tx, migration, 1)
+	err = migrator.executeMigrationUp(context.Background(), stdTx{tx: tx}, migration, 1)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -311,7 +311,7 @@ func TestMigrator_executeMigrationUp_QueryError(t *testing.T) {
 		upQueries:   []string{"INVALID SQL STATEMENT"},
 	}
 
-	err = migrator.executeMigrationUp(context.Background(), tx, migration, 1)
+	err = migrator.executeMigrationUp(context.Background(), stdTx{tx: tx}, migration, 1)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -350,7 +350,7 @@ func TestMigrator_executeMigrationUp_InsertError(t *testing.T) {
 		upQueries:   []string{"SELECT 1"},
 	}
 
-	err = migrator.executeMigrationUp(context.Background(), tx, migration, 1)
+	err = migrator.executeMigrationUp(context.Background(), stdTx{tx: tx}, migration, 1)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -383,7 +383,7 @@ func TestMigrator_deleteMigrationRecord(t *testing.T) {
 	tx, _ := db.BeginTx(context.Background(), nil)
 	defer func() { _ = tx.Rollback() }()
 
-	err = migrator.deleteMigrationRecord(context.Background(), tx, "1")
+	err = migrator.deleteMigrationRecord(context.Background(), stdTx{tx: tx}, "1")
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -416,7 +416,7 @@ func TestMigrator_deleteMigrationRecord_Error(t *testing.T) {
 	tx, _ := db.BeginTx(context.Background(), nil)
 	defer func() { _ = tx.Rollback() }()
 
-	err = migrator.deleteMigrationRecord(context.Background(), tx, "1")
+	err = migrator.deleteMigrationRecord(context.Background(), stdTx{tx: tx}, "1")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -444,7 +444,7 @@ func TestMigrator_rollbackSingleMigration_NotFound(t *testing.T) {
 	migrationMap := make(map[string]Migration)
 	migrationStatus := MigrationStatus{ID: "1"}
 
-	err = migrator.rollbackSingleMigration(context.Background(), tx, migrationStatus, migrationMap)
+	err = migrator.rollbackSingleMigration(context.Background(), stdTx{tx: tx}, migrationStatus, migrationMap)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -481,7 +481,7 @@ func TestMigrator_rollbackSingleMigration_EmptyDown(t *testing.T) {
 
 	migrationStatus := MigrationStatus{ID: "1"}
 
-	err = migrator.rollbackSingleMigration(context.Background(), tx, migrationStatus, migrationMap)
+	err = migrator.rollbackSingleMigration(context.Background(), stdTx{tx: tx}, migrationStatus, migrationMap)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -529,7 +529,7 @@ func TestMigrator_rollbackSingleMigration_DownWithComments(t *testing.T) {
 
 	migrationStatus := MigrationStatus{ID: "1"}
 
-	err = migrator.rollbackSingleMigration(context.Background(), tx, migrationStatus, migrationMap)
+	err = migrator.rollbackSingleMigration(context.Background(), stdTx{tx: tx}, migrationStatus, migrationMap)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -566,7 +566,7 @@ func TestMigrator_rollbackSingleMigration_DownError(t *testing.T) {
 
 	migrationStatus := MigrationStatus{ID: "1"}
 
-	err = migrator.rollbackSingleMigration(context.Background(), tx, migrationStatus, migrationMap)
+	err = migrator.rollbackSingleMigration(context.Background(), stdTx{tx: tx}, migrationStatus, migrationMap)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -594,7 +594,7 @@ func TestMigrator_rollbackSingleMigration_DeleteError(t *testing.T) {
 	migrationMap := make(map[string]Migration)
 	migrationStatus := MigrationStatus{ID: "1"}
 
-	err = migrator.rollbackSingleMigration(context.Background(), tx, migrationStatus, migrationMap)
+	err = migrator.rollbackSingleMigration(context.Background(), stdTx{tx: tx}, migrationStatus, migrationMap)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -617,7 +617,7 @@ func TestMigrator_MigrateUp_NoMigrations(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register([]Migration{}...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -649,7 +649,7 @@ func TestMigrator_MigrateUp_Success(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register(migrations...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -691,11 +691,11 @@ func TestMigrator_MigrateUp_AlreadyApplied(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register(migrations...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Fatalf("failed to apply initial migrations: %v", err)
 	}
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Errorf("expected no error when applying already applied migrations, got %v", err)
 	}
@@ -730,7 +730,7 @@ func TestMigrator_MigrateUp_TransactionError(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register(migrations...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -753,7 +753,7 @@ func TestMigrator_MigrateDown_NoMigrations(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register([]Migration{}...)
-	err = migrator.Down(1)
+	_, err = migrator.Down(1)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -785,16 +785,16 @@ func TestMigrator_MigrateDown_Success(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register(migrations...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Fatalf("failed to apply migrations: %v", err)
 	}
-	err = migrator.Down(1)
+	_, err = migrator.Down(1)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 	var count int
-	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
+	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE status = 'applied'").Scan(&count)
 	if err != nil {
 		t.Errorf("failed to count migrations: %v", err)
 	}
@@ -823,7 +823,7 @@ func TestMigrator_MigrateDown_TransactionError(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register([]Migration{}...)
-	err = migrator.Down(1)
+	_, err = migrator.Down(1)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -854,7 +854,7 @@ func TestMigrator_Status_Success(t *testing.T) {
 
 	migrator := New(db)
 	migrator.Register(migrations...)
-	err = migrator.Up()
+	_, err = migrator.Up()
 	if err != nil {
 		t.Fatalf("failed to apply migrations: %v", err)
 	}
@@ -926,7 +926,7 @@ func TestMigrator_executeMigrationBatch_Success(t *testing.T) {
 		},
 	}
 
-	err = migrator.executeMigrationBatch(context.Background(), migrations, 1)
+	err = migrator.executeMigrationBatch(context.Background(), migrations, 1, &Result{})
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -960,7 +960,7 @@ func TestMigrator_executeMigrationBatch_TransactionError(t *testing.T) {
 		},
 	}
 
-	err = migrator.executeMigrationBatch(context.Background(), migrations, 1)
+	err = migrator.executeMigrationBatch(context.Background(), migrations, 1, &Result{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -1003,7 +1003,7 @@ func TestMigrator_executeRollback_Success(t *testing.T) {
 		},
 	}
 
-	err = migrator.executeRollback(context.Background(), rollbackList, migrationMap)
+	err = migrator.executeRollback(context.Background(), rollbackList, migrationMap, &Result{})
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -1034,7 +1034,7 @@ func TestMigrator_executeRollback_TransactionError(t *testing.T) {
 	}
 	migrationMap := map[string]Migration{}
 
-	err = migrator.executeRollback(context.Background(), rollbackList, migrationMap)
+	err = migrator.executeRollback(context.Background(), rollbackList, migrationMap, &Result{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -1138,3 +1138,140 @@ CREATE TABLE schema_migrations (
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestMigrator_WithNamespace(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	billing := New(db, WithNamespace("billing"))
+	billing.Register(&mockMigration{
+		id:          "001",
+		description: "create invoices",
+		upQueries:   []string{"CREATE TABLE invoices (id INTEGER)"},
+		downQueries: []string{"DROP TABLE invoices"},
+	})
+
+	auth := New(db, WithNamespace("auth"))
+	auth.Register(&mockMigration{
+		id:          "001",
+		description: "create accounts",
+		upQueries:   []string{"CREATE TABLE accounts (id INTEGER)"},
+		downQueries: []string{"DROP TABLE accounts"},
+	})
+
+	if _, err := billing.Up(); err != nil {
+		t.Fatalf("failed to migrate billing: %v", err)
+	}
+	if _, err := auth.Up(); err != nil {
+		t.Fatalf("failed to migrate auth: %v", err)
+	}
+
+	if _, err := billing.Down(0); err != nil {
+		t.Fatalf("failed to roll back billing: %v", err)
+	}
+
+	status, err := auth.Status()
+	if err != nil {
+		t.Fatalf("failed to get auth status: %v", err)
+	}
+	if len(status) != 1 || status[0].Description != "create accounts" {
+		t.Errorf("expected auth history to be untouched, got %+v", status)
+	}
+
+	status, err = billing.Status()
+	if err != nil {
+		t.Fatalf("failed to get billing status: %v", err)
+	}
+	if len(status) != 1 || status[0].Status != StateRolledBack {
+		t.Errorf("expected billing history to only record the rollback, got %+v", status)
+	}
+}
+
+func TestMigrator_upgradeMigrationTable(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+CREATE TABLE schema_migrations (
+    id VARCHAR(255) PRIMARY KEY,
+    description TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    batch INTEGER NOT NULL
+)`)
+	if err != nil {
+		t.Fatalf("failed to create legacy schema_migrations table: %v", err)
+	}
+	_, err = db.Exec("INSERT INTO schema_migrations (id, description, batch) VALUES (?, ?, ?)", "1", "legacy", 1)
+	if err != nil {
+		t.Fatalf("failed to insert legacy migration: %v", err)
+	}
+
+	status, err := New(db).Status()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(status) != 1 || status[0].ID != "1" {
+		t.Errorf("expected legacy migration to be visible, got %+v", status)
+	}
+}
+
+func TestMigrator_upgradeMigrationTablePrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+CREATE TABLE schema_migrations (
+    id VARCHAR(255) PRIMARY KEY,
+    description TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    batch INTEGER NOT NULL
+)`)
+	if err != nil {
+		t.Fatalf("failed to create legacy schema_migrations table: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO schema_migrations (id, description, batch) VALUES ('001', 'legacy', 1)"); err != nil {
+		t.Fatalf("failed to insert legacy migration: %v", err)
+	}
+
+	if _, err := New(db, WithNamespace("billing")).Status(); !errors.Is(err, ErrLegacyHistoryTable) {
+		t.Fatalf("expected ErrLegacyHistoryTable without a dialect, got %v", err)
+	}
+
+	for _, namespace := range []string{"billing", "shipping"} {
+		migrator := New(db, WithDialect(DialectSQLite), WithNamespace(namespace))
+		migrator.Register(CreateMigration("001", "create "+namespace).RawUp("CREATE TABLE " + namespace + " (id INTEGER)").Build())
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("expected %s to apply 001 next to other namespaces, got %v", namespace, err)
+		}
+		assertAppliedIDs(t, migrator, "001")
+	}
+
+	status, err := New(db, WithDialect(DialectSQLite)).Status()
+	if err != nil || len(status) != 1 || status[0].Description != "legacy" {
+		t.Errorf("expected legacy history to survive the upgrade, got %+v (%v)", status, err)
+	}
+}
@@ -0,0 +1,73 @@
+package migratortest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shuldan/migrator"
+)
+
+const updateGoldenEnv = "MIGRATOR_UPDATE_GOLDEN"
+
+func AssertGolden(t testing.TB, dir string, dialect migrator.Dialect, migrations []migrator.Migration, opts ...migrator.Option) {
+	t.Helper()
+
+	m := migrator.New(nil, append(opts, migrator.WithDialect(dialect))...)
+	update := os.Getenv(updateGoldenEnv) != ""
+
+	for _, migration := range migrations {
+		up, down, err := m.Render(migration)
+		if err != nil {
+			t.Fatalf("migration %s: failed to render: %v", migration.ID(), err)
+		}
+		got := goldenContent(up, down)
+		path := filepath.Join(dir, goldenName(migration.ID(), dialect))
+
+		want, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) || update {
+			if err := os.MkdirAll(dir, 0o750); err != nil {
+				t.Fatalf("failed to create golden directory: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+				t.Fatalf("migration %s: failed to write golden file: %v", migration.ID(), err)
+			}
+			t.Logf("migration %s: wrote golden file %s", migration.ID(), path)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("migration %s: failed to read golden file: %v", migration.ID(), err)
+		}
+
+		if string(want) != got {
+			t.Errorf("migration %s: generated SQL differs from %s (set %s=1 to accept)\nwant:\n%s\ngot:\n%s",
+				migration.ID(), path, updateGoldenEnv, want, got)
+		}
+	}
+}
+
+func goldenName(id string, dialect migrator.Dialect) string {
+	name := string(dialect)
+	if name == "" {
+		name = "generic"
+	}
+	return id + "." + name + ".sql"
+}
+
+func goldenContent(up, down []string) string {
+	var b strings.Builder
+	b.WriteString("-- up\n")
+	for _, query := range up {
+		b.WriteString(strings.TrimSpace(query))
+		b.WriteString("\n")
+	}
+	b.WriteString("-- down\n")
+	for _, query := range down {
+		b.WriteString(strings.TrimSpace(query))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
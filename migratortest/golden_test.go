@@ -0,0 +1,44 @@
+package migratortest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shuldan/migrator"
+)
+
+func TestAssertGolden(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	original := []migrator.Migration{
+		migrator.CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY").
+			Build(),
+	}
+
+	AssertGolden(t, dir, migrator.DialectPostgres, original)
+
+	content, err := os.ReadFile(filepath.Join(dir, "1.postgres.sql"))
+	if err != nil {
+		t.Fatalf("expected golden file to be written: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "-- up\nCREATE TABLE IF NOT EXISTS users") ||
+		!strings.HasSuffix(string(content), "-- down\nDROP TABLE IF EXISTS users;\n") {
+		t.Errorf("unexpected golden content:\n%s", content)
+	}
+
+	AssertGolden(t, dir, migrator.DialectPostgres, original)
+
+	recorder := &recordingT{TB: t}
+	AssertGolden(recorder, dir, migrator.DialectPostgres, []migrator.Migration{
+		migrator.CreateMigration("1", "create users").
+			CreateTable("users", "id BIGINT PRIMARY KEY").
+			Build(),
+	})
+	if !recorder.failed {
+		t.Error("expected changed SQL to be reported")
+	}
+}
@@ -0,0 +1,44 @@
+package migratortest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/shuldan/migrator"
+)
+
+func AssertReversible(t testing.TB, db *sql.DB, migrations []migrator.Migration, opts ...migrator.Option) {
+	t.Helper()
+
+	ctx := context.Background()
+	for i, migration := range migrations {
+		m := migrator.New(db, opts...)
+		m.Register(migrations[:i+1]...)
+
+		before, err := m.SchemaSnapshot(ctx)
+		if err != nil {
+			t.Fatalf("migration %s: failed to snapshot schema: %v", migration.ID(), err)
+		}
+
+		if _, err := m.Up(); err != nil {
+			t.Fatalf("migration %s: up failed: %v", migration.ID(), err)
+		}
+		if _, err := m.Down(1); err != nil {
+			t.Fatalf("migration %s: down failed: %v", migration.ID(), err)
+		}
+
+		after, err := m.SchemaSnapshot(ctx)
+		if err != nil {
+			t.Fatalf("migration %s: failed to snapshot schema: %v", migration.ID(), err)
+		}
+		if after != before {
+			t.Errorf("migration %s: down does not reverse up\nbefore up:\n%s\nafter down:\n%s", migration.ID(), before, after)
+			return
+		}
+
+		if _, err := m.Up(); err != nil {
+			t.Fatalf("migration %s: reapplying failed: %v", migration.ID(), err)
+		}
+	}
+}
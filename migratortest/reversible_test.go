@@ -0,0 +1,64 @@
+package migratortest
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/shuldan/migrator"
+)
+
+type recordingT struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingT) Errorf(string, ...any) {
+	r.failed = true
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func TestAssertReversible(t *testing.T) {
+	t.Parallel()
+
+	AssertReversible(t, openDB(t), []migrator.Migration{
+		migrator.CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY", "email TEXT").
+			Build(),
+		migrator.CreateMigration("2", "index email").
+			CreateIndex("idx_users_email", "users", "email").
+			Build(),
+	}, migrator.WithDialect(migrator.DialectSQLite))
+}
+
+func TestAssertReversible_DetectsIncompleteDown(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingT{TB: t}
+	AssertReversible(recorder, openDB(t), []migrator.Migration{
+		migrator.CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY").
+			Build(),
+		migrator.CreateMigration("2", "leaky").
+			Raw("CREATE TABLE audit (id INTEGER)", "SELECT 1").
+			Build(),
+	}, migrator.WithDialect(migrator.DialectSQLite))
+
+	if !recorder.failed {
+		t.Error("expected a down that leaves a table behind to be reported")
+	}
+}
@@ -0,0 +1,114 @@
+package migratortest
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/shuldan/migrator"
+)
+
+type OpenFunc func(database string) (*sql.DB, error)
+
+type TemplateDB struct {
+	admin  *sql.DB
+	open   OpenFunc
+	name   string
+	mu     sync.Mutex
+	clones atomic.Int64
+}
+
+func NewTemplateDB(ctx context.Context, admin *sql.DB, prefix string, open OpenFunc, migrations []migrator.Migration, opts ...migrator.Option) (*TemplateDB, error) {
+	name, err := templateName(prefix, migrations)
+	if err != nil {
+		return nil, err
+	}
+	template := &TemplateDB{admin: admin, open: open, name: name}
+
+	var exists bool
+	err = admin.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", template.name).Scan(&exists)
+	if err != nil || exists {
+		return template, err
+	}
+
+	if _, err := admin.ExecContext(ctx, "CREATE DATABASE "+quoteIdent(template.name)); err != nil {
+		return nil, err
+	}
+
+	if err := template.migrate(migrations, opts); err != nil {
+		_, _ = admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+quoteIdent(template.name))
+		return nil, err
+	}
+	return template, nil
+}
+
+func (t *TemplateDB) Name() string {
+	return t.name
+}
+
+func (t *TemplateDB) migrate(migrations []migrator.Migration, opts []migrator.Option) error {
+	db, err := t.open(t.name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	m := migrator.New(db, append(opts, migrator.WithDialect(migrator.DialectPostgres))...)
+	m.Register(migrations...)
+	_, err = m.Up()
+	return err
+}
+
+func (t *TemplateDB) Clone(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	name := fmt.Sprintf("%s_%d", t.name, t.clones.Add(1))
+	ctx := context.Background()
+
+	t.mu.Lock()
+	_, err := t.admin.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", quoteIdent(name), quoteIdent(t.name)))
+	t.mu.Unlock()
+	if err != nil {
+		tb.Fatalf("failed to create database %s from template: %v", name, err)
+	}
+
+	db, err := t.open(name)
+	if err != nil {
+		tb.Fatalf("failed to open database %s: %v", name, err)
+	}
+
+	tb.Cleanup(func() {
+		_ = db.Close()
+		if _, err := t.admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+quoteIdent(name)); err != nil {
+			tb.Errorf("failed to drop database %s: %v", name, err)
+		}
+	})
+	return db
+}
+
+func templateName(prefix string, migrations []migrator.Migration) (string, error) {
+	hash := sha256.New()
+	for _, migration := range migrations {
+		checksum, err := migrator.Checksum(migration)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte(migration.ID()))
+		hash.Write([]byte{0})
+		hash.Write([]byte(checksum))
+		hash.Write([]byte{0})
+	}
+	return strings.ToLower(prefix) + "_" + hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,33 @@
+package migratortest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shuldan/migrator"
+)
+
+func TestTemplateName(t *testing.T) {
+	t.Parallel()
+
+	first := []migrator.Migration{
+		migrator.CreateMigration("1", "create users").CreateTable("users", "id INTEGER").Build(),
+	}
+	changed := []migrator.Migration{
+		migrator.CreateMigration("1", "create users").CreateTable("users", "id BIGINT").Build(),
+	}
+
+	name, err := templateName("App", first)
+	if err != nil {
+		t.Fatalf("failed to name template: %v", err)
+	}
+	if !strings.HasPrefix(name, "app_") || len(name) != len("app_")+12 {
+		t.Errorf("unexpected template name %q", name)
+	}
+	if again, _ := templateName("App", first); again != name {
+		t.Error("expected template name to be stable for the same migrations")
+	}
+	if other, _ := templateName("App", changed); other == name {
+		t.Error("expected template name to change when a migration changes")
+	}
+}
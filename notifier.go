@@ -0,0 +1,102 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, result *Result, err error) error
+}
+
+type NotifierFunc func(ctx context.Context, result *Result, err error) error
+
+func (f NotifierFunc) Notify(ctx context.Context, result *Result, err error) error {
+	return f(ctx, result, err)
+}
+
+func (r *Migrator) notify(ctx context.Context, result *Result, err error) {
+	logger := r.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	for _, notifier := range r.notifiers {
+		if notifyErr := notifier.Notify(ctx, result, err); notifyErr != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "migration notifier failed",
+				slog.String("notifier", fmt.Sprintf("%T", notifier)), slog.Any("error", notifyErr))
+		}
+	}
+}
+
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookNotifier{url: url, client: client}
+}
+
+type webhookMigration struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+type webhookPayload struct {
+	Direction  Direction          `json:"direction"`
+	Batch      int                `json:"batch"`
+	Migrations []webhookMigration `json:"migrations"`
+	DurationMs int64              `json:"duration_ms"`
+	Error      string             `json:"error,omitempty"`
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, result *Result, err error) error {
+	payload := webhookPayload{
+		Direction:  result.Direction,
+		Batch:      result.Batch,
+		Migrations: make([]webhookMigration, 0, len(result.Migrations)),
+		DurationMs: result.Duration.Milliseconds(),
+	}
+	for _, migration := range result.Migrations {
+		payload.Migrations = append(payload.Migrations, webhookMigration{
+			ID:          migration.ID,
+			Description: migration.Description,
+			DurationMs:  migration.Duration.Milliseconds(),
+		})
+	}
+	if err != nil {
+		payload.Error = err.Error()
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %s", ErrWebhookFailed, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMigrator_WithNotifier(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	var results []*Result
+	var errs []error
+	notifier := NotifierFunc(func(_ context.Context, result *Result, err error) error {
+		results = append(results, result)
+		errs = append(errs, err)
+		return nil
+	})
+
+	migrator := New(db, WithNotifier(notifier))
+	migrator.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"INVALID SQL STATEMENT"}},
+	)
+
+	if _, err := migrator.Up(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(results))
+	}
+	if results[0].Direction != DirectionUp {
+		t.Errorf("expected direction up, got %s", results[0].Direction)
+	}
+	if !errors.Is(errs[0], ErrMigrationFailed) {
+		t.Errorf("expected failure to be reported, got %v", errs[0])
+	}
+	if len(results[0].Migrations) != 0 {
+		t.Errorf("expected no migrations to be reported as applied, got %v", results[0].IDs())
+	}
+}
+
+func TestMigrator_WithNotifierFailureLogged(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	notifier := NotifierFunc(func(context.Context, *Result, error) error {
+		return errors.New("chat is down")
+	})
+
+	migrator := New(db, WithLogger(logger), WithNotifier(notifier))
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}})
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected notifier failure not to fail the run, got %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a log entry, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "migration notifier failed" || entry["notifier"] != "migrator.NotifierFunc" || entry["error"] != "chat is down" {
+		t.Errorf("expected notifier failure to be logged, got %v", entry)
+	}
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	result := &Result{
+		Direction: DirectionUp,
+		Batch:     3,
+		Migrations: []MigrationResult{
+			{ID: "001", Description: "create users"},
+		},
+	}
+
+	notifier := NewWebhookNotifier(server.URL, server.Client())
+	if err := notifier.Notify(context.Background(), result, errors.New("boom")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if payload.Batch != 3 || len(payload.Migrations) != 1 || payload.Migrations[0].ID != "001" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if payload.Error != "boom" {
+		t.Errorf("expected error to be sent, got '%s'", payload.Error)
+	}
+}
+
+func TestWebhookNotifier_Notify_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, nil)
+	err := notifier.Notify(context.Background(), &Result{Direction: DirectionDown}, nil)
+	if !errors.Is(err, ErrWebhookFailed) {
+		t.Errorf("expected ErrWebhookFailed, got %v", err)
+	}
+}
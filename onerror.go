@@ -0,0 +1,65 @@
+package migrator
+
+import "slices"
+
+type OnErrorPolicy int
+
+const (
+	OnErrorAbort OnErrorPolicy = iota
+	OnErrorSkipMigration
+	OnErrorPrompt
+)
+
+type ErrorPrompt func(failure MigrationFailure) bool
+
+type BatchError struct {
+	Batch      int
+	Applied    []string
+	Failed     []MigrationFailure
+	NotApplied []string
+	Err        error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+func (r *Migrator) skipFailure(failure MigrationFailure) bool {
+	switch r.onError {
+	case OnErrorSkipMigration:
+		return true
+	case OnErrorPrompt:
+		return r.errorPrompt != nil && r.errorPrompt(failure)
+	default:
+		return false
+	}
+}
+
+func (r *Migrator) batchError(migrations []Migration, result *Result, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	report := &BatchError{Batch: result.Batch, Applied: result.IDs(), Failed: slices.Clone(result.Failures), Err: err}
+	if result.FailedID != "" {
+		report.Failed = append(report.Failed, MigrationFailure{ID: result.FailedID, Err: err})
+	}
+
+	done := make(map[string]bool)
+	for _, id := range report.Applied {
+		done[id] = true
+	}
+	for _, failure := range report.Failed {
+		done[failure.ID] = true
+	}
+	for _, migration := range migrations {
+		if !done[migration.ID()] {
+			report.NotApplied = append(report.NotApplied, migration.ID())
+		}
+	}
+	return report
+}
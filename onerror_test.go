@@ -0,0 +1,89 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func onErrorMigrations() []Migration {
+	return []Migration{
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "broken", upQueries: []string{"INVALID SQL STATEMENT"}},
+		&mockMigration{id: "3", description: "third", upQueries: []string{"CREATE TABLE c (id INTEGER)"}},
+		&mockMigration{id: "4", description: "also broken", upQueries: []string{"ANOTHER INVALID STATEMENT"}},
+		&mockMigration{id: "5", description: "fifth", upQueries: []string{"CREATE TABLE e (id INTEGER)"}},
+	}
+}
+
+func TestMigrator_WithOnError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		applied    []string
+		failed     []string
+		notApplied []string
+		sentinel   error
+	}{
+		{
+			name:       "abort",
+			opts:       []Option{WithOnError(OnErrorAbort)},
+			failed:     []string{"2"},
+			notApplied: []string{"1", "3", "4", "5"},
+			sentinel:   ErrMigrationFailed,
+		},
+		{
+			name:     "skip",
+			opts:     []Option{WithOnError(OnErrorSkipMigration)},
+			applied:  []string{"1", "3", "5"},
+			failed:   []string{"2", "4"},
+			sentinel: ErrPartialFailure,
+		},
+		{
+			name: "prompt",
+			opts: []Option{WithErrorPrompt(func(failure MigrationFailure) bool {
+				return failure.ID == "2"
+			})},
+			failed:     []string{"2", "4"},
+			notApplied: []string{"1", "3", "5"},
+			sentinel:   ErrMigrationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			if err != nil {
+				t.Fatalf("failed to open sqlite database: %v", err)
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+			db.SetMaxOpenConns(1)
+
+			migrator := New(db, append(tt.opts, WithDialect(DialectSQLite))...)
+			migrator.Register(onErrorMigrations()...)
+
+			_, err = migrator.Up()
+			var report *BatchError
+			if !errors.As(err, &report) || !errors.Is(err, tt.sentinel) {
+				t.Fatalf("expected BatchError wrapping %v, got %v", tt.sentinel, err)
+			}
+
+			var failed []string
+			for _, failure := range report.Failed {
+				failed = append(failed, failure.ID)
+			}
+			if !slices.Equal(report.Applied, tt.applied) || !slices.Equal(failed, tt.failed) || !slices.Equal(report.NotApplied, tt.notApplied) {
+				t.Errorf("expected applied %v, failed %v, not applied %v; got %v, %v, %v",
+					tt.applied, tt.failed, tt.notApplied, report.Applied, failed, report.NotApplied)
+			}
+			assertAppliedIDs(t, migrator, tt.applied...)
+		})
+	}
+}
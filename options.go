@@ -0,0 +1,263 @@
+package migrator
+
+import (
+	"log/slog"
+	"time"
+)
+
+type Option func(*Migrator)
+
+func WithDialect(dialect Dialect) Option {
+	return func(m *Migrator) {
+		m.dialect = dialect
+	}
+}
+
+func WithTablePrefix(prefix string) Option {
+	return func(m *Migrator) {
+		m.tablePrefix = prefix
+	}
+}
+
+func WithNamespace(namespace string) Option {
+	return func(m *Migrator) {
+		m.namespace = namespace
+	}
+}
+
+func WithNotifier(notifiers ...Notifier) Option {
+	return func(m *Migrator) {
+		m.notifiers = append(m.notifiers, notifiers...)
+	}
+}
+
+func WithPostgresNotify(channel string) Option {
+	return func(m *Migrator) {
+		m.notifyChannel = channel
+	}
+}
+
+func WithAuditLog(actor string) Option {
+	return func(m *Migrator) {
+		m.auditLog = true
+		m.actor = actor
+	}
+}
+
+func WithProgress(progress ProgressFunc) Option {
+	return func(m *Migrator) {
+		m.progress = progress
+	}
+}
+
+func WithStatementProgress(progress StatementProgressFunc) Option {
+	return func(m *Migrator) {
+		m.statementProgress = progress
+	}
+}
+
+func WithOutOfOrder(policy OutOfOrderPolicy) Option {
+	return func(m *Migrator) {
+		m.outOfOrder = policy
+	}
+}
+
+func WithOrphanPolicy(policy OrphanPolicy) Option {
+	return func(m *Migrator) {
+		m.orphans = policy
+	}
+}
+
+func WithStrictDown() Option {
+	return func(m *Migrator) {
+		m.strictDown = true
+	}
+}
+
+func WithContinueOnError() Option {
+	return WithOnError(OnErrorSkipMigration)
+}
+
+func WithOnError(policy OnErrorPolicy) Option {
+	return func(m *Migrator) {
+		m.onError = policy
+	}
+}
+
+func WithErrorPrompt(prompt ErrorPrompt) Option {
+	return func(m *Migrator) {
+		m.onError = OnErrorPrompt
+		m.errorPrompt = prompt
+	}
+}
+
+func WithTemplateData(data map[string]any) Option {
+	return func(m *Migrator) {
+		m.templateData = data
+	}
+}
+
+func WithThrottle(lag LagFunc, maxLag, interval time.Duration) Option {
+	return func(m *Migrator) {
+		m.lag = lag
+		m.maxLag = maxLag
+		if interval > 0 {
+			m.throttleInterval = interval
+		}
+	}
+}
+
+func WithMaxChunks(n int) Option {
+	return func(m *Migrator) {
+		if n > 0 {
+			m.maxChunks = n
+		}
+	}
+}
+
+func WithExplainLimit(maxRows int64) Option {
+	return func(m *Migrator) {
+		m.explainLimit = maxRows
+	}
+}
+
+func WithClock(clock Clock) Option {
+	return func(m *Migrator) {
+		m.clock = clock
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(m *Migrator) {
+		m.logger = logger
+	}
+}
+
+func WithSkip(ids ...string) Option {
+	return func(m *Migrator) {
+		if m.skip == nil {
+			m.skip = make(map[string]bool, len(ids))
+		}
+		for _, id := range ids {
+			m.skip[id] = true
+		}
+	}
+}
+
+func WithLockTable(owner string, lease time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockOwner = owner
+		if m.lockOwner == "" {
+			m.lockOwner = defaultLockOwner()
+		}
+		m.lockLease = lease
+	}
+}
+
+func WithRateLimit(statementsPerSecond float64) Option {
+	return func(m *Migrator) {
+		if statementsPerSecond > 0 {
+			m.limiter = NewRateLimiter(statementsPerSecond)
+		}
+	}
+}
+
+func WithLimiter(limiter Limiter) Option {
+	return func(m *Migrator) {
+		m.limiter = limiter
+	}
+}
+
+func WithFixtureDecoder(ext string, decoder FixtureDecoder) Option {
+	return func(m *Migrator) {
+		if m.fixtureDecoders == nil {
+			m.fixtureDecoders = make(map[string]FixtureDecoder)
+		}
+		m.fixtureDecoders[ext] = decoder
+	}
+}
+
+func WithSchemaDumper(dumper SchemaDumpFunc) Option {
+	return func(m *Migrator) {
+		m.schemaDumper = dumper
+	}
+}
+
+func WithChecks(checks ...Check) Option {
+	return func(m *Migrator) {
+		m.checks = append(m.checks, checks...)
+	}
+}
+
+func WithAfterMigrate(queries ...string) Option {
+	return func(m *Migrator) {
+		m.afterMigrate = append(m.afterMigrate, queries...)
+	}
+}
+
+func WithPreflight(config PreflightConfig) Option {
+	return func(m *Migrator) {
+		m.preflight = &config
+	}
+}
+
+func WithBackupHook(hook BackupHook) Option {
+	return func(m *Migrator) {
+		m.backupHook = hook
+	}
+}
+
+func WithOrdering(ordering Ordering) Option {
+	return func(m *Migrator) {
+		m.ordering = ordering
+	}
+}
+
+func WithConfirm(confirm ConfirmFunc) Option {
+	return func(m *Migrator) {
+		m.confirm = confirm
+	}
+}
+
+func WithEnvironment(name string, guardrails ...Guardrail) Option {
+	return func(m *Migrator) {
+		m.environment = name
+		m.guardrails = 0
+		if len(guardrails) == 0 && protectedEnvironments[name] {
+			m.guardrails = GuardDown
+		}
+		for _, guardrail := range guardrails {
+			m.guardrails |= guardrail
+		}
+	}
+}
+
+func WithMaintenanceWindow(windows ...MaintenanceWindow) Option {
+	return func(m *Migrator) {
+		m.maintenanceWindows = append(m.maintenanceWindows, windows...)
+	}
+}
+
+func WithInterceptor(interceptor Interceptor) Option {
+	return func(m *Migrator) {
+		m.interceptors = append(m.interceptors, interceptor)
+	}
+}
+
+func WithVersionTable() Option {
+	return func(m *Migrator) {
+		m.versionTable = true
+	}
+}
+
+func WithIntentJournal() Option {
+	return func(m *Migrator) {
+		m.intentJournal = true
+	}
+}
+
+func WithSQLValidator(validator SQLValidator) Option {
+	return func(m *Migrator) {
+		m.sqlValidator = validator
+	}
+}
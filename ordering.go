@@ -0,0 +1,54 @@
+package migrator
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+type Ordering int
+
+const (
+	OrderByID Ordering = iota
+	OrderByRegistration
+	OrderByTimestamp
+)
+
+func (r *Migrator) orderedMigrations() []Migration {
+	migrations := r.activeMigrations()
+
+	if r.ordering != OrderByRegistration {
+		slices.SortStableFunc(migrations, func(a, b Migration) int {
+			return r.compareIDs(a.ID(), b.ID())
+		})
+	}
+	return migrations
+}
+
+func (r *Migrator) compareIDs(a, b string) int {
+	if r.ordering == OrderByTimestamp {
+		return compareTimestampIDs(a, b)
+	}
+	return strings.Compare(a, b)
+}
+
+func compareTimestampIDs(a, b string) int {
+	x, y := strings.TrimLeft(timestampPrefix(a), "0"), strings.TrimLeft(timestampPrefix(b), "0")
+	if c := cmp.Compare(len(x), len(y)); c != 0 {
+		return c
+	}
+	if c := strings.Compare(x, y); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func timestampPrefix(id string) string {
+	end := strings.IndexFunc(id, func(ch rune) bool {
+		return ch < '0' || ch > '9'
+	})
+	if end < 0 {
+		return id
+	}
+	return id[:end]
+}
@@ -0,0 +1,90 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_WithOrdering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ordering Ordering
+		expected []string
+	}{
+		{"id", OrderByID, []string{"10_posts", "2_users", "9_tags"}},
+		{"registration", OrderByRegistration, []string{"9_tags", "10_posts", "2_users"}},
+		{"timestamp", OrderByTimestamp, []string{"2_users", "9_tags", "10_posts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			if err != nil {
+				t.Fatalf("failed to open sqlite database: %v", err)
+			}
+			defer func() {
+				_ = db.Close()
+			}()
+			db.SetMaxOpenConns(1)
+
+			registered := []Migration{
+				&mockMigration{id: "9_tags", upQueries: []string{"CREATE TABLE tags (id INTEGER)"}},
+				&mockMigration{id: "10_posts", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}},
+				&mockMigration{id: "2_users", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+			}
+			migrator := New(db, WithOrdering(tt.ordering))
+			migrator.Register(registered...)
+
+			if _, err := migrator.Up(); err != nil {
+				t.Fatalf("failed to apply migrations: %v", err)
+			}
+
+			var ids []string
+			rows, err := db.Query("SELECT id FROM schema_migrations ORDER BY applied_at, rowid")
+			if err != nil {
+				t.Fatalf("failed to query history: %v", err)
+			}
+			defer func() {
+				_ = rows.Close()
+			}()
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					t.Fatalf("failed to scan history: %v", err)
+				}
+				ids = append(ids, id)
+			}
+
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, ids)
+				}
+			}
+			for i, id := range []string{"9_tags", "10_posts", "2_users"} {
+				if migrator.migrations[i].ID() != id {
+					t.Errorf("expected registered order to be preserved, got %s at %d", migrator.migrations[i].ID(), i)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrator_TimestampOrderingGaps(t *testing.T) {
+	t.Parallel()
+
+	migrator := &Migrator{ordering: OrderByTimestamp}
+	applied := []MigrationStatus{{ID: "9_tags"}}
+	pending := []Migration{&mockMigration{id: "10_posts"}, &mockMigration{id: "8_users"}}
+
+	gaps := migrator.findGaps(applied, pending)
+	if len(gaps) != 1 || gaps[0] != "8_users" {
+		t.Errorf("expected only 8_users to be a gap, got %v", gaps)
+	}
+}
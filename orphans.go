@@ -0,0 +1,64 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type OrphanPolicy int
+
+const (
+	OrphanIgnore OrphanPolicy = iota
+	OrphanWarn
+	OrphanError
+)
+
+func (r *Migrator) Orphans(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	return r.findOrphans(applied), nil
+}
+
+func (r *Migrator) findOrphans(applied []MigrationStatus) []string {
+	registered := r.buildMigrationMap(r.activeMigrations())
+
+	var orphans []string
+	for _, status := range applied {
+		if _, ok := registered[status.ID]; !ok {
+			orphans = append(orphans, status.ID)
+		}
+	}
+	return orphans
+}
+
+func (r *Migrator) checkOrphans(ctx context.Context, applied []MigrationStatus) error {
+	if r.orphans == OrphanIgnore {
+		return nil
+	}
+
+	orphans := r.findOrphans(applied)
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if r.orphans == OrphanError {
+		return fmt.Errorf("%w: %s", ErrAppliedMigrationMissing, strings.Join(orphans, ", "))
+	}
+
+	logger := r.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(ctx, slog.LevelWarn, "applied migrations are no longer registered",
+		slog.Any("migration_ids", orphans))
+	return nil
+}
@@ -0,0 +1,67 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_OrphanPolicy(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	first := New(db)
+	first.Register(
+		&mockMigration{id: "001", description: "first", upQueries: []string{"SELECT 1"}},
+		&mockMigration{id: "002", description: "second", upQueries: []string{"SELECT 2"}},
+	)
+	if _, err := first.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	registered := []Migration{
+		&mockMigration{id: "001", description: "first", upQueries: []string{"SELECT 1"}},
+		&mockMigration{id: "003", description: "third", upQueries: []string{"SELECT 3"}},
+	}
+
+	strict := New(db, WithOrphanPolicy(OrphanError))
+	strict.Register(registered...)
+
+	orphans, err := strict.Orphans(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(orphans) != 1 || orphans[0] != "002" {
+		t.Errorf("expected orphan 002, got %v", orphans)
+	}
+
+	if _, err := strict.Up(); !errors.Is(err, ErrAppliedMigrationMissing) {
+		t.Errorf("expected ErrAppliedMigrationMissing, got %v", err)
+	}
+	if _, err := strict.Down(1); !errors.Is(err, ErrAppliedMigrationMissing) {
+		t.Errorf("expected ErrAppliedMigrationMissing on down, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	warning := New(db, WithOrphanPolicy(OrphanWarn), WithLogger(logger))
+	warning.Register(registered...)
+	if _, err := warning.Up(); err != nil {
+		t.Fatalf("expected warn policy not to fail, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "level=WARN") || !strings.Contains(buf.String(), "002") {
+		t.Errorf("expected orphan warning to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,27 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+)
+
+func (r *Migrator) broadcastVersion(ctx context.Context, result *Result) error {
+	if r.notifyChannel == "" || len(result.Migrations) == 0 {
+		return nil
+	}
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToBroadcast, err)
+	}
+
+	version := ""
+	if len(applied) > 0 {
+		version = applied[len(applied)-1].ID
+	}
+
+	if _, err := r.conn.Exec(ctx, r.dialect.rebind("SELECT pg_notify(?, ?)"), r.notifyChannel, version); err != nil {
+		return errors.Join(ErrFailedToBroadcast, err)
+	}
+	return nil
+}
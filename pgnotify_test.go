@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+type notifyConn struct {
+	Conn
+	payloads [][]any
+}
+
+func (c *notifyConn) Exec(ctx context.Context, query string, args ...any) (ExecResult, error) {
+	if strings.Contains(query, "pg_notify") {
+		c.payloads = append(c.payloads, args)
+		return nil, nil
+	}
+	return c.Conn.Exec(ctx, query, args...)
+}
+
+func TestMigrator_WithPostgresNotify(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	conn := &notifyConn{Conn: stdConn{db: db}}
+	migrator := NewWithConn(conn, WithPostgresNotify("schema_migrations"))
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}, downQueries: []string{"DROP TABLE users"}},
+		&mockMigration{id: "2", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}, downQueries: []string{"DROP TABLE posts"}},
+	)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to re-run migrations: %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+
+	if len(conn.payloads) != 2 {
+		t.Fatalf("expected notifications only for runs that changed the schema, got %v", conn.payloads)
+	}
+	for i, version := range []string{"2", "1"} {
+		if conn.payloads[i][0] != "schema_migrations" || conn.payloads[i][1] != version {
+			t.Errorf("expected notification %d to carry version %s, got %v", i, version, conn.payloads[i])
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Plan struct {
+	Direction    Direction
+	Batch        int
+	Migrations   []PlannedMigration
+	Transactions []PlannedTransaction
+	Warnings     []string
+}
+
+type PlannedMigration struct {
+	ID            string
+	Description   string
+	Statements    []string
+	Transactional bool
+	Reversible    bool
+	Destructive   bool
+	Blocking      bool
+	Operations    []PlannedOperation
+}
+
+type PlannedTransaction struct {
+	Transactional bool
+	IDs           []string
+}
+
+func (p *Plan) IDs() []string {
+	ids := make([]string, len(p.Migrations))
+	for i, migration := range p.Migrations {
+		ids[i] = migration.ID
+	}
+	return ids
+}
+
+func (p *Plan) StatementCount() int {
+	count := 0
+	for _, migration := range p.Migrations {
+		count += len(migration.Statements)
+	}
+	return count
+}
+
+func (p *Plan) Destructive() bool {
+	for _, migration := range p.Migrations {
+		if migration.Destructive {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Migrator) Plan(ctx context.Context, opts ...RunOption) (*Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	pending, err := r.orderByDependencies(newRunConfig(opts).filter(r.pendingMigrations(applied)), applied)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := r.buildPlan(applied, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := r.validateMigrations(pending)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s has invalid %s SQL: %v: %s", issue.ID, issue.Direction, issue.Err, firstLine(strings.TrimSpace(issue.Statement))))
+	}
+	return plan, nil
+}
+
+func lazyPlan(build func() (*Plan, error)) func() (*Plan, error) {
+	var (
+		plan  *Plan
+		err   error
+		built bool
+	)
+	return func() (*Plan, error) {
+		if !built {
+			plan, err = build()
+			built = true
+		}
+		return plan, err
+	}
+}
+
+func (r *Migrator) buildPlan(applied []MigrationStatus, pending []Migration) (*Plan, error) {
+	plan := &Plan{Direction: DirectionUp, Batch: r.getNextBatchNumber(applied)}
+
+	for _, migration := range pending {
+		queries, err := r.upQueries(migration)
+		if err != nil {
+			return nil, err
+		}
+
+		planned := r.plannedMigration(migration, queries)
+		if streaming, ok := migration.(Streaming); ok {
+			if err := classifyStream(&planned, streaming); err != nil {
+				return nil, err
+			}
+		}
+		plan.Migrations = append(plan.Migrations, planned)
+
+		if !planned.Transactional {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s runs outside a transaction and is not rolled back on failure", planned.ID))
+		}
+		if !planned.Reversible {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s has no down queries and cannot be rolled back", planned.ID))
+		}
+	}
+
+	for _, segment := range splitByTransaction(plan.Migrations, func(m PlannedMigration) bool { return m.Transactional }) {
+		transaction := PlannedTransaction{Transactional: segment[0].Transactional}
+		for _, migration := range segment {
+			transaction.IDs = append(transaction.IDs, migration.ID)
+		}
+		plan.Transactions = append(plan.Transactions, transaction)
+	}
+
+	if gaps := r.findGaps(applied, pending); len(gaps) > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("out-of-order migrations older than the newest applied one: %s", strings.Join(gaps, ", ")))
+	}
+
+	return plan, nil
+}
+
+func (r *Migrator) buildRollbackPlan(rollbackList []MigrationStatus, migrationMap map[string]Migration) (*Plan, error) {
+	plan := &Plan{Direction: DirectionDown}
+	if len(rollbackList) > 0 {
+		plan.Batch = rollbackList[0].Batch
+	}
+
+	for _, status := range rollbackList {
+		migration, ok := migrationMap[status.ID]
+		if !ok {
+			plan.Migrations = append(plan.Migrations, PlannedMigration{ID: status.ID, Description: status.Description})
+			continue
+		}
+
+		queries, err := r.downQueries(migration)
+		if err != nil {
+			return nil, err
+		}
+		plan.Migrations = append(plan.Migrations, r.plannedMigration(migration, queries))
+	}
+	return plan, nil
+}
+
+func (r *Migrator) plannedMigration(migration Migration, queries []string) PlannedMigration {
+	planned := PlannedMigration{
+		ID:            migration.ID(),
+		Description:   migration.Description(),
+		Transactional: r.isTransactional(migration),
+		Reversible:    isReversible(migration),
+	}
+	for _, query := range queries {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		class := classifyStatement(query)
+		planned.Statements = append(planned.Statements, query)
+		planned.Operations = append(planned.Operations, PlannedOperation{Statement: query, Class: class})
+		planned.Destructive = planned.Destructive || class == OperationDestructive
+		planned.Blocking = planned.Blocking || class == OperationBlocking
+	}
+	return planned
+}
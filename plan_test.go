@@ -0,0 +1,83 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_Plan(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"CREATE TABLE b (id INTEGER)"}, downQueries: []string{"DROP TABLE b"}})
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id INTEGER)", " "}, downQueries: []string{"DROP TABLE a"}},
+		&nonTransactionalMigration{mockMigration{id: "3", upQueries: []string{"CREATE INDEX idx_b ON b (id)"}, downQueries: []string{"DROP INDEX idx_b"}}},
+		&mockMigration{id: "4", upQueries: []string{"CREATE TABLE c (id INTEGER)", "INSERT INTO c VALUES (1)"}},
+	)
+
+	plan, err := migrator.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if plan.Batch != 2 {
+		t.Errorf("expected batch 2, got %d", plan.Batch)
+	}
+	if !reflect.DeepEqual(plan.IDs(), []string{"1", "3", "4"}) {
+		t.Errorf("unexpected ids: %v", plan.IDs())
+	}
+	if plan.StatementCount() != 4 {
+		t.Errorf("expected 4 statements, got %d", plan.StatementCount())
+	}
+
+	expected := []PlannedTransaction{
+		{Transactional: true, IDs: []string{"1"}},
+		{Transactional: false, IDs: []string{"3"}},
+		{Transactional: true, IDs: []string{"4"}},
+	}
+	if !reflect.DeepEqual(plan.Transactions, expected) {
+		t.Errorf("expected transactions %+v, got %+v", expected, plan.Transactions)
+	}
+
+	warnings := strings.Join(plan.Warnings, "\n")
+	for _, fragment := range []string{"3 runs outside a transaction", "4 has no down queries", "out-of-order migrations older than the newest applied one: 1"} {
+		if !strings.Contains(warnings, fragment) {
+			t.Errorf("expected warning %q, got:\n%s", fragment, warnings)
+		}
+	}
+
+	assertAppliedIDs(t, migrator, "2")
+}
+
+func TestLazyPlan(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	plan := lazyPlan(func() (*Plan, error) {
+		calls++
+		return &Plan{Direction: DirectionUp}, nil
+	})
+
+	first, _ := plan()
+	second, _ := plan()
+	if calls != 1 || first != second {
+		t.Errorf("expected the plan to be built once and shared, got %d builds", calls)
+	}
+}
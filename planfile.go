@@ -0,0 +1,156 @@
+package migrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+const planFileVersion = 1
+
+type SavedPlan struct {
+	Version    int                  `json:"version"`
+	Module     string               `json:"module"`
+	CreatedAt  time.Time            `json:"created_at"`
+	Migrations []SavedPlanMigration `json:"migrations"`
+}
+
+type SavedPlanMigration struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Checksum    string   `json:"checksum"`
+	Statements  []string `json:"statements"`
+}
+
+func (r *Migrator) SavePlan(ctx context.Context, path string, opts ...RunOption) (*Plan, error) {
+	plan, err := r.Plan(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.stateMu.RLock()
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
+	r.stateMu.RUnlock()
+
+	saved := SavedPlan{Version: planFileVersion, Module: r.module(), CreatedAt: r.now(), Migrations: []SavedPlanMigration{}}
+	for _, planned := range plan.Migrations {
+		checksum, err := r.checksum(migrationMap[planned.ID])
+		if err != nil {
+			return nil, err
+		}
+		saved.Migrations = append(saved.Migrations, SavedPlanMigration{
+			ID:          planned.ID,
+			Description: planned.Description,
+			Checksum:    checksum,
+			Statements:  planned.Statements,
+		})
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (r *Migrator) ApplyPlan(ctx context.Context, path string, opts ...RunOption) error {
+	saved, err := readPlanFile(path)
+	if err != nil {
+		return err
+	}
+	if saved.Module != r.module() {
+		return fmt.Errorf("%w: plan was saved for module %q", ErrPlanMismatch, saved.Module)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config := newRunConfig(opts)
+	config.only = make(map[string]bool)
+	for _, migration := range saved.Migrations {
+		config.only[migration.ID] = true
+	}
+
+	return r.run(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		if err := r.checkSavedPlan(ctx, saved); err != nil {
+			return err
+		}
+		if len(saved.Migrations) == 0 {
+			return nil
+		}
+		return r.up(ctx, config, result)
+	})
+}
+
+func readPlanFile(path string) (*SavedPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved SavedPlan
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, errors.Join(ErrInvalidPlanFile, err)
+	}
+	if saved.Version != planFileVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidPlanFile, saved.Version)
+	}
+	return &saved, nil
+}
+
+func (r *Migrator) checkSavedPlan(ctx context.Context, saved *SavedPlan) error {
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+	pending, err := r.orderByDependencies(r.pendingMigrations(applied), applied)
+	if err != nil {
+		return err
+	}
+
+	pendingByID := make(map[string]Migration, len(pending))
+	var pendingIDs []string
+	for _, migration := range pending {
+		pendingByID[migration.ID()] = migration
+		pendingIDs = append(pendingIDs, migration.ID())
+	}
+
+	var errs []error
+	var planned []string
+	for _, step := range saved.Migrations {
+		planned = append(planned, step.ID)
+		migration, ok := pendingByID[step.ID]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s is no longer pending", ErrPlanMismatch, step.ID))
+			continue
+		}
+		checksum, err := r.checksum(migration)
+		if err != nil {
+			return err
+		}
+		if checksum != step.Checksum {
+			errs = append(errs, fmt.Errorf("%w: %s changed since the plan was saved", ErrPlanMismatch, step.ID))
+		}
+	}
+	if len(errs) == 0 && !slices.Equal(planned, orderedSubset(pendingIDs, planned)) {
+		errs = append(errs, fmt.Errorf("%w: migrations would run in a different order", ErrPlanMismatch))
+	}
+	return errors.Join(errs...)
+}
+
+func orderedSubset(ids, subset []string) []string {
+	var ordered []string
+	for _, id := range ids {
+		if slices.Contains(subset, id) {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered
+}
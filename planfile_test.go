@@ -0,0 +1,78 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrator_SaveAndApplyPlan(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "release.plan.json")
+
+	migrator := New(db)
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build(),
+		CreateMigration("002", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").Build(),
+	)
+
+	plan, err := migrator.SavePlan(ctx, path)
+	if err != nil {
+		t.Fatalf("failed to save plan: %v", err)
+	}
+	if ids := plan.IDs(); len(ids) != 2 {
+		t.Fatalf("expected two planned migrations, got %v", ids)
+	}
+
+	migrator.Register(CreateMigration("003", "add phone").RawUp("ALTER TABLE users ADD COLUMN phone TEXT").Build())
+	if err := migrator.ApplyPlan(ctx, path); err != nil {
+		t.Fatalf("failed to apply plan: %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "002")
+
+	if err := migrator.ApplyPlan(ctx, path); !errors.Is(err, ErrPlanMismatch) {
+		t.Errorf("expected re-applying a consumed plan to fail with ErrPlanMismatch, got %v", err)
+	}
+}
+
+func TestMigrator_ApplyPlanRejectsChangedRegistry(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "release.plan.json")
+	users := CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build()
+
+	migrator := New(db)
+	migrator.Register(users)
+	if _, err := migrator.SavePlan(ctx, path); err != nil {
+		t.Fatalf("failed to save plan: %v", err)
+	}
+
+	users.(*baseMigration).upQueries[0] = "CREATE TABLE users (id INTEGER, name TEXT)"
+	if err := migrator.ApplyPlan(ctx, path); !errors.Is(err, ErrPlanMismatch) {
+		t.Fatalf("expected ErrPlanMismatch, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
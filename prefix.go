@@ -0,0 +1,63 @@
+package migrator
+
+import "strings"
+
+const identifierMarker = "\x1f"
+
+type markedMigration interface {
+	markedQueries(direction Direction) []string
+}
+
+func identifier(name string) string {
+	return identifierMarker + name + identifierMarker
+}
+
+func (r *Migrator) upQueries(migration Migration) ([]string, error) {
+	if err := checkDialect(migration, r.dialect); err != nil {
+		return nil, err
+	}
+	queries, err := r.renderQueries(migration, queriesForDialect(migration, r.dialect, DirectionUp))
+	if err != nil {
+		return nil, err
+	}
+	return r.prefixQueries(queries), nil
+}
+
+func (r *Migrator) downQueries(migration Migration) ([]string, error) {
+	if err := checkDialect(migration, r.dialect); err != nil {
+		return nil, err
+	}
+	queries, err := r.renderQueries(migration, queriesForDialect(migration, r.dialect, DirectionDown))
+	if err != nil {
+		return nil, err
+	}
+	return r.prefixQueries(queries), nil
+}
+
+func (r *Migrator) prefixQueries(queries []string) []string {
+	prefixed := make([]string, len(queries))
+	for i, query := range queries {
+		prefixed[i] = prefixIdentifiers(query, r.tablePrefix)
+	}
+	return prefixed
+}
+
+func stripIdentifiers(queries []string) []string {
+	stripped := make([]string, len(queries))
+	for i, query := range queries {
+		stripped[i] = prefixIdentifiers(query, "")
+	}
+	return stripped
+}
+
+func prefixIdentifiers(query, prefix string) string {
+	if !strings.Contains(query, identifierMarker) {
+		return query
+	}
+
+	parts := strings.Split(query, identifierMarker)
+	for i := 1; i < len(parts); i += 2 {
+		parts[i] = prefix + parts[i]
+	}
+	return strings.Join(parts, "")
+}
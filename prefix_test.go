@@ -0,0 +1,121 @@
+package migrator
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPrefixIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		migration Migration
+		expected  []string
+	}{
+		{
+			name:      "column named like the table",
+			migration: CreateMigration("1", "tags").CreateTable("tag", "tag TEXT").Build(),
+			expected:  []string{"CREATE TABLE IF NOT EXISTS app1_tag (\n    tag TEXT\n);"},
+		},
+		{
+			name: "foreign key reference",
+			migration: CreateMigration("1", "posts").
+				AddForeignKey("posts", "user_id", "users", "id").
+				Build(),
+			expected: []string{"ALTER TABLE app1_posts ADD CONSTRAINT fk_posts_user_id FOREIGN KEY (user_id) REFERENCES app1_users(id);"},
+		},
+		{
+			name:      "index",
+			migration: CreateMigration("1", "index").CreateIndex("idx_users_email", "users", "email").Build(),
+			expected:  []string{"CREATE INDEX app1_idx_users_email ON app1_users (email);"},
+		},
+		{
+			name: "raw statement",
+			migration: CreateMigration("1", "seed").
+				CreateTable("users", "id INTEGER").
+				RawUp("INSERT INTO app1_users (id) VALUES (1); -- users").
+				RawUp("UPDATE app1_users SET note = 'users'").
+				Build(),
+			expected: []string{
+				"CREATE TABLE IF NOT EXISTS app1_users (\n    id INTEGER\n);",
+				"INSERT INTO app1_users (id) VALUES (1); -- users",
+				"UPDATE app1_users SET note = 'users'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			migrator := New(nil, WithTablePrefix("app1_"))
+			got, err := migrator.upQueries(tt.migration)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if strings.Contains(strings.Join(tt.migration.Up(), ""), identifierMarker) {
+				t.Errorf("expected Up to return plain SQL, got %q", tt.migration.Up())
+			}
+		})
+	}
+}
+
+func TestMigrator_WithTablePrefix(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	build := func() Migration {
+		return CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY").
+			CreateIndex("idx_users_id", "users", "id").
+			Build()
+	}
+
+	for _, prefix := range []string{"app1_", "app2_"} {
+		migrator := New(db, WithTablePrefix(prefix))
+		migrator.Register(build())
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("expected no error for prefix %s, got %v", prefix, err)
+		}
+	}
+
+	for _, table := range []string{"app1_users", "app2_users", "app1_schema_migrations", "app2_schema_migrations"} {
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&count)
+		if err != nil {
+			t.Fatalf("failed to check table existence: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("expected table %s to exist", table)
+		}
+	}
+
+	migrator := New(db, WithTablePrefix("app1_"))
+	migrator.Register(build())
+	if _, err := migrator.Down(0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name IN ('app1_users', 'app2_users')").Scan(&count)
+	if err != nil {
+		t.Fatalf("failed to check table existence: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected only app2_users to remain, got %d tables", count)
+	}
+}
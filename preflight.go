@@ -0,0 +1,205 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PreflightCheck string
+
+const (
+	PreflightVersion          PreflightCheck = "version"
+	PreflightPrivileges       PreflightCheck = "privileges"
+	PreflightDiskSpace        PreflightCheck = "disk_space"
+	PreflightLongTransactions PreflightCheck = "long_transactions"
+)
+
+type PreflightConfig struct {
+	MinVersion        string
+	Privileges        bool
+	MinFreeBytes      int64
+	FreeBytes         func(ctx context.Context) (int64, error)
+	MaxTransactionAge time.Duration
+}
+
+type PreflightFailure struct {
+	Check  PreflightCheck
+	Detail string
+}
+
+type PreflightError struct {
+	Failures []PreflightFailure
+}
+
+func (e *PreflightError) Error() string {
+	details := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		details[i] = fmt.Sprintf("%s: %s", failure.Check, failure.Detail)
+	}
+	return fmt.Sprintf("%s: %s", ErrPreflightFailed, strings.Join(details, "; "))
+}
+
+func (e *PreflightError) Unwrap() error {
+	return ErrPreflightFailed
+}
+
+type preflightQueries struct {
+	version      string
+	privileges   string
+	transactions string
+}
+
+var preflight = map[Dialect]preflightQueries{
+	DialectPostgres: {
+		version: "SHOW server_version",
+		privileges: `SELECT CASE WHEN has_database_privilege(current_database(), 'CREATE')
+    AND has_schema_privilege(current_schema(), 'CREATE') THEN 1 ELSE 0 END`,
+		transactions: `SELECT pid, EXTRACT(EPOCH FROM now() - xact_start)::BIGINT FROM pg_stat_activity
+WHERE xact_start IS NOT NULL AND pid <> pg_backend_pid() AND datname = current_database()`,
+	},
+	DialectMySQL: {
+		version: "SELECT VERSION()",
+		privileges: `SELECT CASE WHEN COUNT(DISTINCT privilege_type) = 2 THEN 1 ELSE 0 END FROM (
+    SELECT grantee, privilege_type FROM information_schema.user_privileges
+    UNION ALL
+    SELECT grantee, privilege_type FROM information_schema.schema_privileges WHERE table_schema = DATABASE()
+) p WHERE grantee = CONCAT('''', REPLACE(CURRENT_USER(), '@', '''@'''), '''') AND privilege_type IN ('CREATE', 'ALTER')`,
+		transactions: `SELECT trx_mysql_thread_id, TIMESTAMPDIFF(SECOND, trx_started, NOW())
+FROM information_schema.innodb_trx WHERE trx_mysql_thread_id <> CONNECTION_ID()`,
+	},
+	DialectSQLite: {
+		version: "SELECT sqlite_version()",
+	},
+}
+
+func (r *Migrator) Preflight(ctx context.Context) error {
+	if r.preflight == nil {
+		return nil
+	}
+
+	config := *r.preflight
+	queries := preflight[r.dialect]
+	var failures []PreflightFailure
+	fail := func(check PreflightCheck, format string, args ...any) {
+		failures = append(failures, PreflightFailure{Check: check, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if config.MinVersion != "" && queries.version != "" {
+		var version string
+		if err := r.queryValue(ctx, queries.version, &version); err != nil {
+			fail(PreflightVersion, "failed to query server version: %v", err)
+		} else if compareVersions(version, config.MinVersion) < 0 {
+			fail(PreflightVersion, "server version %s is older than required %s", version, config.MinVersion)
+		}
+	}
+
+	if config.Privileges && queries.privileges != "" {
+		var granted int
+		if err := r.queryValue(ctx, queries.privileges, &granted); err != nil {
+			fail(PreflightPrivileges, "failed to query privileges: %v", err)
+		} else if granted == 0 {
+			fail(PreflightPrivileges, "current user lacks CREATE/ALTER privileges")
+		}
+	}
+
+	if config.MinFreeBytes > 0 && config.FreeBytes != nil {
+		free, err := config.FreeBytes(ctx)
+		if err != nil {
+			fail(PreflightDiskSpace, "failed to determine free space: %v", err)
+		} else if free < config.MinFreeBytes {
+			fail(PreflightDiskSpace, "%d bytes free, %d required", free, config.MinFreeBytes)
+		}
+	}
+
+	if config.MaxTransactionAge > 0 && queries.transactions != "" {
+		if err := r.checkLongTransactions(ctx, queries.transactions, config.MaxTransactionAge, fail); err != nil {
+			fail(PreflightLongTransactions, "failed to query open transactions: %v", err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return &PreflightError{Failures: failures}
+	}
+	return nil
+}
+
+func (r *Migrator) checkLongTransactions(ctx context.Context, query string, maxAge time.Duration, fail func(PreflightCheck, string, ...any)) error {
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var session, seconds int64
+		if err := rows.Scan(&session, &seconds); err != nil {
+			return err
+		}
+		if age := time.Duration(seconds) * time.Second; age > maxAge {
+			fail(PreflightLongTransactions, "session %d has a transaction open for %s", session, age)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *Migrator) queryValue(ctx context.Context, query string, dest any) error {
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("no rows returned by %q", query)
+	}
+	return rows.Scan(dest)
+}
+
+func compareVersions(actual, required string) int {
+	a, b := versionParts(actual), versionParts(required)
+	for i := range max(len(a), len(b)) {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	end := strings.IndexFunc(version, func(ch rune) bool {
+		return (ch < '0' || ch > '9') && ch != '.'
+	})
+	if end >= 0 {
+		version = version[:end]
+	}
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
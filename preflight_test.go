@@ -0,0 +1,75 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Preflight(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migration := &mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}}
+
+	failing := New(db, WithDialect(DialectSQLite), WithPreflight(PreflightConfig{
+		MinVersion:   "99.0",
+		MinFreeBytes: 1 << 30,
+		FreeBytes: func(context.Context) (int64, error) {
+			return 1 << 20, nil
+		},
+	}))
+	failing.Register(migration)
+
+	_, err = failing.Up()
+	if !errors.Is(err, ErrPreflightFailed) {
+		t.Fatalf("expected ErrPreflightFailed, got %v", err)
+	}
+	var preflightErr *PreflightError
+	if !errors.As(err, &preflightErr) || len(preflightErr.Failures) != 2 {
+		t.Fatalf("expected 2 structured failures, got %v", err)
+	}
+	if preflightErr.Failures[0].Check != PreflightVersion || preflightErr.Failures[1].Check != PreflightDiskSpace {
+		t.Errorf("unexpected failures: %+v", preflightErr.Failures)
+	}
+	assertAppliedIDs(t, failing)
+
+	passing := New(db, WithDialect(DialectSQLite), WithPreflight(PreflightConfig{
+		MinVersion:        "3.0",
+		Privileges:        true,
+		MaxTransactionAge: 1,
+	}))
+	passing.Register(migration)
+	if _, err := passing.Up(); err != nil {
+		t.Fatalf("expected preflight to pass, got %v", err)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		actual   string
+		required string
+		want     int
+	}{
+		{"15.4 (Debian 15.4-1.pgdg120+1)", "13", 1},
+		{"8.0.34-log", "8.0.34", 0},
+		{"5.7.44", "8.0", -1},
+		{"3.45.1", "3.45.10", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.actual, tt.required); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.actual, tt.required, got, tt.want)
+		}
+	}
+}
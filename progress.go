@@ -0,0 +1,53 @@
+package migrator
+
+import "strings"
+
+type ProgressFunc func(done, total int, current Migration)
+
+type StatementProgress struct {
+	Direction   Direction
+	MigrationID string
+	Index       int
+	Total       int
+	Statement   string
+}
+
+type StatementProgressFunc func(progress StatementProgress)
+
+func (r *Migrator) reportProgress(done, total int, current Migration) {
+	if r.progress != nil {
+		r.progress(done, total, current)
+	}
+}
+
+func (r *Migrator) reportStatement(direction Direction, batch int, migrationID string, index, total int, statement string) {
+	r.emit(Event{
+		Kind:           EventStatementStarted,
+		Direction:      direction,
+		Batch:          batch,
+		MigrationID:    migrationID,
+		Statement:      statement,
+		StatementIndex: index,
+		StatementTotal: total,
+	})
+
+	if r.statementProgress != nil {
+		r.statementProgress(StatementProgress{
+			Direction:   direction,
+			MigrationID: migrationID,
+			Index:       index,
+			Total:       total,
+			Statement:   firstLine(strings.TrimSpace(statement)),
+		})
+	}
+}
+
+func countStatements(queries []string) int {
+	total := 0
+	for _, query := range queries {
+		if strings.TrimSpace(query) != "" {
+			total++
+		}
+	}
+	return total
+}
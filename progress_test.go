@@ -0,0 +1,98 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_WithProgress(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	type call struct {
+		done, total int
+		current     string
+	}
+	var calls []call
+
+	migrator := New(db, WithProgress(func(done, total int, current Migration) {
+		c := call{done: done, total: total}
+		if current != nil {
+			c.current = current.ID()
+		}
+		calls = append(calls, c)
+	}))
+	migrator.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"CREATE TABLE b (id INTEGER)"}},
+	)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	expected := []call{
+		{done: 0, total: 2, current: "1"},
+		{done: 1, total: 2, current: "2"},
+		{done: 2, total: 2},
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d progress calls, got %d: %+v", len(expected), len(calls), calls)
+	}
+	for i, want := range expected {
+		if calls[i] != want {
+			t.Errorf("expected call %d to be %+v, got %+v", i, want, calls[i])
+		}
+	}
+}
+
+func TestMigrator_WithStatementProgress(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var calls []StatementProgress
+	migrator := New(db, WithStatementProgress(func(progress StatementProgress) {
+		calls = append(calls, progress)
+	}))
+	migrator.Register(&mockMigration{
+		id:          "1",
+		upQueries:   []string{"CREATE TABLE a (\n    id INTEGER\n)", "", "CREATE INDEX idx_a ON a (id)"},
+		downQueries: []string{"-- keep data", "DROP TABLE a"},
+	})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back migrations: %v", err)
+	}
+
+	expected := []StatementProgress{
+		{Direction: DirectionUp, MigrationID: "1", Index: 1, Total: 2, Statement: "CREATE TABLE a ("},
+		{Direction: DirectionUp, MigrationID: "1", Index: 2, Total: 2, Statement: "CREATE INDEX idx_a ON a (id)"},
+		{Direction: DirectionDown, MigrationID: "1", Index: 1, Total: 1, Statement: "DROP TABLE a"},
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d statement progress calls, got %d: %+v", len(expected), len(calls), calls)
+	}
+	for i, want := range expected {
+		if calls[i] != want {
+			t.Errorf("expected call %d to be %+v, got %+v", i, want, calls[i])
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Protected interface {
+	Protected() bool
+}
+
+func isProtected(migration Migration) bool {
+	protected, ok := migration.(Protected)
+	return ok && protected.Protected()
+}
+
+func checkProtected(rollbackList []MigrationStatus, migrationMap map[string]Migration) error {
+	var blocking []string
+	for _, status := range rollbackList {
+		if migration, ok := migrationMap[status.ID]; ok && isProtected(migration) {
+			blocking = append(blocking, status.ID)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrProtectedMigration, strings.Join(blocking, ", "))
+}
@@ -0,0 +1,41 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_ProtectedMigration(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, total INTEGER)").RawDown("DROP TABLE users").Build(),
+		CreateMigration("002", "backfill totals").RawUp("UPDATE users SET total = 0").RawDown("UPDATE users SET total = NULL").Protected().Build(),
+		CreateMigration("003", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").RawDown("ALTER TABLE users DROP COLUMN email").Build(),
+	)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected rollback above the protected migration to succeed, got %v", err)
+	}
+
+	_, err = migrator.Down(0, AllowDestructive())
+	if !errors.Is(err, ErrProtectedMigration) || !strings.Contains(err.Error(), "002") {
+		t.Fatalf("expected ErrProtectedMigration naming 002, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "002")
+}
@@ -0,0 +1,93 @@
+package migrator
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"slices"
+)
+
+type Provider interface {
+	Migrations() []Migration
+}
+
+type NamedProvider interface {
+	Provider
+	Name() string
+}
+
+type providedMigration struct {
+	Migration
+	group string
+}
+
+func (m providedMigration) Group() string {
+	return m.group
+}
+
+func providerName(provider Provider) string {
+	if named, ok := provider.(NamedProvider); ok {
+		return named.Name()
+	}
+
+	t := reflect.TypeOf(provider)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return path.Base(t.PkgPath())
+}
+
+func (m *Migrator) Use(providers ...Provider) {
+	for _, provider := range providers {
+		name := providerName(provider)
+
+		var migrations []Migration
+		for _, migration := range provider.Migrations() {
+			migrations = append(migrations, inGroup(migration, name))
+		}
+
+		m.Register(migrations...)
+
+		m.mu.Lock()
+		if !slices.Contains(m.providers, name) {
+			m.providers = append(m.providers, name)
+		}
+		m.mu.Unlock()
+	}
+}
+
+func inGroup(migration Migration, group string) Migration {
+	if migration == nil || groupOf(migration) != "" {
+		return migration
+	}
+	if base, ok := migration.(*baseMigration); ok {
+		clone := *base
+		clone.group = group
+		return &clone
+	}
+	return providedMigration{Migration: migration, group: group}
+}
+
+func (r *Migrator) upProviders(ctx context.Context, config runConfig) (*Result, error) {
+	combined := &Result{Direction: DirectionUp}
+	for _, group := range r.providers {
+		var result *Result
+		err := r.withGroup(group, func() error {
+			var err error
+			result, err = r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+				return r.up(ctx, config, result)
+			})
+			return err
+		})
+		combined.Migrations = append(combined.Migrations, result.Migrations...)
+		combined.Failures = append(combined.Failures, result.Failures...)
+		if err != nil {
+			combined.FailedID = result.FailedID
+			return combined, err
+		}
+	}
+	return combined, nil
+}
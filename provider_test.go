@@ -0,0 +1,74 @@
+package migrator
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+type auditProvider struct{}
+
+func (auditProvider) Name() string {
+	return "audit"
+}
+
+func (auditProvider) Migrations() []Migration {
+	return []Migration{
+		CreateMigration("001", "create audit log").RawUp("CREATE TABLE audit_log (id INTEGER)").RawDown("DROP TABLE audit_log").Build(),
+	}
+}
+
+type jobsProvider struct{}
+
+func (*jobsProvider) Migrations() []Migration {
+	return []Migration{
+		CreateMigration("001", "create jobs").RawUp("CREATE TABLE jobs (id INTEGER)").Build(),
+	}
+}
+
+func TestMigrator_Use(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Use(auditProvider{}, &jobsProvider{})
+	migrator.Register(CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build())
+
+	if groups := migrator.Groups(); !slices.Equal(groups, []string{"audit", "migrator"}) {
+		t.Errorf("expected providers to be namespaced into groups, got %v", groups)
+	}
+
+	result, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if len(result.Migrations) != 3 {
+		t.Errorf("expected provider and host migrations to be applied, got %+v", result.Migrations)
+	}
+	for _, table := range []string{"audit_log", "jobs", "users"} {
+		if count := countRows(t, db, table); count != 0 {
+			t.Errorf("expected empty table %s, got %d rows", table, count)
+		}
+	}
+
+	history, err := migrator.StatusGroup("audit")
+	if err != nil {
+		t.Fatalf("failed to get audit status: %v", err)
+	}
+	if len(history) != 1 || history[0].ID != "001" {
+		t.Errorf("unexpected audit history: %+v", history)
+	}
+	assertAppliedIDs(t, migrator, "001")
+
+	if result, err := migrator.Up(); err != nil || len(result.Migrations) != 0 {
+		t.Errorf("expected nothing left to apply, got %+v (%v)", result, err)
+	}
+}
@@ -0,0 +1,198 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const baselineTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[1]s_baseline (
+    module VARCHAR(255) NOT NULL,
+    id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (module)
+);
+`
+
+const defaultKeepBatches = 1
+
+type PruneOption func(*pruneConfig)
+
+type pruneConfig struct {
+	keepBatches int
+	archive     bool
+}
+
+func KeepBatches(n int) PruneOption {
+	return func(c *pruneConfig) {
+		c.keepBatches = n
+	}
+}
+
+func WithArchive() PruneOption {
+	return func(c *pruneConfig) {
+		c.archive = true
+	}
+}
+
+func (r *Migrator) PruneHistory(ctx context.Context, olderThan time.Time, opts ...PruneOption) (int64, error) {
+	config := pruneConfig{keepBatches: defaultKeepBatches}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	cutoff := r.getNextBatchNumber(applied) - 1 - config.keepBatches
+	newest := r.newestPrunable(applied, cutoff, olderThan)
+	if newest == "" {
+		return 0, nil
+	}
+	advance := r.baseline == "" || r.compareIDs(newest, r.baseline) > 0
+	if advance {
+		if ids := r.unappliedBelow(applied, newest); len(ids) > 0 {
+			return 0, fmt.Errorf("%w: %s", ErrUnappliedBelowBaseline, strings.Join(ids, ", "))
+		}
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToBeginTransaction, err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	pruned, err := r.pruneRows(ctx, tx, config, cutoff, olderThan)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToPruneHistory, err)
+	}
+	if advance {
+		if err := r.saveBaseline(ctx, tx, newest); err != nil {
+			return 0, errors.Join(ErrFailedToPruneHistory, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Join(ErrFailedToPruneHistory, err)
+	}
+	tx = nil
+
+	if advance {
+		r.setBaseline(newest)
+	}
+	return pruned, nil
+}
+
+func (r *Migrator) newestPrunable(applied []MigrationStatus, cutoff int, olderThan time.Time) string {
+	repeatable := r.repeatableIDs()
+	newest := ""
+	for _, migration := range applied {
+		if repeatable[migration.ID] || migration.Batch > cutoff || !migration.AppliedAt.Before(olderThan) {
+			continue
+		}
+		if newest == "" || r.compareIDs(migration.ID, newest) > 0 {
+			newest = migration.ID
+		}
+	}
+	return newest
+}
+
+func (r *Migrator) unappliedBelow(applied []MigrationStatus, newest string) []string {
+	appliedIDs := make(map[string]bool, len(applied))
+	for _, migration := range applied {
+		appliedIDs[migration.ID] = true
+	}
+
+	var ids []string
+	for _, migration := range r.activeMigrations() {
+		id := migration.ID()
+		if isRepeatable(migration) || appliedIDs[id] || r.compareIDs(id, newest) > 0 {
+			continue
+		}
+		if r.baseline != "" && r.compareIDs(id, r.baseline) <= 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r *Migrator) pruneRows(ctx context.Context, tx Tx, config pruneConfig, cutoff int, olderThan time.Time) (int64, error) {
+	where, args := r.excludeRepeatable(" WHERE module = ? AND status = ? AND batch <= ? AND applied_at < ?", []any{r.module(), string(StateApplied), cutoff, olderThan.UTC()})
+
+	if config.archive {
+		if err := r.archiveHistory(ctx, tx, where, args); err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := tx.Exec(ctx, r.query("DELETE FROM %s"+where), args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *Migrator) archiveHistory(ctx context.Context, tx Tx, where string, args []any) error {
+	archive := r.table + "_archive"
+	if _, err := tx.Exec(ctx, r.dialect.rebind(fmt.Sprintf(migrationTableTemplate, archive))); err != nil {
+		return err
+	}
+
+	const columns = "module, id, description, applied_at, batch, checksum, duration_ms, status"
+	_, err := tx.Exec(ctx, r.query("INSERT INTO "+archive+" ("+columns+") SELECT "+columns+" FROM %s"+where), args...)
+	return err
+}
+
+func (r *Migrator) saveBaseline(ctx context.Context, tx Tx, id string) error {
+	if _, err := tx.Exec(ctx, r.query("DELETE FROM %s_baseline WHERE module = ?"), r.module()); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, r.query("INSERT INTO %s_baseline (module, id) VALUES (?, ?)"), r.module(), id)
+	return err
+}
+
+func (r *Migrator) refreshBaseline(ctx context.Context) error {
+	baseline, err := r.loadBaseline(ctx)
+	if err != nil {
+		return err
+	}
+	r.setBaseline(baseline)
+	return nil
+}
+
+func (r *Migrator) setBaseline(baseline string) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+	r.baseline = baseline
+}
+
+func (r *Migrator) loadBaseline(ctx context.Context) (string, error) {
+	rows, err := r.conn.Query(ctx, r.query("SELECT id FROM %s_baseline WHERE module = ?"), r.module())
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var baseline sql.NullString
+	if rows.Next() {
+		if err := rows.Scan(&baseline); err != nil {
+			return "", err
+		}
+	}
+	return baseline.String, rows.Err()
+}
@@ -0,0 +1,208 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMigrator_PruneHistory(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	for _, id := range []string{"1", "2", "3"} {
+		migrator.Register(&mockMigration{id: id, upQueries: []string{"CREATE TABLE t" + id + " (id INTEGER)"}})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+
+	ctx := context.Background()
+	pruned, err := migrator.PruneHistory(ctx, time.Now().Add(-time.Hour))
+	if err != nil || pruned != 0 {
+		t.Fatalf("expected nothing older than an hour to be pruned, got %d (%v)", pruned, err)
+	}
+
+	pruned, err = migrator.PruneHistory(ctx, time.Now().Add(time.Hour), WithArchive())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pruned != 2 {
+		t.Errorf("expected 2 rows pruned while keeping the latest batch, got %d", pruned)
+	}
+	assertAppliedIDs(t, migrator, "3")
+
+	var archived int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations_archive").Scan(&archived); err != nil {
+		t.Fatalf("failed to count archive: %v", err)
+	}
+	if archived != 2 {
+		t.Errorf("expected 2 archived rows, got %d", archived)
+	}
+
+	fresh := New(db)
+	fresh.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE t1 (id INTEGER)"}},
+		&mockMigration{id: "2", upQueries: []string{"CREATE TABLE t2 (id INTEGER)"}},
+		&mockMigration{id: "3", upQueries: []string{"CREATE TABLE t3 (id INTEGER)"}},
+		&mockMigration{id: "4", upQueries: []string{"CREATE TABLE t4 (id INTEGER)"}},
+	)
+	if pending, err := fresh.PendingCount(ctx); err != nil || pending != 1 {
+		t.Errorf("expected pruned migrations not to become pending, got %d (%v)", pending, err)
+	}
+	if _, err := fresh.Up(); err != nil {
+		t.Fatalf("expected only new migration to apply, got %v", err)
+	}
+	assertAppliedIDs(t, fresh, "3", "4")
+}
+
+func TestMigrator_PruneHistory_KeepBatches(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	for _, id := range []string{"1", "2", "3"} {
+		migrator.Register(&mockMigration{id: id})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+
+	pruned, err := migrator.PruneHistory(context.Background(), time.Now().Add(time.Hour), KeepBatches(3))
+	if err != nil || pruned != 0 {
+		t.Errorf("expected all batches to be kept, got %d (%v)", pruned, err)
+	}
+}
+
+func TestMigrator_PruneHistory_TimestampOrdering(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithOrdering(OrderByTimestamp))
+	for _, id := range []string{"9", "10", "11"} {
+		migrator.Register(&mockMigration{id: id, upQueries: []string{"CREATE TABLE t" + id + " (id INTEGER)"}})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+
+	ctx := context.Background()
+	if pruned, err := migrator.PruneHistory(ctx, time.Now().Add(time.Hour)); err != nil || pruned != 2 {
+		t.Fatalf("expected 2 rows pruned, got %d (%v)", pruned, err)
+	}
+
+	fresh := New(db, WithOrdering(OrderByTimestamp))
+	fresh.Register(
+		&mockMigration{id: "9", upQueries: []string{"CREATE TABLE t9 (id INTEGER)"}},
+		&mockMigration{id: "10", upQueries: []string{"CREATE TABLE t10 (id INTEGER)"}},
+		&mockMigration{id: "11", upQueries: []string{"CREATE TABLE t11 (id INTEGER)"}},
+		CreateMigration("12", "depends on pruned").RawUp("CREATE TABLE t12 (id INTEGER)").DependsOn("9").Build(),
+	)
+	if pending, err := fresh.PendingCount(ctx); err != nil || pending != 1 {
+		t.Errorf("expected the baseline to cover 10 under timestamp ordering, got %d pending (%v)", pending, err)
+	}
+	if _, err := fresh.Up(); err != nil {
+		t.Fatalf("expected dependency below the baseline to be satisfied, got %v", err)
+	}
+	assertAppliedIDs(t, fresh, "11", "12")
+}
+
+func TestMigrator_PruneHistory_KeepsRolledBackRows(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	for _, id := range []string{"1", "2"} {
+		migrator.Register(&mockMigration{id: id})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back migration: %v", err)
+	}
+
+	fresh := New(db)
+	for _, id := range []string{"1", "3", "4"} {
+		fresh.Register(&mockMigration{id: id})
+		if _, err := fresh.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+
+	pruned, err := fresh.PruneHistory(context.Background(), time.Now().Add(time.Hour))
+	if err != nil || pruned != 2 {
+		t.Fatalf("expected 2 applied rows pruned, got %d (%v)", pruned, err)
+	}
+
+	var rolledBack int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE status = 'rolled_back'").Scan(&rolledBack); err != nil {
+		t.Fatalf("failed to count rolled back rows: %v", err)
+	}
+	if rolledBack != 1 {
+		t.Errorf("expected the rolled back row to survive pruning, got %d", rolledBack)
+	}
+}
+
+func TestMigrator_PruneHistory_RefusesUnappliedBelowBaseline(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	for _, id := range []string{"1", "3", "4"} {
+		migrator.Register(&mockMigration{id: id})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+	migrator.Register(&mockMigration{id: "2"})
+
+	pruned, err := migrator.PruneHistory(context.Background(), time.Now().Add(time.Hour))
+	if !errors.Is(err, ErrUnappliedBelowBaseline) || pruned != 0 {
+		t.Fatalf("expected ErrUnappliedBelowBaseline, got %d (%v)", pruned, err)
+	}
+	assertAppliedIDs(t, migrator, "1", "3", "4")
+}
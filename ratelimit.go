@@ -0,0 +1,54 @@
+package migrator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func NewRateLimiter(statementsPerSecond float64) Limiter {
+	return &intervalLimiter{interval: time.Duration(float64(time.Second) / statementsPerSecond)}
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	wait := l.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+		l.next = now
+	}
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *Migrator) waitForLimiter(ctx context.Context) error {
+	if r.limiter == nil {
+		return nil
+	}
+	return r.limiter.Wait(ctx)
+}
@@ -0,0 +1,79 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+type countingLimiter struct {
+	calls int
+	err   error
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.calls++
+	return l.err
+}
+
+func TestMigrator_WithLimiter(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	limiter := &countingLimiter{}
+	migrator := New(db, WithLimiter(limiter))
+	migrator.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1", "SELECT 2"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"SELECT 3"}},
+	)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if limiter.calls != 3 {
+		t.Errorf("expected limiter to be consulted before each of 3 statements, got %d", limiter.calls)
+	}
+
+	limiter.err = context.DeadlineExceeded
+	migrator.Register(&mockMigration{id: "3", description: "third", upQueries: []string{"SELECT 4"}})
+	if _, err := migrator.Up(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected limiter error to abort the migration, got %v", err)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(50)
+	ctx := context.Background()
+
+	started := time.Now()
+	for range 6 {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 100*time.Millisecond {
+		t.Errorf("expected 6 statements at 50/s to take at least 100ms, took %v", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	slow := NewRateLimiter(0.1)
+	if err := slow.Wait(ctx); err != nil {
+		t.Fatalf("expected first wait to pass immediately, got %v", err)
+	}
+	if err := slow.Wait(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package migrator
+
+import (
+	"context"
+)
+
+var readOnlyChecks = map[Dialect]string{
+	DialectPostgres: "SELECT pg_is_in_recovery() OR current_setting('transaction_read_only') = 'on'",
+	DialectMySQL:    "SELECT @@read_only",
+	DialectSQLite:   "PRAGMA query_only",
+}
+
+func (r *Migrator) checkWritable(ctx context.Context) error {
+	query, ok := readOnlyChecks[r.dialect]
+	if !ok {
+		return nil
+	}
+
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var readOnly bool
+	if rows.Next() {
+		if err := rows.Scan(&readOnly); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if readOnly {
+		return ErrReadOnlyDatabase
+	}
+	return nil
+}
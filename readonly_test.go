@@ -0,0 +1,40 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_ReadOnlyDatabase(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}, downQueries: []string{"DROP TABLE users"}})
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected writable database to migrate, got %v", err)
+	}
+
+	if _, err := db.Exec("PRAGMA query_only = ON"); err != nil {
+		t.Fatalf("failed to make database read-only: %v", err)
+	}
+
+	if _, err := migrator.Down(1); !errors.Is(err, ErrReadOnlyDatabase) {
+		t.Errorf("expected ErrReadOnlyDatabase, got %v", err)
+	}
+
+	migrator.Register(&mockMigration{id: "2", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}})
+	if _, err := migrator.Up(); !errors.Is(err, ErrReadOnlyDatabase) {
+		t.Errorf("expected ErrReadOnlyDatabase, got %v", err)
+	}
+}
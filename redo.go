@@ -0,0 +1,27 @@
+package migrator
+
+import (
+	"context"
+)
+
+func (r *Migrator) Redo(steps int, opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx := context.Background()
+
+	config := newRunConfig(opts)
+	config.only = make(map[string]bool)
+	rolledBack, err := r.runWithResult(ctx, DirectionDown, func(ctx context.Context, result *Result) error {
+		return r.down(ctx, steps, newRunConfig(opts), result)
+	})
+	if err != nil {
+		return rolledBack, err
+	}
+	for _, id := range rolledBack.IDs() {
+		config.only[id] = true
+	}
+
+	return r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		return r.up(ctx, config, result)
+	})
+}
@@ -0,0 +1,70 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMigrator_Redo(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id INTEGER)"}, downQueries: []string{"DROP TABLE a"}},
+		&mockMigration{id: "2", upQueries: []string{"CREATE TABLE b (id INTEGER)"}, downQueries: []string{"DROP TABLE b"}},
+	)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO b (id) VALUES (1)"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	migrator.Register(&mockMigration{id: "3", upQueries: []string{"CREATE TABLE c (id INTEGER)"}})
+
+	result, err := migrator.Redo(1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Direction != DirectionUp || !slices.Equal(result.IDs(), []string{"2"}) {
+		t.Errorf("expected redo result to list re-applied migration 2, got %+v", result)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(status) != 2 || status[1].ID != "2" || status[1].Batch != 2 {
+		t.Errorf("expected only migration 2 to be re-applied in a new batch, got %+v", status)
+	}
+	if countRows(t, db, "b") != 0 {
+		t.Error("expected table b to be recreated")
+	}
+}
+
+func TestMigrator_Redo_NothingApplied(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := New(db).Redo(1); !errors.Is(err, ErrNoMigrationsToRollback) {
+		t.Errorf("expected ErrNoMigrationsToRollback, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type MigrationInfo struct {
+	ID            string
+	Description   string
+	Checksum      string
+	Group         string
+	Tags          []string
+	DependsOn     []string
+	Repeatable    bool
+	Protected     bool
+	Transactional bool
+}
+
+func (r *Migrator) register(migrations []Migration) {
+	for _, migration := range migrations {
+		if migration == nil {
+			continue
+		}
+
+		index := slices.IndexFunc(r.migrations, func(registered Migration) bool {
+			return registered.ID() == migration.ID() && groupOf(registered) == groupOf(migration)
+		})
+		if index < 0 {
+			r.migrations = append(r.migrations, migration)
+			continue
+		}
+		if !r.sameMigration(r.migrations[index], migration) {
+			r.registryErr = errors.Join(r.registryErr, fmt.Errorf("%w: %s", ErrDuplicateMigrationID, migration.ID()))
+		}
+	}
+}
+
+func (r *Migrator) sameMigration(a, b Migration) bool {
+	if a == b {
+		return true
+	}
+
+	first, err := r.checksum(a)
+	if err != nil {
+		return false
+	}
+	second, err := r.checksum(b)
+	return err == nil && first == second
+}
+
+func (r *Migrator) Registered() []MigrationInfo {
+	r.stateMu.RLock()
+	defer r.stateMu.RUnlock()
+
+	migrations := slices.Clone(r.migrations)
+	if r.ordering != OrderByRegistration {
+		slices.SortStableFunc(migrations, func(a, b Migration) int {
+			return r.compareIDs(a.ID(), b.ID())
+		})
+	}
+
+	infos := make([]MigrationInfo, 0, len(migrations))
+	for _, migration := range migrations {
+		info := MigrationInfo{
+			ID:            migration.ID(),
+			Description:   migration.Description(),
+			Group:         groupOf(migration),
+			DependsOn:     slices.Clone(dependenciesOf(migration)),
+			Repeatable:    isRepeatable(migration),
+			Protected:     isProtected(migration),
+			Transactional: r.isTransactional(migration),
+		}
+		if checksum, err := r.checksum(migration); err == nil {
+			info.Checksum = checksum
+		}
+		if tagged, ok := migration.(Tagged); ok {
+			info.Tags = slices.Clone(tagged.Tags())
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_Registered(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	users := CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Tags("core").Build()
+	migrations := []Migration{
+		CreateMigration("002", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").DependsOn("001").Build(),
+		users,
+		nil,
+	}
+
+	migrator := New(db)
+	migrator.Register(migrations...)
+	migrator.Register(users, CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build())
+
+	if migrations[0].ID() != "002" || migrations[1].ID() != "001" {
+		t.Errorf("expected caller's slice to stay untouched, got %s, %s", migrations[0].ID(), migrations[1].ID())
+	}
+
+	registered := migrator.Registered()
+	if len(registered) != 2 || registered[0].ID != "001" || registered[1].ID != "002" {
+		t.Fatalf("unexpected registered migrations: %+v", registered)
+	}
+	if registered[0].Checksum != mustChecksum(t, users) || !registered[0].Transactional || len(registered[0].Tags) != 1 || registered[0].Tags[0] != "core" {
+		t.Errorf("unexpected info for 001: %+v", registered[0])
+	}
+	if len(registered[1].DependsOn) != 1 || registered[1].DependsOn[0] != "001" {
+		t.Errorf("unexpected info for 002: %+v", registered[1])
+	}
+
+	registered[0].Tags[0] = "changed"
+	if migrator.Registered()[0].Tags[0] != "core" {
+		t.Error("expected snapshot to be independent from the registry")
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	migrator.Register(CreateMigration("002", "add phone").RawUp("ALTER TABLE users ADD COLUMN phone TEXT").Build())
+	if _, err := migrator.Up(); !errors.Is(err, ErrDuplicateMigrationID) {
+		t.Errorf("expected ErrDuplicateMigrationID, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package migrator
+
+const repeatablePrefix = "R__"
+
+type Repeatable interface {
+	Repeatable() bool
+}
+
+func isRepeatable(migration Migration) bool {
+	repeatable, ok := migration.(Repeatable)
+	return ok && repeatable.Repeatable()
+}
+
+func (r *Migrator) repeatableIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, migration := range r.activeMigrations() {
+		if isRepeatable(migration) {
+			ids[migration.ID()] = true
+		}
+	}
+	return ids
+}
+
+func (r *Migrator) repeatableChanged(migration Migration, applied MigrationStatus) bool {
+	checksum, err := r.checksum(migration)
+	return err != nil || checksum != applied.Checksum
+}
+
+func (r *Migrator) excludeRepeatable(condition string, args []any) (string, []any) {
+	ids := r.repeatableIDs()
+	if len(ids) == 0 {
+		return condition, args
+	}
+
+	for id := range ids {
+		args = append(args, id)
+	}
+	return condition + " AND id NOT IN (" + placeholderList(len(ids)) + ")", args
+}
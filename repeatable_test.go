@@ -0,0 +1,97 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrator_RepeatableMigration(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	view := CreateMigration("R__active_users", "active users view").
+		RawUp("DROP VIEW IF EXISTS active_users").
+		RawUp("CREATE VIEW active_users AS SELECT id FROM users").
+		Repeatable().
+		Build()
+
+	migrator := New(db)
+	migrator.Register(view, CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, active INTEGER)").Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "R__active_users")
+	if pending, err := migrator.PendingCount(ctx); err != nil || pending != 0 {
+		t.Fatalf("expected unchanged repeatable migration to stay applied, got %d (%v)", pending, err)
+	}
+
+	view.(*baseMigration).upQueries[1] = "CREATE VIEW active_users AS SELECT id FROM users WHERE active = 1"
+	migrator.Register(CreateMigration("002", "add name").RawUp("ALTER TABLE users ADD COLUMN name TEXT").Build())
+
+	if err := migrator.Verify(ctx); err != nil {
+		t.Fatalf("expected changed repeatable migration to pass verification, got %v", err)
+	}
+	if pending, err := migrator.PendingCount(ctx); err != nil || pending != 2 {
+		t.Fatalf("expected changed repeatable migration to be pending, got %d (%v)", pending, err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to re-apply repeatable migration: %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "002", "R__active_users")
+
+	history, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	for _, status := range history {
+		if status.ID == "R__active_users" && (status.Batch != 2 || status.Checksum != mustChecksum(t, view)) {
+			t.Errorf("expected repeatable migration to be recorded in batch 2 with the new checksum, got %+v", status)
+		}
+	}
+
+	if _, err := db.Exec("INSERT INTO users (id, active) VALUES (1, 1), (2, 0)"); err != nil {
+		t.Fatalf("failed to insert users: %v", err)
+	}
+	if count := countRows(t, db, "active_users"); count != 1 {
+		t.Errorf("expected re-created view to filter inactive users, got %d rows", count)
+	}
+}
+
+func TestLoadFS_RepeatableMigration(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := LoadFS(fstest.MapFS{
+		"001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id INTEGER);")},
+		"R__active_users.up.sql":    {Data: []byte("CREATE VIEW active_users AS SELECT id FROM users;")},
+		"R__active_users.down.sql":  {Data: []byte("DROP VIEW active_users;")},
+		"002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD COLUMN email TEXT;")},
+		"002_add_email.down.sql":    {Data: []byte("ALTER TABLE users DROP COLUMN email;")},
+		"R__grants_readonly.up.sql": {Data: []byte("SELECT 1;")},
+	}, ".")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	repeatable := make(map[string]string)
+	for _, migration := range migrations {
+		if isRepeatable(migration) {
+			repeatable[migration.ID()] = migration.Description()
+		}
+	}
+	if len(repeatable) != 2 || repeatable["R__active_users"] != "active users" || repeatable["R__grants_readonly"] != "grants readonly" {
+		t.Errorf("unexpected repeatable migrations: %v", repeatable)
+	}
+}
@@ -0,0 +1,209 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (r *Migrator) Reset(opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reset(context.Background(), newRunConfig(opts))
+}
+
+func (r *Migrator) Refresh(opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx := context.Background()
+	config := newRunConfig(opts)
+
+	if result, err := r.reset(ctx, config); err != nil {
+		return result, err
+	}
+	return r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		return r.up(ctx, config, result)
+	})
+}
+
+func (r *Migrator) Fresh(opts ...RunOption) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx := context.Background()
+	config := newRunConfig(opts)
+
+	return r.runWithResult(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		tables, err := r.ownedTables(ctx)
+		if err != nil {
+			return err
+		}
+
+		build := func() (*Plan, error) {
+			return r.buildDropPlan(tables), nil
+		}
+		if err := r.guardEnvironment(GuardDown, &config, build); err != nil {
+			return err
+		}
+		if err := r.guardDestructive(&config, build); err != nil {
+			return err
+		}
+		if err := r.backupDestructive(ctx, build); err != nil {
+			return err
+		}
+		if err := r.dropTables(ctx, tables); err != nil {
+			return err
+		}
+		return r.up(ctx, config, result)
+	})
+}
+
+func (r *Migrator) ownedTables(ctx context.Context) ([]string, error) {
+	if r.namespace != "" && r.tablePrefix == "" {
+		return nil, fmt.Errorf("%w: namespace %q has no table prefix", ErrSharedHistoryTable, r.namespace)
+	}
+
+	modules, err := r.historyModules(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToDropTables, err)
+	}
+	for _, module := range modules {
+		if module != r.namespace && !strings.HasPrefix(module, r.namespace+groupSeparator) {
+			return nil, fmt.Errorf("%w: namespace %q", ErrSharedHistoryTable, module)
+		}
+	}
+
+	tables, err := r.listTables(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToDropTables, err)
+	}
+	return slices.DeleteFunc(tables, func(table string) bool {
+		return table == r.table+"_lock"
+	}), nil
+}
+
+func (r *Migrator) historyModules(ctx context.Context) ([]string, error) {
+	if err := r.createMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.Query(ctx, r.query("SELECT DISTINCT module FROM %s"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var modules []string
+	for rows.Next() {
+		var module string
+		if err := rows.Scan(&module); err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	return modules, rows.Err()
+}
+
+func (r *Migrator) reset(ctx context.Context, config runConfig) (*Result, error) {
+	result, err := r.runWithResult(ctx, DirectionDown, func(ctx context.Context, result *Result) error {
+		return r.down(ctx, 0, config, result)
+	})
+	if errors.Is(err, ErrNoMigrationsToRollback) {
+		return result, nil
+	}
+	return result, err
+}
+
+func (r *Migrator) buildDropPlan(tables []string) *Plan {
+	statements := make([]string, len(tables))
+	for i, table := range tables {
+		statements[i] = r.dropTableSQL(table)
+	}
+	return &Plan{
+		Direction: DirectionDown,
+		Migrations: []PlannedMigration{{
+			ID:            "fresh",
+			Description:   "drop all tables",
+			Statements:    statements,
+			Transactional: true,
+			Destructive:   true,
+		}},
+	}
+}
+
+func (r *Migrator) dropTableSQL(table string) string {
+	query := "DROP TABLE IF EXISTS " + r.dialect.quoteIdent(table)
+	if r.dialect == DialectPostgres {
+		query += " CASCADE"
+	}
+	return query
+}
+
+func (r *Migrator) dropTables(ctx context.Context, tables []string) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToBeginTransaction, err)
+	}
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if r.dialect == DialectMySQL {
+		if _, err := tx.Exec(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return errors.Join(ErrFailedToDropTables, err)
+		}
+	}
+
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, r.dropTableSQL(table)); err != nil {
+			return errors.Join(ErrFailedToDropTables, err)
+		}
+	}
+
+	if r.dialect == DialectMySQL {
+		if _, err := tx.Exec(ctx, "SET FOREIGN_KEY_CHECKS = 1"); err != nil {
+			return errors.Join(ErrFailedToDropTables, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Join(ErrFailedToDropTables, err)
+	}
+	tx = nil
+
+	r.setBaseline("")
+	return nil
+}
+
+func (r *Migrator) listTables(ctx context.Context) ([]string, error) {
+	query := introspection[r.dialect].tables
+	if query == "" {
+		return nil, ErrUnsupportedDialect
+	}
+
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(table, r.tablePrefix) {
+			tables = append(tables, table)
+		}
+	}
+	return tables, rows.Err()
+}
@@ -0,0 +1,195 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func newResetTestMigrator(t *testing.T, opts ...Option) (*sql.DB, *Migrator) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, append([]Option{WithDialect(DialectSQLite)}, opts...)...)
+	migrator.Register(
+		CreateMigration("1", "create users").CreateTable("users", "id INTEGER PRIMARY KEY").Build(),
+		CreateMigration("2", "create posts").CreateTable("posts", "id INTEGER PRIMARY KEY").Build(),
+	)
+	return db, migrator
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+	return count
+}
+
+func TestMigrator_ResetAndRefresh(t *testing.T) {
+	t.Parallel()
+
+	db, migrator := newResetTestMigrator(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := migrator.Reset(); err != nil {
+		t.Fatalf("expected reset of an empty history to succeed, got %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO users (id) VALUES (1)"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	if _, err := migrator.Refresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1", "2")
+	if countRows(t, db, "users") != 0 {
+		t.Error("expected refresh to recreate tables")
+	}
+
+	if _, err := migrator.Reset(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+	if ok, err := migrator.HasTable(context.Background(), "users"); err != nil || ok {
+		t.Errorf("expected users table to be rolled back, got %v (%v)", ok, err)
+	}
+}
+
+func TestMigrator_Fresh(t *testing.T) {
+	t.Parallel()
+
+	db, migrator := newResetTestMigrator(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, legacy TEXT)"); err != nil {
+		t.Fatalf("failed to create stray table: %v", err)
+	}
+
+	if _, err := migrator.Fresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1", "2")
+	if ok, err := migrator.HasColumn(context.Background(), "users", "legacy"); err != nil || ok {
+		t.Errorf("expected stray table to be dropped, got %v (%v)", ok, err)
+	}
+}
+
+func TestMigrator_Fresh_TablePrefix(t *testing.T) {
+	t.Parallel()
+
+	db, migrator := newResetTestMigrator(t, WithTablePrefix("app_"))
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.Exec("CREATE TABLE other_app (id INTEGER)"); err != nil {
+		t.Fatalf("failed to create foreign table: %v", err)
+	}
+
+	if _, err := migrator.Fresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, err := migrator.HasTable(context.Background(), "other_app"); err != nil || !ok {
+		t.Errorf("expected tables without the prefix to survive, got %v (%v)", ok, err)
+	}
+	if ok, err := migrator.HasTable(context.Background(), "app_users"); err != nil || !ok {
+		t.Errorf("expected prefixed tables to be recreated, got %v (%v)", ok, err)
+	}
+}
+
+func TestMigrator_Fresh_SharedHistoryTable(t *testing.T) {
+	t.Parallel()
+
+	db, migrator := newResetTestMigrator(t)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	billing := New(db, WithDialect(DialectSQLite), WithNamespace("billing"))
+	billing.Register(CreateMigration("1", "create invoices").CreateTable("invoices", "id INTEGER PRIMARY KEY").Build())
+	if _, err := billing.Up(); err != nil {
+		t.Fatalf("failed to apply billing migrations: %v", err)
+	}
+
+	if _, err := migrator.Fresh(); !errors.Is(err, ErrSharedHistoryTable) {
+		t.Fatalf("expected ErrSharedHistoryTable, got %v", err)
+	}
+	if _, err := billing.Fresh(); !errors.Is(err, ErrSharedHistoryTable) {
+		t.Fatalf("expected namespace without a prefix to be refused, got %v", err)
+	}
+	if ok, err := migrator.HasTable(context.Background(), "invoices"); err != nil || !ok {
+		t.Errorf("expected other namespace tables to survive, got %v (%v)", ok, err)
+	}
+}
+
+func TestMigrator_Fresh_BackupAndConfirm(t *testing.T) {
+	t.Parallel()
+
+	var backups []*Plan
+	db, migrator := newResetTestMigrator(t,
+		WithBackupHook(func(_ context.Context, plan *Plan) error {
+			backups = append(backups, plan)
+			return nil
+		}),
+		WithConfirm(func(Plan) (bool, error) {
+			return false, nil
+		}),
+	)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Fresh(); !errors.Is(err, ErrNotConfirmed) {
+		t.Fatalf("expected refused confirmation to stop Fresh, got %v", err)
+	}
+	if len(backups) != 0 {
+		t.Fatalf("expected no backup for a refused Fresh, got %d", len(backups))
+	}
+	assertAppliedIDs(t, migrator, "1", "2")
+
+	approving := New(db, WithDialect(DialectSQLite), WithBackupHook(func(_ context.Context, plan *Plan) error {
+		backups = append(backups, plan)
+		return nil
+	}))
+	if _, err := approving.Fresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(backups) != 1 || !backups[0].Destructive() || backups[0].StatementCount() == 0 {
+		t.Errorf("expected Fresh to back up before dropping, got %+v", backups)
+	}
+}
+
+func TestMigrator_Fresh_UnsupportedDialect(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := New(db).Fresh(); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+type MigrationResult struct {
+	ID          string
+	Description string
+	Duration    time.Duration
+	Statements  []StatementResult
+}
+
+type MigrationFailure struct {
+	ID  string
+	Err error
+}
+
+type Result struct {
+	Direction    Direction
+	Batch        int
+	Migrations   []MigrationResult
+	Failures     []MigrationFailure
+	FailedID     string
+	Skipped      []string
+	Irreversible []string
+	Interrupted  *Intent
+	Duration     time.Duration
+}
+
+func (r *Result) IDs() []string {
+	ids := make([]string, len(r.Migrations))
+	for i, migration := range r.Migrations {
+		ids[i] = migration.ID
+	}
+	return ids
+}
+
+func (r *Result) String() string {
+	verb := "applied"
+	if r.Direction == DirectionDown {
+		verb = "rolled back"
+	}
+	noun := "migrations"
+	if len(r.Migrations) == 1 {
+		noun = "migration"
+	}
+	return fmt.Sprintf("%s %d %s in batch %d (%s)", verb, len(r.Migrations), noun, r.Batch, r.Duration.Round(time.Millisecond))
+}
+
+func (r *Result) partialError() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+
+	errs := []error{ErrPartialFailure}
+	for _, failure := range r.Failures {
+		errs = append(errs, fmt.Errorf("%s: %w", failure.ID, failure.Err))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Migrator) irreversibleIDs(result *Result) []string {
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
+
+	var ids []string
+	for _, executed := range result.Migrations {
+		if migration, ok := migrationMap[executed.ID]; !ok || !isReversible(migration) {
+			ids = append(ids, executed.ID)
+		}
+	}
+	return ids
+}
+
+func newMigrationResult(id, description string, started time.Time) MigrationResult {
+	return MigrationResult{
+		ID:          id,
+		Description: description,
+		Duration:    time.Since(started),
+	}
+}
+
+func (r *Migrator) run(ctx context.Context, direction Direction, fn func(context.Context, *Result) error) error {
+	_, err := r.runWithResult(ctx, direction, fn)
+	return err
+}
+
+func (r *Migrator) runWithResult(ctx context.Context, direction Direction, fn func(context.Context, *Result) error) (*Result, error) {
+	started := time.Now()
+	result := &Result{Direction: direction}
+
+	if r.registryErr != nil {
+		return result, r.registryErr
+	}
+
+	lock, err := r.acquireLock(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	r.statements = make(map[string][]StatementResult)
+	err = fn(ctx, result)
+	result.Duration = time.Since(started)
+	r.attachStatements(result)
+	r.statements = nil
+	result.Irreversible = r.irreversibleIDs(result)
+
+	if releaseErr := r.releaseLock(ctx, lock); releaseErr != nil {
+		err = errors.Join(err, releaseErr)
+	}
+
+	if err == nil {
+		err = r.broadcastVersion(ctx, result)
+	}
+
+	if r.auditLog {
+		if auditErr := r.writeAuditLog(ctx, result, err); auditErr != nil {
+			err = errors.Join(err, auditErr)
+		}
+	}
+
+	r.notify(ctx, result, err)
+	return result, err
+}
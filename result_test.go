@@ -0,0 +1,53 @@
+package migrator
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_UpDownResult(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithSkip("003"))
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build(),
+		CreateMigration("002", "seed users").RawUp("INSERT INTO users (id) VALUES (1)").Build(),
+		CreateMigration("003", "broken").RawUp("NOT SQL").Build(),
+	)
+
+	result, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if result.Direction != DirectionUp || result.Batch != 1 || !slices.Equal(result.IDs(), []string{"001", "002"}) {
+		t.Errorf("unexpected up result: %+v", result)
+	}
+	if !slices.Equal(result.Skipped, []string{"003"}) || !slices.Equal(result.Irreversible, []string{"002"}) {
+		t.Errorf("expected skipped 003 and irreversible 002, got %v and %v", result.Skipped, result.Irreversible)
+	}
+	if summary := result.String(); !strings.HasPrefix(summary, "applied 2 migrations in batch 1") {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+
+	result, err = migrator.Down(1)
+	if err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if result.Direction != DirectionDown || !slices.Equal(result.IDs(), []string{"002"}) {
+		t.Errorf("unexpected down result: %+v", result)
+	}
+	if summary := result.String(); !strings.HasPrefix(summary, "rolled back 1 migration in batch 1") {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+}
@@ -0,0 +1,300 @@
+package migrator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const irreversiblePrefix = "-- Cannot reverse: "
+
+func GenerateDown(dialect Dialect, up ...string) []string {
+	down := make([]string, 0, len(up))
+	for i := len(up) - 1; i >= 0; i-- {
+		for _, statement := range slices.Backward(SplitStatements(up[i])) {
+			if reversed, ok := ReverseStatement(dialect, statement); ok {
+				down = append(down, reversed)
+			} else {
+				down = append(down, irreversiblePrefix+firstLine(strings.TrimSpace(statement)))
+			}
+		}
+	}
+	return down
+}
+
+func ReverseStatement(dialect Dialect, statement string) (string, bool) {
+	p := &sqlParser{tokens: tokenizeSQL(statement), dialect: dialect}
+	switch {
+	case p.accept("CREATE"):
+		return p.reverseCreate()
+	case p.accept("ALTER", "TABLE"):
+		return p.reverseAlterTable()
+	default:
+		return "", false
+	}
+}
+
+type sqlParser struct {
+	tokens  []string
+	pos     int
+	dialect Dialect
+}
+
+func (p *sqlParser) peek(words ...string) bool {
+	if p.pos+len(words) > len(p.tokens) {
+		return false
+	}
+	for i, word := range words {
+		if !strings.EqualFold(p.tokens[p.pos+i], word) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *sqlParser) accept(words ...string) bool {
+	if !p.peek(words...) {
+		return false
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *sqlParser) name() (string, bool) {
+	if p.pos >= len(p.tokens) || !isSQLName(p.tokens[p.pos]) {
+		return "", false
+	}
+	p.pos++
+	return p.tokens[p.pos-1], true
+}
+
+func (p *sqlParser) reverseCreate() (string, bool) {
+	if p.accept("OR", "REPLACE") {
+		return "", false
+	}
+	unique := p.accept("UNIQUE")
+
+	switch {
+	case !unique && p.accept("TABLE"):
+		return p.dropObject("TABLE")
+	case !unique && (p.accept("TEMP", "TABLE") || p.accept("TEMPORARY", "TABLE")):
+		return p.dropObject("TABLE")
+	case !unique && p.accept("VIEW"):
+		return p.dropObject("VIEW")
+	case !unique && p.accept("MATERIALIZED", "VIEW"):
+		return p.dropObject("MATERIALIZED VIEW")
+	case !unique && p.accept("SCHEMA"):
+		return p.dropObject("SCHEMA")
+	case !unique && p.accept("SEQUENCE"):
+		return p.dropObject("SEQUENCE")
+	case p.accept("INDEX"):
+		return p.reverseCreateIndex()
+	default:
+		return "", false
+	}
+}
+
+func (p *sqlParser) dropObject(kind string) (string, bool) {
+	p.accept("IF", "NOT", "EXISTS")
+	name, ok := p.name()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("DROP %s IF EXISTS %s;", kind, name), true
+}
+
+func (p *sqlParser) reverseCreateIndex() (string, bool) {
+	concurrently := p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+	if p.peek("ON") {
+		return "", false
+	}
+	index, ok := p.name()
+	if !ok || !p.accept("ON") {
+		return "", false
+	}
+	p.accept("ONLY")
+	table, ok := p.name()
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case p.dialect == DialectMySQL:
+		return fmt.Sprintf("DROP INDEX %s ON %s;", index, table), true
+	case concurrently:
+		return fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s;", index), true
+	default:
+		return fmt.Sprintf("DROP INDEX IF EXISTS %s;", index), true
+	}
+}
+
+func (p *sqlParser) reverseAlterTable() (string, bool) {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	table, ok := p.name()
+	if !ok || p.pos >= len(p.tokens) {
+		return "", false
+	}
+
+	if p.accept("RENAME", "TO") {
+		renamed, ok := p.name()
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", renamed, table), true
+	}
+
+	var actions []string
+	for _, action := range splitTokens(p.tokens[p.pos:], ",") {
+		reversed, ok := (&sqlParser{tokens: action, dialect: p.dialect}).reverseAlterAction()
+		if !ok {
+			return "", false
+		}
+		actions = append([]string{reversed}, actions...)
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s;", table, strings.Join(actions, ", ")), true
+}
+
+func (p *sqlParser) reverseAlterAction() (string, bool) {
+	switch {
+	case p.accept("RENAME", "COLUMN"):
+		from, ok := p.name()
+		if !ok || !p.accept("TO") {
+			return "", false
+		}
+		to, ok := p.name()
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("RENAME COLUMN %s TO %s", to, from), true
+	case p.accept("ADD", "CONSTRAINT"):
+		name, ok := p.name()
+		if !ok {
+			return "", false
+		}
+		return p.dropConstraint(name), true
+	case p.accept("ADD", "PRIMARY", "KEY"):
+		if p.dialect != DialectMySQL {
+			return "", false
+		}
+		return "DROP PRIMARY KEY", true
+	case p.peek("ADD", "UNIQUE"), p.peek("ADD", "FOREIGN"), p.peek("ADD", "CHECK"), p.peek("ADD", "INDEX"), p.peek("ADD", "KEY"):
+		return "", false
+	case p.accept("ADD"):
+		p.accept("COLUMN")
+		p.accept("IF", "NOT", "EXISTS")
+		column, ok := p.name()
+		if !ok {
+			return "", false
+		}
+		return "DROP COLUMN " + column, true
+	default:
+		return "", false
+	}
+}
+
+func (p *sqlParser) dropConstraint(name string) string {
+	if p.dialect != DialectMySQL {
+		return "DROP CONSTRAINT " + name
+	}
+
+	switch {
+	case p.peek("FOREIGN", "KEY"):
+		return "DROP FOREIGN KEY " + name
+	case p.peek("PRIMARY", "KEY"):
+		return "DROP PRIMARY KEY"
+	case p.peek("UNIQUE"):
+		return "DROP INDEX " + name
+	case p.peek("CHECK"):
+		return "DROP CHECK " + name
+	default:
+		return "DROP CONSTRAINT " + name
+	}
+}
+
+func tokenizeSQL(statement string) []string {
+	var tokens []string
+	runes := []rune(statement)
+
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case unicode.IsSpace(ch) || ch == ';':
+			i++
+		case ch == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case ch == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			for i += 2; i < len(runes) && !(runes[i-1] == '*' && runes[i] == '/'); i++ {
+			}
+			i++
+		case ch == '(':
+			start, depth := i, 0
+			for ; i < len(runes); i++ {
+				if runes[i] == '(' {
+					depth++
+				} else if runes[i] == ')' {
+					if depth--; depth == 0 {
+						i++
+						break
+					}
+				}
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case ch == '\'':
+			start := i
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					i++
+					break
+				}
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case isNameRune(ch) || ch == '"' || ch == '`':
+			start := i
+			for i < len(runes) && (isNameRune(runes[i]) || runes[i] == '"' || runes[i] == '`' || runes[i] == '.') {
+				if quote := runes[i]; quote == '"' || quote == '`' {
+					for i++; i < len(runes) && runes[i] != quote; i++ {
+					}
+				}
+				i++
+			}
+			tokens = append(tokens, string(runes[start:min(i, len(runes))]))
+		default:
+			tokens = append(tokens, string(ch))
+			i++
+		}
+	}
+	return tokens
+}
+
+func splitTokens(tokens []string, separator string) [][]string {
+	var parts [][]string
+	start := 0
+	for i, token := range tokens {
+		if token == separator {
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, tokens[start:])
+}
+
+func isNameRune(ch rune) bool {
+	return ch == '_' || ch == '$' || unicode.IsLetter(ch) || unicode.IsDigit(ch)
+}
+
+func isSQLName(token string) bool {
+	if token == "" {
+		return false
+	}
+	first := []rune(token)[0]
+	return isNameRune(first) || first == '"' || first == '`'
+}
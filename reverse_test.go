@@ -0,0 +1,104 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestReverseStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect   Dialect
+		statement string
+		expected  string
+	}{
+		{DialectGeneric, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT DEFAULT 'a, b')", "DROP TABLE IF EXISTS users;"},
+		{DialectGeneric, "create table if not exists \"app\".\"users\" (id int);", `DROP TABLE IF EXISTS "app"."users";`},
+		{DialectGeneric, "-- users by email\nCREATE UNIQUE INDEX idx_users_email ON users (email)", "DROP INDEX IF EXISTS idx_users_email;"},
+		{DialectPostgres, "CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_a ON ONLY users (a)", "DROP INDEX CONCURRENTLY IF EXISTS idx_a;"},
+		{DialectMySQL, "CREATE INDEX idx_a ON users (a)", "DROP INDEX idx_a ON users;"},
+		{DialectGeneric, "ALTER TABLE users ADD COLUMN email TEXT NOT NULL DEFAULT ''", "ALTER TABLE users DROP COLUMN email;"},
+		{DialectGeneric, "ALTER TABLE users ADD a INT, ADD COLUMN IF NOT EXISTS b INT", "ALTER TABLE users DROP COLUMN b, DROP COLUMN a;"},
+		{DialectGeneric, "ALTER TABLE users ADD CONSTRAINT fk_org FOREIGN KEY (org_id) REFERENCES orgs (id)", "ALTER TABLE users DROP CONSTRAINT fk_org;"},
+		{DialectMySQL, "ALTER TABLE users ADD CONSTRAINT fk_org FOREIGN KEY (org_id) REFERENCES orgs (id)", "ALTER TABLE users DROP FOREIGN KEY fk_org;"},
+		{DialectGeneric, "ALTER TABLE users RENAME COLUMN name TO full_name", "ALTER TABLE users RENAME COLUMN full_name TO name;"},
+		{DialectGeneric, "ALTER TABLE users RENAME TO accounts", "ALTER TABLE accounts RENAME TO users;"},
+		{DialectGeneric, "CREATE VIEW active_users AS SELECT * FROM users", "DROP VIEW IF EXISTS active_users;"},
+	}
+	for _, tt := range tests {
+		got, ok := ReverseStatement(tt.dialect, tt.statement)
+		if !ok || got != tt.expected {
+			t.Errorf("ReverseStatement(%q) = %q, %v, want %q", tt.statement, got, ok, tt.expected)
+		}
+	}
+
+	for _, statement := range []string{
+		"DROP TABLE users",
+		"INSERT INTO users (id) VALUES (1)",
+		"CREATE OR REPLACE VIEW v AS SELECT 1",
+		"CREATE INDEX ON users (a)",
+		"ALTER TABLE users ADD COLUMN a INT, ALTER COLUMN b TYPE TEXT",
+		"ALTER TABLE users ADD UNIQUE (email)",
+	} {
+		if got, ok := ReverseStatement(DialectPostgres, statement); ok {
+			t.Errorf("expected %q to be irreversible, got %q", statement, got)
+		}
+	}
+}
+
+func TestGenerateDown(t *testing.T) {
+	t.Parallel()
+
+	down := GenerateDown(DialectGeneric,
+		"CREATE TABLE users (id INTEGER); CREATE INDEX idx_users_id ON users (id);",
+		"UPDATE users SET id = id + 1",
+	)
+	expected := []string{
+		"-- Cannot reverse: UPDATE users SET id = id + 1",
+		"DROP INDEX IF EXISTS idx_users_id;",
+		"DROP TABLE IF EXISTS users;",
+	}
+	if len(down) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, down)
+	}
+	for i := range expected {
+		if down[i] != expected[i] {
+			t.Errorf("statement %d: expected %q, got %q", i, expected[i], down[i])
+		}
+	}
+}
+
+func TestMigrationBuilder_RawReversible(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(CreateMigration("1", "users").
+		RawReversible("CREATE TABLE users (id INTEGER)").
+		RawReversible("ALTER TABLE users ADD COLUMN email TEXT").
+		Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'users'").Scan(&count); err != nil {
+		t.Fatalf("failed to query schema: %v", err)
+	}
+	if count != 0 {
+		t.Error("expected generated down to drop the table")
+	}
+}
@@ -0,0 +1,85 @@
+package migrator
+
+import (
+	"strings"
+	"time"
+)
+
+var dmlKeywords = []string{"INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE"}
+
+type StatementResult struct {
+	Statement    string
+	RowsAffected int64
+	Duration     time.Duration
+}
+
+func (m MigrationResult) RowsAffected() int64 {
+	total := int64(-1)
+	for _, statement := range m.Statements {
+		if statement.RowsAffected < 0 {
+			continue
+		}
+		total = max(total, 0) + statement.RowsAffected
+	}
+	return total
+}
+
+func rowsAffected(query string, res ExecResult) int64 {
+	if res == nil || !isDML(query) {
+		return -1
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return affected
+}
+
+func isDML(query string) bool {
+	fields := strings.Fields(strings.ToUpper(stripLeadingComments(query)))
+	if len(fields) == 0 {
+		return false
+	}
+	for _, keyword := range dmlKeywords {
+		if fields[0] == keyword || strings.HasPrefix(fields[0], keyword+"(") {
+			return true
+		}
+	}
+	return false
+}
+
+func stripLeadingComments(query string) string {
+	for {
+		query = strings.TrimSpace(query)
+		switch {
+		case strings.HasPrefix(query, "--"):
+			_, rest, _ := strings.Cut(query, "\n")
+			query = rest
+		case strings.HasPrefix(query, "/*"):
+			_, rest, ok := strings.Cut(query, "*/")
+			if !ok {
+				return ""
+			}
+			query = rest
+		default:
+			return query
+		}
+	}
+}
+
+func (r *Migrator) recordStatement(migrationID string, event Event) {
+	if r.statements == nil || event.Err != nil {
+		return
+	}
+	r.statements[migrationID] = append(r.statements[migrationID], StatementResult{
+		Statement:    event.Statement,
+		RowsAffected: event.RowsAffected,
+		Duration:     event.Duration,
+	})
+}
+
+func (r *Migrator) attachStatements(result *Result) {
+	for i := range result.Migrations {
+		result.Migrations[i].Statements = r.statements[result.Migrations[i].ID]
+	}
+}
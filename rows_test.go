@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_RowsAffected(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	events := migrator.Events()
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER, active INTEGER)").Build(),
+		CreateMigration("002", "seed users").RawUp("INSERT INTO users (id, active) VALUES (1, 0), (2, 0)").Build(),
+		CreateMigration("003", "backfill").
+			RawUp("/* backfill */ UPDATE users SET active = 1 WHERE id > 0").
+			RawUp("UPDATE users SET active = 2 WHERE id > 100").
+			Build(),
+	)
+
+	result, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if len(result.Migrations) != 3 {
+		t.Fatalf("expected three migrations, got %+v", result.Migrations)
+	}
+
+	if statements := result.Migrations[0].Statements; len(statements) != 1 || statements[0].RowsAffected != -1 {
+		t.Errorf("expected DDL to report no row count, got %+v", statements)
+	}
+	if affected := result.Migrations[1].RowsAffected(); affected != 2 {
+		t.Errorf("expected seed to insert 2 rows, got %d", affected)
+	}
+	backfill := result.Migrations[2].Statements
+	if len(backfill) != 2 || backfill[0].RowsAffected != 2 || backfill[1].RowsAffected != 0 {
+		t.Errorf("unexpected backfill statements: %+v", backfill)
+	}
+
+	counts := make(map[string]int64)
+	for len(events) > 0 {
+		event := <-events
+		if event.Kind == EventStatementExecuted && event.MigrationID == "002" {
+			counts[event.MigrationID] = event.RowsAffected
+		}
+	}
+	if counts["002"] != 2 {
+		t.Errorf("expected statement event to carry the row count, got %v", counts)
+	}
+}
@@ -0,0 +1,52 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+)
+
+func (r *Migrator) RunOnce(ctx context.Context, opts ...RunOption) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.lockLease <= 0 {
+		return false, ErrLockNotConfigured
+	}
+
+	config := newRunConfig(opts)
+	pending, err := r.hasPendingUp(ctx, config)
+	if err != nil || !pending {
+		return false, err
+	}
+
+	provided, err := r.upProviders(ctx, config)
+	if err != nil {
+		return len(provided.Migrations) > 0, err
+	}
+
+	leader := len(provided.Migrations) > 0
+	err = r.run(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		err := r.up(ctx, config, result)
+		leader = leader || len(result.Migrations) > 0
+		return err
+	})
+	return leader, err
+}
+
+func (r *Migrator) hasPendingUp(ctx context.Context, config runConfig) (bool, error) {
+	for _, group := range append([]string{""}, r.providers...) {
+		var pending []Migration
+		err := r.withGroup(group, func() error {
+			applied, err := r.getAppliedMigrations(ctx)
+			if err != nil {
+				return errors.Join(ErrFailedToGetAppliedMigrations, err)
+			}
+			pending = config.filter(r.pendingMigrations(applied))
+			return nil
+		})
+		if err != nil || len(pending) > 0 {
+			return len(pending) > 0, err
+		}
+	}
+	return false, nil
+}
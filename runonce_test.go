@@ -0,0 +1,113 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMigrator_RunOnce(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	replicas := make([]*Migrator, 5)
+	for i := range replicas {
+		replicas[i] = New(db, WithLockTable(fmt.Sprintf("replica-%d", i), time.Minute))
+		replicas[i].Register(
+			&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+			&mockMigration{id: "2", upQueries: []string{"INSERT INTO users (id) VALUES (1)"}},
+		)
+	}
+
+	var wg sync.WaitGroup
+	leaders := make([]bool, len(replicas))
+	errs := make([]error, len(replicas))
+	for i, replica := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			leaders[i], errs[i] = replica.RunOnce(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	for i := range replicas {
+		if errs[i] != nil {
+			t.Errorf("replica %d: expected no error, got %v", i, errs[i])
+		}
+		if leaders[i] {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected exactly one leader, got %d", count)
+	}
+	if n := countRows(t, db, "users"); n != 1 {
+		t.Errorf("expected migrations to run once, got %d rows", n)
+	}
+	assertAppliedIDs(t, replicas[0], "1", "2")
+}
+
+func TestMigrator_RunOnceTimesOut(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("replica-2", time.Minute))
+	migrator.Register(&mockMigration{id: "1", upQueries: []string{"SELECT 1"}})
+
+	if _, err := db.Exec(lockTableSQL()); err != nil {
+		t.Fatalf("failed to create lock table: %v", err)
+	}
+	expires := time.Now().Add(time.Hour).UnixMilli()
+	if _, err := db.Exec("INSERT INTO schema_migrations_lock VALUES ('', 'replica-1', 0, ?)", expires); err != nil {
+		t.Fatalf("failed to insert lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	leader, err := migrator.RunOnce(ctx)
+	if !errors.Is(err, ErrFailedToAcquireLock) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected lock timeout, got %v", err)
+	}
+	if leader {
+		t.Error("expected replica not to lead")
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+func TestMigrator_RunOnceRequiresLockTable(t *testing.T) {
+	t.Parallel()
+
+	migrator := New(newLockTestDB(t))
+	if _, err := migrator.RunOnce(context.Background()); !errors.Is(err, ErrLockNotConfigured) {
+		t.Fatalf("expected ErrLockNotConfigured, got %v", err)
+	}
+}
+
+func TestMigrator_RunOnceAppliesProviders(t *testing.T) {
+	t.Parallel()
+
+	db := newLockTestDB(t)
+	migrator := New(db, WithLockTable("replica-providers", time.Minute))
+	migrator.Use(auditProvider{})
+
+	leader, err := migrator.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !leader {
+		t.Error("expected provider migrations to make this replica the leader")
+	}
+	if n := countRows(t, db, "audit_log"); n != 0 {
+		t.Errorf("expected empty audit_log table, got %d rows", n)
+	}
+
+	if leader, err := migrator.RunOnce(context.Background()); err != nil || leader {
+		t.Errorf("expected nothing left to run, got leader=%v (%v)", leader, err)
+	}
+}
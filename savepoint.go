@@ -0,0 +1,27 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+)
+
+func (r *Migrator) withSavepoint(ctx context.Context, tx Tx, migrationID string, fn func() error) (*MigrationFailure, error) {
+	const name = "migrator_migration"
+
+	if _, err := tx.Exec(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+
+	if migrationErr := fn(); migrationErr != nil {
+		if _, err := tx.Exec(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+			return nil, errors.Join(migrationErr, err)
+		}
+		if _, err := tx.Exec(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+			return nil, err
+		}
+		return &MigrationFailure{ID: migrationID, Err: migrationErr}, nil
+	}
+
+	_, err := tx.Exec(ctx, "RELEASE SAVEPOINT "+name)
+	return nil, err
+}
@@ -0,0 +1,81 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_WithContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	var reported *Result
+	migrator := New(db,
+		WithDialect(DialectSQLite),
+		WithContinueOnError(),
+		WithNotifier(NotifierFunc(func(_ context.Context, result *Result, _ error) error {
+			reported = result
+			return nil
+		})),
+	)
+	migrator.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "broken", upQueries: []string{"CREATE TABLE b (id INTEGER)", "INVALID SQL STATEMENT"}},
+		&mockMigration{id: "3", description: "third", upQueries: []string{"CREATE TABLE c (id INTEGER)"}},
+	)
+
+	_, err = migrator.Up()
+	if !errors.Is(err, ErrPartialFailure) {
+		t.Fatalf("expected ErrPartialFailure, got %v", err)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(status) != 2 || status[0].ID != "1" || status[1].ID != "3" {
+		t.Errorf("expected migrations 1 and 3 to be applied, got %+v", status)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='b'").Scan(&count)
+	if err != nil {
+		t.Fatalf("failed to check table existence: %v", err)
+	}
+	if count != 0 {
+		t.Error("expected the failed migration to be rolled back to its savepoint")
+	}
+
+	if reported == nil || len(reported.Failures) != 1 || reported.Failures[0].ID != "2" {
+		t.Errorf("expected failure of migration 2 to be reported, got %+v", reported)
+	}
+}
+
+func TestMigrator_WithContinueOnError_UnsupportedDialect(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	migrator := New(db, WithContinueOnError())
+	migrator.Register(&mockMigration{id: "1", description: "first", upQueries: []string{"SELECT 1"}})
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package migrator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const MigrationIDLayout = "20060102150405"
+
+type ScaffoldFormat string
+
+const (
+	ScaffoldSQL ScaffoldFormat = "sql"
+	ScaffoldGo  ScaffoldFormat = "go"
+)
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+func NewMigrationID(now time.Time) string {
+	return now.UTC().Format(MigrationIDLayout)
+}
+
+func Scaffold(dir, name string, format ScaffoldFormat, now time.Time) ([]string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMigrationName, name)
+	}
+
+	file := SQLFile{ID: NewMigrationID(now), Description: strings.ReplaceAll(name, "_", " ")}
+	base := filepath.Join(dir, file.ID+"_"+name)
+
+	var contents map[string][]byte
+	switch format {
+	case ScaffoldSQL:
+		contents = map[string][]byte{
+			base + upSuffix:   []byte("-- " + file.Description + "\n"),
+			base + downSuffix: []byte("-- revert " + file.Description + "\n"),
+		}
+	case ScaffoldGo:
+		contents = map[string][]byte{
+			base + ".go": scaffoldGoSource(filepath.Base(dir), file),
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScaffoldFormat, format)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(contents))
+	for _, p := range []string{base + upSuffix, base + downSuffix, base + ".go"} {
+		content, ok := contents[p]
+		if !ok {
+			continue
+		}
+		if err := writeNewFile(p, content); err != nil {
+			return paths, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func scaffoldGoSource(pkg string, file SQLFile) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"github.com/shuldan/migrator\"\n\n")
+	fmt.Fprintf(&buf, "var %s = migrator.CreateMigration(%q, %q).\n", goIdentifier(file), file.ID, file.Description)
+	buf.WriteString("\tBuild()\n")
+	return buf.Bytes()
+}
+
+func writeNewFile(name string, content []byte) error {
+	f, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
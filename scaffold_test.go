@@ -0,0 +1,72 @@
+package migrator
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScaffold_SQL(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2024, 6, 1, 12, 30, 45, 0, time.UTC)
+
+	paths, err := Scaffold(dir, "add_users_table", ScaffoldSQL, now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(paths) != 2 || filepath.Base(paths[0]) != "20240601123045_add_users_table.up.sql" ||
+		filepath.Base(paths[1]) != "20240601123045_add_users_table.down.sql" {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+
+	files, err := ReadSQLFiles(os.DirFS(dir), ".")
+	if err != nil {
+		t.Fatalf("expected scaffolded files to be loadable, got %v", err)
+	}
+	if len(files) != 1 || files[0].ID != "20240601123045" || files[0].Description != "add users table" {
+		t.Errorf("unexpected files: %+v", files)
+	}
+
+	if _, err := Scaffold(dir, "add_users_table", ScaffoldSQL, now); !errors.Is(err, os.ErrExist) {
+		t.Errorf("expected existing files not to be overwritten, got %v", err)
+	}
+}
+
+func TestScaffold_Go(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "schema")
+	paths, err := Scaffold(dir, "add_users_table", ScaffoldGo, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(paths) != 1 || filepath.Base(paths[0]) != "20240601000000_add_users_table.go" {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), paths[0], nil, 0)
+	if err != nil {
+		t.Fatalf("scaffolded source does not parse: %v", err)
+	}
+	if file.Name.Name != "schema" {
+		t.Errorf("expected package schema, got %s", file.Name.Name)
+	}
+}
+
+func TestScaffold_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, err := Scaffold(dir, "Add Users", ScaffoldSQL, time.Now()); !errors.Is(err, ErrInvalidMigrationName) {
+		t.Errorf("expected ErrInvalidMigrationName, got %v", err)
+	}
+	if _, err := Scaffold(dir, "add_users", "yaml", time.Now()); !errors.Is(err, ErrUnsupportedScaffoldFormat) {
+		t.Errorf("expected ErrUnsupportedScaffoldFormat, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	createTablePattern = regexp.MustCompile(`(?is)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."]+)\s*\((.*)\)$`)
+	createIndexPattern = regexp.MustCompile(`(?is)^CREATE\s+(UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."]+)\s+ON\s+([\w."]+)\s*\((.*)\)$`)
+	constraintPrefixes = []string{"CONSTRAINT", "PRIMARY KEY", "UNIQUE", "FOREIGN KEY", "CHECK"}
+)
+
+func ParseSchema(script string) (Schema, error) {
+	var schema Schema
+	tables := make(map[string]int)
+
+	for _, statement := range SplitStatements(script) {
+		if match := createTablePattern.FindStringSubmatch(statement); match != nil {
+			tables[match[1]] = len(schema.Tables)
+			schema.Tables = append(schema.Tables, parseTable(match[1], match[2]))
+			continue
+		}
+
+		if match := createIndexPattern.FindStringSubmatch(statement); match != nil {
+			i, ok := tables[match[3]]
+			if !ok {
+				return Schema{}, fmt.Errorf("%w: index %s references unknown table %s", ErrInvalidSchema, match[2], match[3])
+			}
+			schema.Tables[i].Indexes = append(schema.Tables[i].Indexes, IndexSchema{
+				Name:    match[2],
+				Columns: splitTopLevel(match[4]),
+				Unique:  match[1] != "",
+			})
+			continue
+		}
+
+		return Schema{}, fmt.Errorf("%w: unsupported statement %q", ErrInvalidSchema, firstLine(statement))
+	}
+
+	return schema, nil
+}
+
+func parseTable(name, body string) TableSchema {
+	table := TableSchema{Name: name}
+	for _, entry := range splitTopLevel(body) {
+		if isTableConstraint(entry) {
+			table.Constraints = append(table.Constraints, entry)
+			continue
+		}
+
+		column := ColumnSchema{Name: entry}
+		if i := strings.IndexAny(entry, " \t\n"); i > 0 {
+			column.Name = entry[:i]
+			column.Definition = strings.TrimSpace(entry[i:])
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	return table
+}
+
+func isTableConstraint(entry string) bool {
+	upper := strings.ToUpper(entry)
+	for _, prefix := range constraintPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTopLevel(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+
+	for i, ch := range list {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(list[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(list[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+func SplitStatements(script string) []string {
+	var statements []string
+	scanner := newStatementScanner(strings.NewReader(script))
+	for scanner.Next() {
+		statements = append(statements, scanner.Statement())
+	}
+	return statements
+}
+
+func firstLine(statement string) string {
+	if i := strings.IndexByte(statement, '\n'); i >= 0 {
+		return statement[:i]
+	}
+	return statement
+}
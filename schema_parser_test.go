@@ -0,0 +1,105 @@
+package migrator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	t.Parallel()
+
+	script := `
+-- users; with a semicolon in a comment
+CREATE TABLE users (id INTEGER, note TEXT DEFAULT 'a;b');
+INSERT INTO users (id) VALUES (1);
+SELECT 1`
+
+	statements := SplitStatements(script)
+	expected := []string{
+		"CREATE TABLE users (id INTEGER, note TEXT DEFAULT 'a;b')",
+		"INSERT INTO users (id) VALUES (1)",
+		"SELECT 1",
+	}
+	if len(statements) != len(expected) {
+		t.Fatalf("expected %d statements, got %d: %q", len(expected), len(statements), statements)
+	}
+	for i, statement := range expected {
+		if statements[i] != statement {
+			t.Errorf("expected statement %d to be %q, got %q", i, statement, statements[i])
+		}
+	}
+}
+
+func TestSplitStatements_DollarQuotesAndBlockComments(t *testing.T) {
+	t.Parallel()
+
+	script := `
+CREATE FUNCTION f() RETURNS trigger AS $$ BEGIN NEW.x := 1; RETURN NEW; END; $$ LANGUAGE plpgsql;
+CREATE FUNCTION g() RETURNS text AS $body$ SELECT '$$;'; $body$ LANGUAGE sql;
+/* drop; the old table */ DROP TABLE legacy;
+/*!40101 SET NAMES utf8mb4 */;
+UPDATE t SET v = $1 WHERE id = $2;
+SELECT 1`
+
+	statements := SplitStatements(script)
+	expected := []string{
+		"CREATE FUNCTION f() RETURNS trigger AS $$ BEGIN NEW.x := 1; RETURN NEW; END; $$ LANGUAGE plpgsql",
+		"CREATE FUNCTION g() RETURNS text AS $body$ SELECT '$$;'; $body$ LANGUAGE sql",
+		"/* drop; the old table */ DROP TABLE legacy",
+		"/*!40101 SET NAMES utf8mb4 */",
+		"UPDATE t SET v = $1 WHERE id = $2",
+		"SELECT 1",
+	}
+	if !slices.Equal(statements, expected) {
+		t.Errorf("expected statements %q, got %q", expected, statements)
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := ParseSchema(`
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    price NUMERIC(10, 2),
+    CONSTRAINT chk_price CHECK (price > 0)
+);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(schema.Tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(schema.Tables))
+	}
+	table := schema.Tables[0]
+	if table.Name != "users" || len(table.Columns) != 3 {
+		t.Fatalf("unexpected table: %+v", table)
+	}
+	if table.Columns[2].Name != "price" || table.Columns[2].Definition != "NUMERIC(10, 2)" {
+		t.Errorf("unexpected column: %+v", table.Columns[2])
+	}
+	if len(table.Constraints) != 1 || table.Constraints[0] != "CONSTRAINT chk_price CHECK (price > 0)" {
+		t.Errorf("unexpected constraints: %q", table.Constraints)
+	}
+	if len(table.Indexes) != 1 || !table.Indexes[0].Unique || table.Indexes[0].Columns[0] != "email" {
+		t.Errorf("unexpected indexes: %+v", table.Indexes)
+	}
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	t.Parallel()
+
+	scripts := []string{
+		"CREATE INDEX idx_posts_id ON posts (id);",
+		"DROP TABLE users;",
+	}
+	for _, script := range scripts {
+		if _, err := ParseSchema(script); !errors.Is(err, ErrInvalidSchema) {
+			t.Errorf("expected ErrInvalidSchema for %q, got %v", script, err)
+		}
+	}
+}
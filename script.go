@@ -0,0 +1,183 @@
+package migrator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func (r *Migrator) GenerateScript(ctx context.Context, w io.Writer, direction Direction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	out := bufio.NewWriter(w)
+	switch direction {
+	case DirectionUp:
+		err = r.writeUpScript(out, applied)
+	case DirectionDown:
+		err = r.writeDownScript(out, applied)
+	default:
+		err = fmt.Errorf("%w: unknown direction %q", ErrFailedToGenerateScript, direction)
+	}
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+func (r *Migrator) writeUpScript(w *bufio.Writer, applied []MigrationStatus) error {
+	pending, err := r.orderByDependencies(r.pendingMigrations(applied), applied)
+	if err != nil {
+		return err
+	}
+	batch := r.getNextBatchNumber(applied)
+
+	_, _ = fmt.Fprintf(w, "-- migrator: up, batch %d, %d migration(s)\n", batch, len(pending))
+	_, _ = fmt.Fprintf(w, "%s\n", strings.TrimSpace(fmt.Sprintf(migrationTableTemplate, r.table)))
+
+	for _, segment := range splitByTransaction(pending, r.isTransactional) {
+		transactional := r.isTransactional(segment[0])
+		beginScriptSegment(w, transactional)
+		for _, migration := range segment {
+			if err := r.writeUpMigration(w, migration, batch); err != nil {
+				return err
+			}
+		}
+		endScriptSegment(w, transactional)
+	}
+	return nil
+}
+
+func (r *Migrator) writeUpMigration(w *bufio.Writer, migration Migration, batch int) error {
+	if _, ok := migration.(*copyMigration); ok {
+		return fmt.Errorf("%w: %s loads data with COPY", ErrFailedToGenerateScript, migration.ID())
+	}
+
+	_, _ = fmt.Fprintf(w, "\n-- %s: %s\n", migration.ID(), migration.Description())
+
+	if streaming, ok := migration.(Streaming); ok {
+		stream, err := streaming.UpStream()
+		if err != nil {
+			return errors.Join(ErrFailedToGenerateScript, err)
+		}
+		_, err = scanStatements(stream, func(statement string) error {
+			writeScriptStatement(w, statement)
+			return nil
+		})
+		if err = errors.Join(err, stream.Close()); err != nil {
+			return errors.Join(ErrFailedToGenerateScript, err)
+		}
+	} else {
+		queries, err := r.upQueries(migration)
+		if err != nil {
+			return err
+		}
+		chunked := chunkedQueries(migration)
+		for i, query := range queries {
+			if strings.TrimSpace(query) == "" {
+				continue
+			}
+			if chunked[i] {
+				_, _ = w.WriteString("-- repeat the next statement until it affects no rows\n")
+			}
+			writeScriptStatement(w, query)
+		}
+	}
+
+	checksum, err := r.checksum(migration)
+	if err != nil {
+		return err
+	}
+	if isRepeatable(migration) {
+		_, _ = fmt.Fprintf(w, "DELETE FROM %s WHERE module = %s AND id = %s;\n",
+			r.table, r.sqlLiteral(r.module()), r.sqlLiteral(migration.ID()))
+	} else {
+		_, _ = fmt.Fprintf(w, "DELETE FROM %s WHERE module = %s AND id = %s AND status <> %s;\n",
+			r.table, r.sqlLiteral(r.module()), r.sqlLiteral(migration.ID()), r.sqlLiteral(string(StateApplied)))
+	}
+	_, _ = fmt.Fprintf(w, "INSERT INTO %s (module, id, description, batch, checksum) VALUES (%s, %s, %s, %d, %s);\n",
+		r.table, r.sqlLiteral(r.module()), r.sqlLiteral(migration.ID()), r.sqlLiteral(migration.Description()), batch, r.sqlLiteral(checksum))
+	return nil
+}
+
+func (r *Migrator) writeDownScript(w *bufio.Writer, applied []MigrationStatus) error {
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
+	var rollbackList []MigrationStatus
+	for _, status := range r.buildRollbackList(applied, 0) {
+		if len(rollbackList) > 0 && status.Batch != rollbackList[0].Batch {
+			break
+		}
+		rollbackList = append(rollbackList, status)
+	}
+
+	batch := 0
+	if len(rollbackList) > 0 {
+		batch = rollbackList[0].Batch
+	}
+	_, _ = fmt.Fprintf(w, "-- migrator: down, batch %d, %d migration(s)\n", batch, len(rollbackList))
+
+	transactional := func(status MigrationStatus) bool {
+		migration, exists := migrationMap[status.ID]
+		return !exists || r.isTransactional(migration)
+	}
+	for _, segment := range splitByTransaction(rollbackList, transactional) {
+		beginScriptSegment(w, transactional(segment[0]))
+		for _, status := range segment {
+			_, _ = fmt.Fprintf(w, "\n-- %s: %s\n", status.ID, status.Description)
+			if migration, exists := migrationMap[status.ID]; exists {
+				queries, err := r.downQueries(migration)
+				if err != nil {
+					return err
+				}
+				for _, query := range queries {
+					if strings.TrimSpace(query) != "" {
+						writeScriptStatement(w, query)
+					}
+				}
+			}
+			_, _ = fmt.Fprintf(w, "UPDATE %s SET status = %s WHERE module = %s AND id = %s;\n",
+				r.table, r.sqlLiteral(string(StateRolledBack)), r.sqlLiteral(r.module()), r.sqlLiteral(status.ID))
+		}
+		endScriptSegment(w, transactional(segment[0]))
+	}
+	return nil
+}
+
+func beginScriptSegment(w *bufio.Writer, transactional bool) {
+	if transactional {
+		_, _ = w.WriteString("\nBEGIN;\n")
+	} else {
+		_, _ = w.WriteString("\n-- the following statements cannot run inside a transaction\n")
+	}
+}
+
+func endScriptSegment(w *bufio.Writer, transactional bool) {
+	if transactional {
+		_, _ = w.WriteString("\nCOMMIT;\n")
+	}
+}
+
+func writeScriptStatement(w *bufio.Writer, statement string) {
+	statement = strings.TrimSpace(statement)
+	_, _ = w.WriteString(statement)
+	if !strings.HasSuffix(statement, ";") && !isNoopQuery(statement) {
+		_, _ = w.WriteString(";")
+	}
+	_, _ = w.WriteString("\n")
+}
+
+func (r *Migrator) sqlLiteral(value string) string {
+	value = strings.ReplaceAll(value, "'", "''")
+	if r.dialect == DialectMySQL {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + value + "'"
+}
@@ -0,0 +1,72 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_GenerateScript(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrations := []Migration{
+		CreateMigration("1", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY", "name TEXT").
+			Build(),
+		&mockMigration{
+			id:          "2",
+			description: "seed o'brien",
+			upQueries:   []string{"INSERT INTO users (name) VALUES ('o''brien')"},
+			downQueries: []string{"DELETE FROM users"},
+		},
+	}
+
+	migrator := New(db)
+	migrator.Register(migrations...)
+
+	var up bytes.Buffer
+	if err := migrator.GenerateScript(context.Background(), &up, DirectionUp); err != nil {
+		t.Fatalf("failed to generate up script: %v", err)
+	}
+	script := up.String()
+	for _, fragment := range []string{"BEGIN;", "COMMIT;", "CREATE TABLE IF NOT EXISTS users", "-- 2: seed o'brien", "'seed o''brien', 1,"} {
+		if !strings.Contains(script, fragment) {
+			t.Errorf("expected script to contain %q, got:\n%s", fragment, script)
+		}
+	}
+	assertAppliedIDs(t, migrator)
+
+	if _, err := db.Exec(script); err != nil {
+		t.Fatalf("failed to run generated script: %v\n%s", err, script)
+	}
+	assertAppliedIDs(t, migrator, "1", "2")
+	if err := migrator.Verify(context.Background()); err != nil {
+		t.Errorf("expected scripted history to match checksums, got %v", err)
+	}
+	if n := countRows(t, db, "users"); n != 1 {
+		t.Errorf("expected seeded row, got %d", n)
+	}
+
+	var down bytes.Buffer
+	if err := migrator.GenerateScript(context.Background(), &down, DirectionDown); err != nil {
+		t.Fatalf("failed to generate down script: %v", err)
+	}
+	if !strings.Contains(down.String(), "-- migrator: down, batch 1, 2 migration(s)") {
+		t.Errorf("unexpected down script:\n%s", down.String())
+	}
+	if _, err := db.Exec(down.String()); err != nil {
+		t.Fatalf("failed to run down script: %v\n%s", err, down.String())
+	}
+	assertAppliedIDs(t, migrator)
+}
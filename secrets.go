@@ -0,0 +1,86 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, key string) (string, error)
+}
+
+type SecretResolverFunc func(ctx context.Context, key string) (string, error)
+
+func (f SecretResolverFunc) ResolveSecret(ctx context.Context, key string) (string, error) {
+	return f(ctx, key)
+}
+
+type EnvSecretResolver struct{}
+
+func (EnvSecretResolver) ResolveSecret(_ context.Context, key string) (string, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", key)
+	}
+	return value, nil
+}
+
+type FileSecretResolver struct{}
+
+func (FileSecretResolver) ResolveSecret(_ context.Context, key string) (string, error) {
+	data, err := os.ReadFile(key)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func DefaultSecretResolvers() map[string]SecretResolver {
+	return map[string]SecretResolver{
+		"env":  EnvSecretResolver{},
+		"file": FileSecretResolver{},
+	}
+}
+
+func ResolveSecrets(ctx context.Context, value string, resolvers map[string]SecretResolver) (string, error) {
+	var resolved strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			resolved.WriteString(value)
+			return resolved.String(), nil
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated placeholder", ErrFailedToResolveSecret)
+		}
+
+		reference := value[start+2 : start+end]
+		scheme, key, ok := strings.Cut(reference, "://")
+		if !ok {
+			return "", fmt.Errorf("%w: placeholder %q must look like ${provider://key}", ErrFailedToResolveSecret, reference)
+		}
+		secret, err := resolveSecret(ctx, scheme, key, resolvers)
+		if err != nil {
+			return "", err
+		}
+
+		resolved.WriteString(value[:start])
+		resolved.WriteString(secret)
+		value = value[start+end+1:]
+	}
+}
+
+func resolveSecret(ctx context.Context, scheme, key string, resolvers map[string]SecretResolver) (string, error) {
+	resolver, ok := resolvers[scheme]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown provider %q", ErrFailedToResolveSecret, scheme)
+	}
+	secret, err := resolver.ResolveSecret(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s://%s: %w", ErrFailedToResolveSecret, scheme, key, err)
+	}
+	return secret, nil
+}
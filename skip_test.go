@@ -0,0 +1,52 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_WithSkip(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithSkip("2"))
+	migrator.Register(
+		&mockMigration{id: "1", description: "good", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "known bad", upQueries: []string{"THIS IS NOT SQL"}},
+		&mockMigration{id: "3", description: "good", upQueries: []string{"CREATE TABLE c (id INTEGER)"}},
+	)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected skipped migration to be bypassed, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1", "3")
+
+	ctx := context.Background()
+	if err := migrator.Healthy(ctx); err != nil {
+		t.Errorf("expected skipped migrations not to count as pending, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := migrator.WriteStatus(ctx, &buf, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "2 ") && !strings.Contains(line, "skipped") {
+			t.Errorf("expected migration 2 to be reported as skipped, got %q", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "skipped") {
+		t.Errorf("expected skipped row in status output, got:\n%s", buf.String())
+	}
+}
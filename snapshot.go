@@ -0,0 +1,79 @@
+package migrator
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+var snapshotQueries = map[Dialect][]string{
+	DialectPostgres: {
+		`SELECT table_name, 'column ' || table_name || '.' || column_name || ' ' || data_type ||
+    ' nullable=' || is_nullable || ' default=' || COALESCE(column_default, '')
+FROM information_schema.columns WHERE table_schema = current_schema()`,
+		`SELECT tablename, 'index ' || tablename || '.' || indexname || ' ' || indexdef
+FROM pg_indexes WHERE schemaname = current_schema()`,
+		`SELECT table_name, 'constraint ' || table_name || '.' || constraint_name || ' ' || constraint_type
+FROM information_schema.table_constraints WHERE table_schema = current_schema()`,
+	},
+	DialectMySQL: {
+		`SELECT table_name, CONCAT('column ', table_name, '.', column_name, ' ', column_type,
+    ' nullable=', is_nullable, ' default=', COALESCE(column_default, ''))
+FROM information_schema.columns WHERE table_schema = DATABASE()`,
+		`SELECT table_name, CONCAT('index ', table_name, '.', index_name, ' unique=', 1 - non_unique,
+    ' ', seq_in_index, ' ', column_name)
+FROM information_schema.statistics WHERE table_schema = DATABASE()`,
+		`SELECT table_name, CONCAT('constraint ', table_name, '.', constraint_name, ' ', constraint_type)
+FROM information_schema.table_constraints WHERE table_schema = DATABASE()`,
+	},
+	DialectSQLite: {
+		`SELECT tbl_name, type || ' ' || tbl_name || '.' || name || ' ' || COALESCE(sql, '')
+FROM sqlite_master WHERE name NOT LIKE 'sqlite_%'`,
+	},
+}
+
+func (r *Migrator) SchemaSnapshot(ctx context.Context) (string, error) {
+	queries, ok := snapshotQueries[r.dialect]
+	if !ok {
+		return "", ErrUnsupportedDialect
+	}
+
+	var lines []string
+	for _, query := range queries {
+		found, err := r.snapshotLines(ctx, query)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, found...)
+	}
+
+	sort.Strings(lines)
+	return strings.Join(lines, "\n"), nil
+}
+
+func (r *Migrator) snapshotLines(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var lines []string
+	for rows.Next() {
+		var table, line string
+		if err := rows.Scan(&table, &line); err != nil {
+			return nil, err
+		}
+		if r.ownsTable(table) {
+			continue
+		}
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	return lines, rows.Err()
+}
+
+func (r *Migrator) ownsTable(table string) bool {
+	return table == r.table || strings.HasPrefix(table, r.table+"_")
+}
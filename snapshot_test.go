@@ -0,0 +1,48 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMigrator_SchemaSnapshot(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db, WithDialect(DialectSQLite))
+	migrator.Register(CreateMigration("1", "create users").
+		CreateTable("users", "id INTEGER PRIMARY KEY", "email TEXT").
+		CreateIndex("idx_users_email", "users", "email").
+		Build())
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	snapshot, err := migrator.SchemaSnapshot(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(snapshot, "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "index users.idx_users_email") || !strings.HasPrefix(lines[1], "table users.users") {
+		t.Errorf("expected index and table of users only, got:\n%s", snapshot)
+	}
+	if strings.Contains(snapshot, "schema_migrations") {
+		t.Errorf("expected migrator tables to be excluded, got:\n%s", snapshot)
+	}
+
+	if _, err := New(db).SchemaSnapshot(context.Background()); !errors.Is(err, ErrUnsupportedDialect) {
+		t.Errorf("expected ErrUnsupportedDialect, got %v", err)
+	}
+}
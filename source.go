@@ -0,0 +1,193 @@
+package migrator
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const sourceIndexFile = "index.txt"
+
+type Source interface {
+	Load(ctx context.Context) ([]Migration, error)
+}
+
+type FSSource struct {
+	FS  fs.FS
+	Dir string
+}
+
+func (s FSSource) Load(_ context.Context) ([]Migration, error) {
+	return LoadFS(s.FS, s.Dir)
+}
+
+type HTTPSource struct {
+	BaseURL  string
+	Client   *http.Client
+	CacheDir string
+}
+
+func OpenSource(location string) (Source, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &HTTPSource{BaseURL: location}, nil
+	}
+	if location == "" {
+		return nil, fmt.Errorf("%w: empty location", ErrInvalidSource)
+	}
+	return FSSource{FS: os.DirFS(location), Dir: "."}, nil
+}
+
+func (s *HTTPSource) Load(ctx context.Context) ([]Migration, error) {
+	cache, err := s.cacheDir()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToFetchSource, err)
+	}
+	for _, dir := range []string{filepath.Join(cache, "sql"), filepath.Join(cache, "etag")} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, errors.Join(ErrFailedToFetchSource, err)
+		}
+	}
+
+	if err := s.fetch(ctx, cache, sourceIndexFile); err != nil {
+		return nil, err
+	}
+	names, err := readSourceIndex(filepath.Join(cache, "sql", sourceIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	keep := map[string]bool{sourceIndexFile: true}
+	for _, name := range names {
+		if err := s.fetch(ctx, cache, name); err != nil {
+			return nil, err
+		}
+		keep[name] = true
+	}
+	if err := pruneSourceCache(cache, keep); err != nil {
+		return nil, errors.Join(ErrFailedToFetchSource, err)
+	}
+
+	return LoadFS(os.DirFS(filepath.Join(cache, "sql")), ".")
+}
+
+func (s *HTTPSource) cacheDir() (string, error) {
+	if s.CacheDir != "" {
+		return s.CacheDir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return os.MkdirTemp("", "migrator-")
+	}
+	sum := sha256.Sum256([]byte(s.BaseURL))
+	return filepath.Join(base, "migrator", hex.EncodeToString(sum[:8])), nil
+}
+
+func (s *HTTPSource) fetch(ctx context.Context, cache, name string) error {
+	target := filepath.Join(cache, "sql", name)
+	etagFile := filepath.Join(cache, "etag", name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(s.BaseURL, "/")+"/"+name, nil)
+	if err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+	if etag, err := os.ReadFile(etagFile); err == nil {
+		if _, err := os.Stat(target); err == nil {
+			req.Header.Set("If-None-Match", string(etag))
+		}
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("%w: %s: %s", ErrFailedToFetchSource, name, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+	if err := os.WriteFile(target, body, 0o600); err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		err = os.WriteFile(etagFile, []byte(etag), 0o600)
+	} else {
+		err = os.Remove(etagFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return errors.Join(ErrFailedToFetchSource, err)
+	}
+	return nil
+}
+
+func readSourceIndex(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToFetchSource, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line != filepath.Base(line) || line == sourceIndexFile || line == "." || line == ".." {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSource, line)
+		}
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(ErrFailedToFetchSource, err)
+	}
+	return names, nil
+}
+
+func pruneSourceCache(cache string, keep map[string]bool) error {
+	for _, dir := range []string{"sql", "etag"} {
+		entries, err := os.ReadDir(filepath.Join(cache, dir))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if keep[entry.Name()] {
+				continue
+			}
+			if err := os.Remove(filepath.Join(cache, dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHTTPSource_Load(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	files := map[string]string{
+		"index.txt":                 "# published migrations\n001_create_users.up.sql\n001_create_users.down.sql\n",
+		"001_create_users.up.sql":   "CREATE TABLE users (id INTEGER);",
+		"001_create_users.down.sql": "DROP TABLE users;",
+		"002_add_email.up.sql":      "ALTER TABLE users ADD COLUMN email TEXT;",
+	}
+	downloads := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		name := req.URL.Path[1:]
+		content, ok := files[name]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		etag := `"` + checksumQueries([]string{content}) + `"`
+		if req.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads[name]++
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	cache := t.TempDir()
+	source := &HTTPSource{BaseURL: server.URL, CacheDir: cache}
+
+	migrations, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(migrations) != 1 || migrations[0].ID() != "001" || len(migrations[0].Down()) != 1 {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+
+	if _, err := source.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	mu.Lock()
+	if downloads["001_create_users.up.sql"] != 1 {
+		t.Errorf("expected cached file to be reused, downloaded %d times", downloads["001_create_users.up.sql"])
+	}
+	files["index.txt"] = "002_add_email.up.sql\n"
+	mu.Unlock()
+
+	migrations, err = source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(migrations) != 1 || migrations[0].ID() != "002" {
+		t.Fatalf("expected only migration 002, got %+v", migrations)
+	}
+	if _, err := os.Stat(filepath.Join(cache, "sql", "001_create_users.up.sql")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected stale cache entry to be removed, got %v", err)
+	}
+}
+
+func TestHTTPSource_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/index.txt" {
+			_, _ = w.Write([]byte("../etc/passwd\n"))
+			return
+		}
+		http.NotFound(w, req)
+	}))
+	defer server.Close()
+
+	_, err := (&HTTPSource{BaseURL: server.URL, CacheDir: t.TempDir()}).Load(context.Background())
+	if !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("expected ErrInvalidSource, got %v", err)
+	}
+
+	_, err = (&HTTPSource{BaseURL: server.URL + "/missing", CacheDir: t.TempDir()}).Load(context.Background())
+	if !errors.Is(err, ErrFailedToFetchSource) {
+		t.Errorf("expected ErrFailedToFetchSource, got %v", err)
+	}
+}
+
+func TestOpenSource(t *testing.T) {
+	t.Parallel()
+
+	source, err := OpenSource("https://example.com/migrations")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := source.(*HTTPSource); !ok {
+		t.Errorf("expected HTTPSource, got %T", source)
+	}
+
+	source, err = OpenSource(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := source.(FSSource); !ok {
+		t.Errorf("expected FSSource, got %T", source)
+	}
+
+	migrations, err := FSSource{FS: fstest.MapFS{
+		"m/001_a.up.sql": {Data: []byte("SELECT 1")},
+	}, Dir: "m"}.Load(context.Background())
+	if err != nil || len(migrations) != 1 {
+		t.Errorf("expected 1 migration, got %v, %v", migrations, err)
+	}
+}
+
+func TestHTTPSource_DefaultCacheDir(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("HOME", cache)
+
+	dir, err := (&HTTPSource{BaseURL: "https://example.com/migrations"}).cacheDir()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		t.Fatalf("failed to resolve user cache dir: %v", err)
+	}
+	if filepath.Dir(dir) != filepath.Join(base, "migrator") {
+		t.Errorf("expected cache under the user cache dir %s, got %s", base, dir)
+	}
+}
@@ -0,0 +1,115 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+type StatusFilter struct {
+	MinBatch      int
+	MaxBatch      int
+	AppliedAfter  time.Time
+	AppliedBefore time.Time
+	PendingOnly   bool
+	Limit         int
+	Offset        int
+}
+
+func (r *Migrator) StatusWhere(ctx context.Context, filter StatusFilter) ([]MigrationStatus, error) {
+	return r.readView("").statusWhere(ctx, filter)
+}
+
+func (r *Migrator) statusWhere(ctx context.Context, filter StatusFilter) ([]MigrationStatus, error) {
+	if err := r.createMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	if filter.PendingOnly {
+		return r.pendingStatuses(ctx, filter)
+	}
+
+	conditions := []string{"module = ?"}
+	args := []any{r.module()}
+	if filter.MinBatch > 0 {
+		conditions = append(conditions, "batch >= ?")
+		args = append(args, filter.MinBatch)
+	}
+	if filter.MaxBatch > 0 {
+		conditions = append(conditions, "batch <= ?")
+		args = append(args, filter.MaxBatch)
+	}
+	if !filter.AppliedAfter.IsZero() {
+		conditions = append(conditions, "applied_at >= ?")
+		args = append(args, filter.AppliedAfter.UTC())
+	}
+	if !filter.AppliedBefore.IsZero() {
+		conditions = append(conditions, "applied_at < ?")
+		args = append(args, filter.AppliedBefore.UTC())
+	}
+
+	query := "SELECT id, description, applied_at, batch, checksum, duration_ms, status FROM %s WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY batch, id"
+	if filter.Limit > 0 || filter.Offset > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, pageLimit(filter.Limit), filter.Offset)
+	}
+
+	rows, err := r.conn.Query(ctx, r.query(query), args...)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return scanMigrationStatuses(rows)
+}
+
+func (r *Migrator) pendingStatuses(ctx context.Context, filter StatusFilter) ([]MigrationStatus, error) {
+	rows, err := r.conn.Query(ctx, r.query("SELECT id FROM %s WHERE module = ? AND status = ?"), r.module(), string(StateApplied))
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var applied []MigrationStatus
+	for rows.Next() {
+		var status MigrationStatus
+		if err := rows.Scan(&status.ID); err != nil {
+			return nil, err
+		}
+		applied = append(applied, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.refreshBaseline(ctx); err != nil {
+		return nil, err
+	}
+
+	pending := r.pendingMigrations(applied)
+	start := min(filter.Offset, len(pending))
+	end := len(pending)
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, end)
+	}
+
+	statuses := make([]MigrationStatus, 0, end-start)
+	for _, migration := range pending[start:end] {
+		statuses = append(statuses, MigrationStatus{ID: migration.ID(), Description: migration.Description()})
+	}
+	return statuses, nil
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return math.MaxInt
+	}
+	return limit
+}
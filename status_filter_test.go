@@ -0,0 +1,73 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestMigrator_StatusWhere(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		migrator.Register(&mockMigration{id: id, upQueries: []string{"CREATE TABLE t" + id + " (id INTEGER)"}})
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", id, err)
+		}
+	}
+	migrator.Register(
+		&mockMigration{id: "5", description: "five"},
+		&mockMigration{id: "6", description: "six"},
+		&mockMigration{id: "7", description: "seven"},
+	)
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		filter   StatusFilter
+		expected []string
+	}{
+		{"all", StatusFilter{}, []string{"1", "2", "3", "4"}},
+		{"batch range", StatusFilter{MinBatch: 2, MaxBatch: 3}, []string{"2", "3"}},
+		{"limit and offset", StatusFilter{Limit: 2, Offset: 1}, []string{"2", "3"}},
+		{"offset only", StatusFilter{Offset: 3}, []string{"4"}},
+		{"applied after", StatusFilter{AppliedAfter: time.Now().Add(-time.Hour)}, []string{"1", "2", "3", "4"}},
+		{"applied before", StatusFilter{AppliedBefore: time.Now().Add(-time.Hour)}, nil},
+		{"pending only", StatusFilter{PendingOnly: true}, []string{"5", "6", "7"}},
+		{"pending page", StatusFilter{PendingOnly: true, Limit: 1, Offset: 1}, []string{"6"}},
+		{"pending past end", StatusFilter{PendingOnly: true, Offset: 10}, nil},
+	}
+
+	for _, tt := range tests {
+		statuses, err := migrator.StatusWhere(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+
+		ids := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			ids = append(ids, status.ID)
+		}
+		if len(ids) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, ids)
+				break
+			}
+		}
+	}
+}
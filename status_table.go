@@ -0,0 +1,190 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type MigrationState string
+
+const (
+	StateApplied    MigrationState = "applied"
+	StatePending    MigrationState = "pending"
+	StateDrifted    MigrationState = "drifted"
+	StateMissing    MigrationState = "missing"
+	StateSkipped    MigrationState = "skipped"
+	StateFailed     MigrationState = "failed"
+	StateRolledBack MigrationState = "rolled_back"
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiRed    = "\033[31m"
+)
+
+var stateColors = map[MigrationState]string{
+	StateApplied: ansiGreen,
+	StatePending: ansiYellow,
+	StateDrifted: ansiRed,
+	StateMissing: ansiRed,
+	StateSkipped: ansiYellow,
+	StateFailed:  ansiRed,
+}
+
+type StatusRow struct {
+	MigrationStatus
+	State MigrationState
+}
+
+func (r *Migrator) WriteStatus(ctx context.Context, w io.Writer, color bool) error {
+	rows, err := r.readView("").loadStatusRows(ctx)
+	if err != nil {
+		return err
+	}
+	return WriteStatusTable(w, rows, color)
+}
+
+func (r *Migrator) FullStatus(ctx context.Context) ([]StatusRow, error) {
+	view := r.readView("")
+	rows, err := view.loadStatusRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(rows, func(a, b StatusRow) int {
+		return view.compareIDs(a.ID, b.ID)
+	})
+	return rows, nil
+}
+
+func (r *Migrator) loadStatusRows(ctx context.Context) ([]StatusRow, error) {
+	history, err := r.getHistory(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+	return r.statusRows(history)
+}
+
+func (r *Migrator) statusRows(history []MigrationStatus) ([]StatusRow, error) {
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
+
+	var applied []MigrationStatus
+	failed := make(map[string]MigrationStatus)
+	for _, status := range history {
+		switch status.Status {
+		case "", StateApplied:
+			applied = append(applied, status)
+		case StateFailed:
+			failed[status.ID] = status
+		}
+	}
+
+	rows := make([]StatusRow, 0, len(applied))
+	for _, status := range applied {
+		row := StatusRow{MigrationStatus: status, State: StateApplied}
+
+		migration, exists := migrationMap[status.ID]
+		if exists && isRepeatable(migration) && r.repeatableChanged(migration, status) {
+			continue
+		}
+		switch {
+		case !exists:
+			row.State = StateMissing
+		case status.Checksum != "":
+			checksum, err := r.checksum(migration)
+			if err != nil {
+				return nil, err
+			}
+			if checksum != status.Checksum {
+				row.State = StateDrifted
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	for _, migration := range r.unappliedMigrations(applied) {
+		row := StatusRow{
+			MigrationStatus: MigrationStatus{ID: migration.ID(), Description: migration.Description()},
+			State:           StatePending,
+		}
+		if status, ok := failed[migration.ID()]; ok {
+			row = StatusRow{MigrationStatus: status, State: StateFailed}
+		}
+		if r.skip[migration.ID()] {
+			row.State = StateSkipped
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func WriteStatusTable(w io.Writer, rows []StatusRow, color bool) error {
+	var buf bytes.Buffer
+	table := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "ID\tDESCRIPTION\tSTATE\tBATCH\tAPPLIED AT\tDURATION\tCHECKSUM")
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			row.ID, row.Description, row.State, formatBatch(row), formatAppliedAt(row), formatDuration(row), formatChecksum(row))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if color && i > 0 {
+			line = stateColors[rows[i-1].State] + strings.TrimSuffix(line, "\n") + ansiReset + "\n"
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatBatch(row StatusRow) string {
+	if row.State == StatePending || row.State == StateSkipped {
+		return "-"
+	}
+	return fmt.Sprint(row.Batch)
+}
+
+func formatAppliedAt(row StatusRow) string {
+	if row.AppliedAt == nil {
+		return "-"
+	}
+	return row.AppliedAt.UTC().Format(time.DateTime)
+}
+
+func formatDuration(row StatusRow) string {
+	if row.State == StatePending || row.State == StateSkipped {
+		return "-"
+	}
+	return row.Duration.String()
+}
+
+func formatChecksum(row StatusRow) string {
+	switch {
+	case row.State == StatePending || row.State == StateSkipped || row.State == StateMissing:
+		return "-"
+	case row.State == StateDrifted:
+		return "changed"
+	case row.Checksum == "":
+		return "unknown"
+	default:
+		return "ok"
+	}
+}
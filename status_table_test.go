@@ -0,0 +1,143 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrator_WriteStatus(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	original := New(db)
+	original.Register(
+		&mockMigration{id: "1", description: "create users", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&mockMigration{id: "2", description: "create posts", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}},
+	)
+	if _, err := original.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", description: "create users", upQueries: []string{"CREATE TABLE users (id BIGINT)"}},
+		&mockMigration{id: "3", description: "create tags", upQueries: []string{"CREATE TABLE tags (id INTEGER)"}},
+	)
+
+	var buf bytes.Buffer
+	if err := migrator.WriteStatus(context.Background(), &buf, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 rows, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "ID") || strings.Index(lines[0], "STATE") != strings.Index(lines[1], "drifted") {
+		t.Errorf("expected aligned columns, got:\n%s", buf.String())
+	}
+	for i, fragment := range []string{"drifted", "missing", "pending"} {
+		if !strings.Contains(lines[i+1], fragment) {
+			t.Errorf("expected row %d to be %s, got %q", i+1, fragment, lines[i+1])
+		}
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Error("expected no ANSI codes without color")
+	}
+}
+
+func TestWriteStatusTable_Color(t *testing.T) {
+	t.Parallel()
+
+	appliedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rows := []StatusRow{
+		{MigrationStatus: MigrationStatus{ID: "1", Description: "a", Batch: 1, AppliedAt: &appliedAt, Checksum: "abc", Duration: 15 * time.Millisecond}, State: StateApplied},
+		{MigrationStatus: MigrationStatus{ID: "2", Description: "b"}, State: StatePending},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteStatusTable(&buf, rows, true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if strings.Contains(lines[0], "\033[") {
+		t.Error("expected header to stay uncolored")
+	}
+	if !strings.HasPrefix(lines[1], ansiGreen) || !strings.Contains(lines[1], "2024-06-01 12:00:00") || !strings.Contains(lines[1], "15ms") {
+		t.Errorf("unexpected applied row: %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], ansiYellow) || !strings.HasSuffix(lines[2], ansiReset) {
+		t.Errorf("unexpected pending row: %q", lines[2])
+	}
+}
+
+func TestMigrator_FullStatus(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	original := New(db)
+	original.Register(
+		&mockMigration{id: "1", description: "create users", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&mockMigration{id: "3", description: "create posts", upQueries: []string{"CREATE TABLE posts (id INTEGER)"}},
+		&mockMigration{id: "4", description: "create tags", upQueries: []string{"CREATE TABLE tags (id INTEGER)"}},
+	)
+	if _, err := original.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "5", description: "create likes", upQueries: []string{"CREATE TABLE likes (id INTEGER)"}},
+		&mockMigration{id: "1", description: "create users", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&mockMigration{id: "2", description: "create roles", upQueries: []string{"CREATE TABLE roles (id INTEGER)"}},
+		&mockMigration{id: "3", description: "create posts", upQueries: []string{"CREATE TABLE posts (id BIGINT)"}},
+	)
+
+	rows, err := migrator.FullStatus(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		id    string
+		state MigrationState
+	}{
+		{"1", StateApplied},
+		{"2", StatePending},
+		{"3", StateDrifted},
+		{"4", StateMissing},
+		{"5", StatePending},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %+v", len(expected), rows)
+	}
+	for i, want := range expected {
+		if rows[i].ID != want.id || rows[i].State != want.state {
+			t.Errorf("row %d: expected %s %s, got %s %s", i, want.id, want.state, rows[i].ID, rows[i].State)
+		}
+	}
+	if rows[0].Batch != 1 || rows[1].Batch != 0 {
+		t.Errorf("expected batch only on applied rows, got %d and %d", rows[0].Batch, rows[1].Batch)
+	}
+}
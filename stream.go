@@ -0,0 +1,287 @@
+package migrator
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+type Streaming interface {
+	UpStream() (io.ReadCloser, error)
+}
+
+type OpenFunc func() (io.ReadCloser, error)
+
+type streamMigration struct {
+	id          string
+	description string
+	open        OpenFunc
+	downQueries []string
+}
+
+func StreamMigration(id, description string, open OpenFunc, down ...string) Migration {
+	return &streamMigration{id: id, description: description, open: open, downQueries: down}
+}
+
+func (m *streamMigration) ID() string {
+	return m.id
+}
+
+func (m *streamMigration) Description() string {
+	return m.description
+}
+
+func (m *streamMigration) Up() []string {
+	return nil
+}
+
+func (m *streamMigration) Down() []string {
+	return m.downQueries
+}
+
+func (m *streamMigration) UpStream() (io.ReadCloser, error) {
+	return m.open()
+}
+
+func OpenFS(fsys fs.FS, name string) OpenFunc {
+	return func() (io.ReadCloser, error) {
+		file, err := fsys.Open(name)
+		if err != nil || !strings.HasSuffix(name, gzipSuffix) {
+			return file, err
+		}
+
+		reader, err := gzip.NewReader(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return &gzipFile{Reader: reader, file: file}, nil
+	}
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file fs.File
+}
+
+func (f *gzipFile) Close() error {
+	return errors.Join(f.Reader.Close(), f.file.Close())
+}
+
+type statementScanner struct {
+	reader       *bufio.Reader
+	current      strings.Builder
+	quote        rune
+	lineComment  bool
+	blockComment int
+	dollarTag    string
+	dollarStart  int
+	statement    string
+	err          error
+}
+
+func newStatementScanner(r io.Reader) *statementScanner {
+	return &statementScanner{reader: bufio.NewReader(r), blockComment: -1}
+}
+
+func (s *statementScanner) Next() bool {
+	for {
+		ch, _, err := s.reader.ReadRune()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.err = err
+				return false
+			}
+			return s.emit()
+		}
+
+		if s.lineComment {
+			if ch == '\n' {
+				s.lineComment = false
+			}
+			continue
+		}
+		if s.quoted(ch) {
+			continue
+		}
+
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			s.quote = ch
+		case ch == '-' && strings.HasSuffix(s.current.String(), "-"):
+			trimmed := strings.TrimSuffix(s.current.String(), "-")
+			s.current.Reset()
+			s.current.WriteString(trimmed)
+			s.lineComment = true
+			continue
+		case ch == '*' && strings.HasSuffix(s.current.String(), "/"):
+			s.blockComment = s.current.Len() - 1
+		case ch == '$':
+			s.current.WriteRune(ch)
+			if tag := openingDollarTag(s.current.String()); tag != "" {
+				s.dollarTag = tag
+				s.dollarStart = s.current.Len()
+			}
+			continue
+		case ch == ';':
+			if s.emit() {
+				return true
+			}
+			continue
+		}
+		s.current.WriteRune(ch)
+	}
+}
+
+func (s *statementScanner) quoted(ch rune) bool {
+	switch {
+	case s.blockComment >= 0:
+		s.current.WriteRune(ch)
+		if ch == '/' && s.current.Len()-s.blockComment >= 4 && strings.HasSuffix(s.current.String(), "*/") {
+			s.blockComment = -1
+		}
+		return true
+	case s.dollarTag != "":
+		s.current.WriteRune(ch)
+		if ch == '$' && s.current.Len()-len(s.dollarTag) >= s.dollarStart && strings.HasSuffix(s.current.String(), s.dollarTag) {
+			s.dollarTag = ""
+		}
+		return true
+	case s.quote != 0:
+		s.current.WriteRune(ch)
+		if ch == s.quote {
+			s.quote = 0
+		}
+		return true
+	}
+	return false
+}
+
+func openingDollarTag(text string) string {
+	i := len(text) - 2
+	for i >= 0 && isIdentifierByte(text[i]) {
+		i--
+	}
+	if i < 0 || text[i] != '$' {
+		return ""
+	}
+	if body := text[i+1 : len(text)-1]; body != "" && body[0] >= '0' && body[0] <= '9' {
+		return ""
+	}
+	if i > 0 && (isIdentifierByte(text[i-1]) || text[i-1] == '$') {
+		return ""
+	}
+	return text[i:]
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+func (s *statementScanner) emit() bool {
+	s.statement = strings.TrimSpace(s.current.String())
+	s.current.Reset()
+	return s.statement != ""
+}
+
+func (s *statementScanner) Statement() string {
+	return s.statement
+}
+
+func (s *statementScanner) Err() error {
+	return s.err
+}
+
+func streamChecksum(migration Streaming) (string, error) {
+	stream, err := migration.UpStream()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	return scanStatements(stream, func(string) error {
+		return nil
+	})
+}
+
+func scanStatements(stream io.Reader, fn func(statement string) error) (string, error) {
+	hash := sha256.New()
+	scanner := newStatementScanner(stream)
+	for first := true; scanner.Next(); first = false {
+		if !first {
+			hash.Write([]byte("\n"))
+		}
+		hash.Write([]byte(scanner.Statement()))
+
+		if err := fn(scanner.Statement()); err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func classifyStream(planned *PlannedMigration, streaming Streaming) error {
+	stream, err := streaming.UpStream()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	_, err = scanStatements(stream, func(statement string) error {
+		class := classifyStatement(statement)
+		if class == OperationSafe {
+			return nil
+		}
+		planned.Operations = append(planned.Operations, PlannedOperation{Statement: statement, Class: class})
+		planned.Destructive = planned.Destructive || class == OperationDestructive
+		planned.Blocking = planned.Blocking || class == OperationBlocking
+		return nil
+	})
+	return err
+}
+
+func (r *Migrator) runStreamingUp(ctx context.Context, tx Executor, migration Migration, streaming Streaming, batch int) (historyRecord, error) {
+	started := time.Now()
+
+	stream, err := streaming.UpStream()
+	if err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	index := 0
+	checksum, err := scanStatements(stream, func(statement string) error {
+		index++
+		r.reportStatement(DirectionUp, batch, migration.ID(), index, 0, statement)
+		_, err := r.execStatement(ctx, tx, DirectionUp, batch, migration.ID(), statement)
+		return err
+	})
+	if err != nil {
+		return historyRecord{}, errors.Join(ErrFailedToExecuteQuery, err)
+	}
+
+	return historyRecord{
+		id:          migration.ID(),
+		description: migration.Description(),
+		batch:       batch,
+		checksum:    checksum,
+		duration:    time.Since(started),
+		appliedAt:   r.now(),
+	}, nil
+}
@@ -0,0 +1,158 @@
+package migrator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrator_StreamMigration(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	script := "CREATE TABLE seeds (id INTEGER, note TEXT);\n-- seed data; with a semicolon\nINSERT INTO seeds VALUES (1, 'a;b');\nINSERT INTO seeds VALUES (2, 'c');\n"
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte(script))
+	_ = writer.Close()
+
+	fsys := fstest.MapFS{"seed.sql.gz": {Data: compressed.Bytes()}}
+	migration := StreamMigration("1", "seed", OpenFS(fsys, "seed.sql.gz"), "DROP TABLE seeds")
+
+	migrator := New(db)
+	migrator.Register(migration)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := countRows(t, db, "seeds"); n != 2 {
+		t.Errorf("expected 2 seeded rows, got %d", n)
+	}
+
+	if err := migrator.Verify(context.Background()); err != nil {
+		t.Errorf("expected streamed checksum to verify, got %v", err)
+	}
+	plain := SQLFile{ID: "1", Up: script}
+	status, err := migrator.Status()
+	if err != nil || len(status) != 1 || status[0].Checksum != mustChecksum(t, plain.Migration()) {
+		t.Errorf("expected checksum to match the equivalent in-memory migration, got %+v, %v", status, err)
+	}
+	if mustChecksum(t, migration) != status[0].Checksum {
+		t.Errorf("expected Checksum to read the stream like the migrator does, got %s", mustChecksum(t, migration))
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMigrator_StreamMigrationGuardrails(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE legacy (id INTEGER)"); err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+
+	fsys := fstest.MapFS{"cleanup.sql": {Data: []byte("CREATE TABLE seeds (id INTEGER);\nDROP TABLE legacy;\n")}}
+	migrator := New(db, WithEnvironment("staging", GuardDestructiveUp))
+	migrator.Register(StreamMigration("1", "cleanup", OpenFS(fsys, "cleanup.sql")))
+
+	plan, err := migrator.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("failed to build plan: %v", err)
+	}
+	if !plan.Destructive() || len(plan.Operations(OperationDestructive)) != 1 {
+		t.Errorf("expected the streamed DROP TABLE to be classified as destructive, got %+v", plan.Migrations)
+	}
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrProtectedEnvironment) {
+		t.Fatalf("expected ErrProtectedEnvironment, got %v", err)
+	}
+	if n := countRows(t, db, "legacy"); n != 0 {
+		t.Errorf("expected legacy table to survive, got %d rows", n)
+	}
+
+	if _, err := migrator.Up(AllowDestructive()); err != nil {
+		t.Fatalf("expected AllowDestructive to run the stream, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "1")
+}
+
+func TestMigrator_StreamMigrationReadError(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	readErr := errors.New("disk went away")
+	migrator := New(db)
+	migrator.Register(StreamMigration("1", "broken", func() (io.ReadCloser, error) {
+		return io.NopCloser(io.MultiReader(bytes.NewBufferString("SELECT 1;"), &failingReader{err: readErr})), nil
+	}))
+
+	if _, err := migrator.Up(); !errors.Is(err, readErr) {
+		t.Errorf("expected read error, got %v", err)
+	}
+	if _, err := Checksum(migrator.migrations[0]); !errors.Is(err, readErr) {
+		t.Errorf("expected Checksum to report the read error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestStatementScanner_DollarQuotedBody(t *testing.T) {
+	t.Parallel()
+
+	scanner := newStatementScanner(strings.NewReader("CREATE FUNCTION f() RETURNS int AS $fn$ BEGIN RETURN 1; END; $fn$ LANGUAGE plpgsql; /* a; b */ SELECT 2;"))
+
+	var statements []string
+	for scanner.Next() {
+		statements = append(statements, scanner.Statement())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to scan statements: %v", err)
+	}
+
+	expected := []string{
+		"CREATE FUNCTION f() RETURNS int AS $fn$ BEGIN RETURN 1; END; $fn$ LANGUAGE plpgsql",
+		"/* a; b */ SELECT 2",
+	}
+	if len(statements) != len(expected) || statements[0] != expected[0] || statements[1] != expected[1] {
+		t.Errorf("expected statements %q, got %q", expected, statements)
+	}
+}
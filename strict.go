@@ -0,0 +1,35 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func isNoopQuery(query string) bool {
+	trimmed := strings.TrimSpace(query)
+	return trimmed == "" || strings.HasPrefix(trimmed, "--")
+}
+
+func isReversible(migration Migration) bool {
+	for _, query := range migration.Down() {
+		if !isNoopQuery(query) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkStrictDown(rollbackList []MigrationStatus, migrationMap map[string]Migration) error {
+	var errs []error
+	for _, status := range rollbackList {
+		migration, exists := migrationMap[status.ID]
+		switch {
+		case !exists:
+			errs = append(errs, fmt.Errorf("%w: %s", ErrAppliedMigrationMissing, status.ID))
+		case !isReversible(migration):
+			errs = append(errs, fmt.Errorf("%w: %s", ErrIrreversibleMigration, status.ID))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestIsReversible(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		down     []string
+		expected bool
+	}{
+		{name: "no down queries", down: nil, expected: false},
+		{name: "comments only", down: []string{"-- Cannot restore dropped table users", "  "}, expected: false},
+		{name: "executable", down: []string{"-- note", "DROP TABLE users"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isReversible(&mockMigration{downQueries: tt.down}); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMigrator_WithStrictDown(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrations := []Migration{
+		&mockMigration{
+			id:          "1",
+			description: "create users",
+			upQueries:   []string{"CREATE TABLE users (id INTEGER)"},
+			downQueries: []string{"DROP TABLE users"},
+		},
+		CreateMigration("2", "drop legacy").
+			RawUp("CREATE TABLE legacy (id INTEGER)").
+			DropTable("legacy").
+			Build(),
+	}
+
+	migrator := New(db, WithStrictDown())
+	migrator.Register(migrations...)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	_, err = migrator.Down(0)
+	if !errors.Is(err, ErrIrreversibleMigration) {
+		t.Fatalf("expected ErrIrreversibleMigration, got %v", err)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if len(status) != 2 {
+		t.Errorf("expected history to be untouched, got %d records", len(status))
+	}
+
+	orphaned := New(db, WithStrictDown())
+	if _, err := orphaned.Down(1); !errors.Is(err, ErrAppliedMigrationMissing) {
+		t.Errorf("expected ErrAppliedMigrationMissing, got %v", err)
+	}
+}
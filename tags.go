@@ -0,0 +1,80 @@
+package migrator
+
+import (
+	"slices"
+	"strings"
+)
+
+type Tagged interface {
+	Tags() []string
+}
+
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	include                 []string
+	exclude                 []string
+	allowLargeRewrites      bool
+	allowDestructive        bool
+	allowBlocking           bool
+	ignoreMaintenanceWindow bool
+	only                    map[string]bool
+	confirmed               bool
+}
+
+func WithTags(tags ...string) RunOption {
+	return func(c *runConfig) {
+		for _, tag := range tags {
+			if excluded, ok := strings.CutPrefix(tag, "!"); ok {
+				c.exclude = append(c.exclude, excluded)
+			} else {
+				c.include = append(c.include, tag)
+			}
+		}
+	}
+}
+
+func newRunConfig(opts []RunOption) runConfig {
+	var config runConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+func (c runConfig) selects(migration Migration) bool {
+	if c.only != nil && !c.only[migration.ID()] {
+		return false
+	}
+
+	var tags []string
+	if tagged, ok := migration.(Tagged); ok {
+		tags = tagged.Tags()
+	}
+
+	for _, tag := range c.include {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	for _, tag := range c.exclude {
+		if slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c runConfig) filter(migrations []Migration) []Migration {
+	if len(c.include) == 0 && len(c.exclude) == 0 && c.only == nil {
+		return migrations
+	}
+
+	var selected []Migration
+	for _, migration := range migrations {
+		if c.selects(migration) {
+			selected = append(selected, migration)
+		}
+	}
+	return selected
+}
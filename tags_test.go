@@ -0,0 +1,109 @@
+package migrator
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_UpWithTags(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		CreateMigration("001", "create users").
+			CreateTable("users", "id INTEGER PRIMARY KEY").
+			Build(),
+		CreateMigration("002", "seed users").
+			RawUp("INSERT INTO users (id) VALUES (1)").
+			Tags("dev-only").
+			Build(),
+		CreateMigration("003", "heavy index").
+			CreateIndex("idx_users_id", "users", "id").
+			Tags("heavy").
+			Build(),
+	)
+
+	if _, err := migrator.Up(WithTags("!dev-only", "!heavy")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001")
+
+	if _, err := migrator.Up(WithTags("heavy")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "003")
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	assertAppliedIDs(t, migrator, "001", "002", "003")
+}
+
+func TestRunConfig_Selects(t *testing.T) {
+	t.Parallel()
+
+	tagged := CreateMigration("1", "tagged").Tags("a", "b").Build()
+	untagged := &mockMigration{id: "2"}
+
+	tests := []struct {
+		name      string
+		tags      []string
+		migration Migration
+		expected  bool
+	}{
+		{"no filter", nil, untagged, true},
+		{"include matches", []string{"a"}, tagged, true},
+		{"include all must match", []string{"a", "c"}, tagged, false},
+		{"include skips untagged", []string{"a"}, untagged, false},
+		{"exclude matches", []string{"!b"}, tagged, false},
+		{"exclude keeps untagged", []string{"!b"}, untagged, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := newRunConfig([]RunOption{WithTags(tt.tags...)})
+			if got := config.selects(tt.migration); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func assertAppliedIDs(t *testing.T, migrator *Migrator, expected ...string) {
+	t.Helper()
+
+	history, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	var status []MigrationStatus
+	for _, s := range history {
+		if s.Status == StateApplied {
+			status = append(status, s)
+		}
+	}
+	if len(status) != len(expected) {
+		t.Fatalf("expected applied %v, got %v", expected, status)
+	}
+
+	applied := make(map[string]bool, len(status))
+	for _, s := range status {
+		applied[s.ID] = true
+	}
+	for _, id := range expected {
+		if !applied[id] {
+			t.Errorf("expected %s to be applied, got %v", id, status)
+		}
+	}
+}
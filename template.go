@@ -0,0 +1,38 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+func (r *Migrator) renderQueries(migration Migration, queries []string) ([]string, error) {
+	if r.templateData == nil {
+		return queries, nil
+	}
+
+	rendered := make([]string, len(queries))
+	for i, query := range queries {
+		tmpl, err := template.New(migration.ID()).Option("missingkey=error").Parse(query)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrFailedToRenderTemplate, migration.ID(), err)
+		}
+
+		var b strings.Builder
+		if err := tmpl.Execute(&b, r.templateData); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrFailedToRenderTemplate, migration.ID(), err)
+		}
+		rendered[i] = b.String()
+	}
+	return rendered, nil
+}
+
+func (r *Migrator) Render(migration Migration) (up, down []string, err error) {
+	if up, err = r.upQueries(migration); err != nil {
+		return nil, nil, err
+	}
+	if down, err = r.downQueries(migration); err != nil {
+		return nil, nil, err
+	}
+	return up, down, nil
+}
@@ -0,0 +1,82 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_WithTemplateData(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migration := &mockMigration{
+		id:          "1",
+		description: "templated",
+		upQueries:   []string{"CREATE TABLE {{ .Env }}_users (id INTEGER)"},
+		downQueries: []string{"DROP TABLE {{ .Env }}_users"},
+	}
+
+	migrator := New(db, WithTemplateData(map[string]any{"Env": "staging"}))
+	migrator.Register(migration)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM staging_users").Scan(&count); err != nil {
+		t.Fatalf("expected rendered table to exist: %v", err)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status[0].Checksum == mustChecksum(t, migration) {
+		t.Error("expected checksum to be computed over the rendered queries")
+	}
+	if err := migrator.Verify(context.Background()); err != nil {
+		t.Errorf("expected history to verify with the same data, got %v", err)
+	}
+
+	other := New(db, WithTemplateData(map[string]any{"Env": "production"}))
+	other.Register(migration)
+	if err := other.Verify(context.Background()); !errors.Is(err, ErrAppliedMigrationModified) {
+		t.Errorf("expected different data to be detected as a modification, got %v", err)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMigrator_WithTemplateData_MissingKey(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	migrator := New(db, WithTemplateData(map[string]any{}))
+	migrator.Register(&mockMigration{
+		id:        "1",
+		upQueries: []string{"CREATE TABLE {{ .Schema }}.users (id INTEGER)"},
+	})
+
+	if _, err := migrator.Up(); !errors.Is(err, ErrFailedToRenderTemplate) {
+		t.Errorf("expected ErrFailedToRenderTemplate, got %v", err)
+	}
+}
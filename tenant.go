@@ -0,0 +1,134 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type TenantLister func(ctx context.Context) ([]string, error)
+
+type TenantResult struct {
+	Tenant string
+	Result *Result
+	Err    error
+}
+
+type TenantRunner struct {
+	db           *sql.DB
+	tenants      TenantLister
+	opts         []Option
+	dialect      Dialect
+	migrations   []Migration
+	switchTenant func(ctx context.Context, conn *sql.Conn, tenant string) (func(context.Context) error, error)
+}
+
+func NewTenantRunner(db *sql.DB, tenants TenantLister, opts ...Option) *TenantRunner {
+	probe := &Migrator{}
+	for _, opt := range opts {
+		opt(probe)
+	}
+
+	t := &TenantRunner{
+		db:      db,
+		tenants: tenants,
+		opts:    opts,
+		dialect: probe.dialect,
+	}
+	t.switchTenant = t.useSchema
+	return t
+}
+
+func (t *TenantRunner) Register(migration ...Migration) {
+	t.migrations = append(t.migrations, migration...)
+}
+
+func (t *TenantRunner) Up(ctx context.Context, opts ...RunOption) ([]TenantResult, error) {
+	return t.each(ctx, func(m *Migrator) (*Result, error) {
+		return m.Up(opts...)
+	})
+}
+
+func (t *TenantRunner) Down(ctx context.Context, steps int, opts ...RunOption) ([]TenantResult, error) {
+	return t.each(ctx, func(m *Migrator) (*Result, error) {
+		return m.Down(steps, opts...)
+	})
+}
+
+func (t *TenantRunner) each(ctx context.Context, fn func(*Migrator) (*Result, error)) ([]TenantResult, error) {
+	tenants, err := t.tenants(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListTenants, err)
+	}
+
+	results := make([]TenantResult, 0, len(tenants))
+	for _, tenant := range tenants {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result, err := t.runTenant(ctx, tenant, fn)
+		results = append(results, TenantResult{
+			Tenant: tenant,
+			Result: result,
+			Err:    err,
+		})
+	}
+
+	return results, nil
+}
+
+func (t *TenantRunner) runTenant(ctx context.Context, tenant string, fn func(*Migrator) (*Result, error)) (result *Result, err error) {
+	conn, err := t.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	restore, err := t.switchTenant(ctx, conn, tenant)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSwitchTenant, err)
+	}
+	defer func() {
+		if restoreErr := restore(ctx); restoreErr != nil {
+			err = errors.Join(err, restoreErr)
+		}
+	}()
+
+	m := New(conn, t.opts...)
+	m.Register(t.migrations...)
+	return fn(m)
+}
+
+func (t *TenantRunner) useSchema(ctx context.Context, conn *sql.Conn, tenant string) (func(context.Context) error, error) {
+	currentSQL, err := t.dialect.currentSchemaSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	var previous sql.NullString
+	if err := conn.QueryRowContext(ctx, currentSQL).Scan(&previous); err != nil {
+		return nil, err
+	}
+
+	useSQL, err := t.dialect.useSchemaSQL(tenant)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, useSQL); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		if !previous.Valid {
+			return nil
+		}
+		restoreSQL, err := t.dialect.restoreSchemaSQL(previous.String)
+		if err != nil {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, restoreSQL)
+		return err
+	}, nil
+}
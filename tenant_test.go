@@ -0,0 +1,140 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestTenantRunner_Up_Success(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "tenants.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	runner := NewTenantRunner(db, func(context.Context) ([]string, error) {
+		return []string{"acme", "globex"}, nil
+	})
+
+	var switched, restored []string
+	runner.switchTenant = func(_ context.Context, _ *sql.Conn, tenant string) (func(context.Context) error, error) {
+		switched = append(switched, tenant)
+		return func(context.Context) error {
+			restored = append(restored, tenant)
+			return nil
+		}, nil
+	}
+	runner.Register(&mockMigration{
+		id:          "1",
+		description: "create users table",
+		upQueries:   []string{"CREATE TABLE users (id INTEGER PRIMARY KEY)"},
+	})
+
+	results, err := runner.Up(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 tenant results, got %d", len(results))
+	}
+	for i, tenant := range []string{"acme", "globex"} {
+		if results[i].Tenant != tenant {
+			t.Errorf("expected tenant %s at position %d, got %s", tenant, i, results[i].Tenant)
+		}
+		if results[i].Err != nil {
+			t.Errorf("expected no error for tenant %s, got %v", tenant, results[i].Err)
+		}
+		if results[i].Result == nil || results[i].Result.Direction != DirectionUp {
+			t.Errorf("expected an up result for tenant %s, got %+v", tenant, results[i].Result)
+		}
+	}
+
+	if len(switched) != 2 || len(restored) != 2 {
+		t.Errorf("expected every tenant to be switched and restored, got %v and %v", switched, restored)
+	}
+}
+
+func TestTenantRunner_Up_ListError(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	listErr := errors.New("catalog unavailable")
+	runner := NewTenantRunner(db, func(context.Context) ([]string, error) {
+		return nil, listErr
+	})
+
+	_, err = runner.Up(context.Background())
+	if !errors.Is(err, ErrFailedToListTenants) || !errors.Is(err, listErr) {
+		t.Errorf("expected ErrFailedToListTenants, got %v", err)
+	}
+}
+
+func TestTenantRunner_Up_UnsupportedDialect(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	runner := NewTenantRunner(db, func(context.Context) ([]string, error) {
+		return []string{"acme"}, nil
+	}, WithDialect(DialectSQLite))
+
+	results, err := runner.Up(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 tenant result, got %d", len(results))
+	}
+	if !errors.Is(results[0].Err, ErrFailedToSwitchTenant) || !errors.Is(results[0].Err, ErrUnsupportedDialect) {
+		t.Errorf("expected unsupported dialect error, got %v", results[0].Err)
+	}
+}
+
+func TestTenantRunner_Up_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	runner := NewTenantRunner(db, func(context.Context) ([]string, error) {
+		return []string{"acme"}, nil
+	})
+
+	results, err := runner.Up(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no tenant results, got %d", len(results))
+	}
+}
@@ -0,0 +1,104 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	defaultThrottleInterval = time.Second
+	defaultMaxChunks        = 100000
+)
+
+var chunkLimitPattern = regexp.MustCompile(`(?i)\bLIMIT\b`)
+
+type LagFunc func(ctx context.Context) (time.Duration, error)
+
+type chunkedLister interface {
+	chunkedQueries() []int
+}
+
+func ReplicaLagQuery(replica DBTX, query string) LagFunc {
+	return func(ctx context.Context) (time.Duration, error) {
+		rows, err := replica.QueryContext(ctx, query)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		var seconds *float64
+		if rows.Next() {
+			if err := rows.Scan(&seconds); err != nil {
+				return 0, err
+			}
+		}
+		if err := rows.Err(); err != nil || seconds == nil {
+			return 0, err
+		}
+		return time.Duration(*seconds * float64(time.Second)), nil
+	}
+}
+
+func chunkedQueries(migration Migration) map[int]bool {
+	lister, ok := migration.(chunkedLister)
+	if !ok {
+		return nil
+	}
+
+	chunked := make(map[int]bool)
+	for _, i := range lister.chunkedQueries() {
+		chunked[i] = true
+	}
+	return chunked
+}
+
+func (r *Migrator) execChunked(ctx context.Context, tx Executor, batch int, migrationID, query string) error {
+	if !chunkLimitPattern.MatchString(query) {
+		return fmt.Errorf("%w: %s", ErrUnboundedChunk, firstLine(query))
+	}
+
+	for range r.maxChunks {
+		res, err := r.execStatement(ctx, tx, DirectionUp, batch, migrationID, query)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		if err := r.waitForReplicas(ctx, batch, migrationID); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: %d chunks of %s", ErrChunkLimitExceeded, r.maxChunks, firstLine(query))
+}
+
+func (r *Migrator) waitForReplicas(ctx context.Context, batch int, migrationID string) error {
+	if r.lag == nil {
+		return nil
+	}
+
+	for {
+		lag, err := r.lag(ctx)
+		if err != nil {
+			return err
+		}
+		if lag <= r.maxLag {
+			return nil
+		}
+
+		r.emit(Event{Kind: EventThrottled, Direction: DirectionUp, Batch: batch, MigrationID: migrationID, Duration: lag})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.throttleInterval):
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMigrator_ChunkedWithThrottle(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, done INTEGER NOT NULL DEFAULT 0)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := db.Exec("INSERT INTO items (done) VALUES (0)"); err != nil {
+			t.Fatalf("failed to insert: %v", err)
+		}
+	}
+
+	lagChecks := 0
+	lag := func(context.Context) (time.Duration, error) {
+		lagChecks++
+		if lagChecks == 1 {
+			return time.Minute, nil
+		}
+		return 0, nil
+	}
+
+	migrator := New(db, WithThrottle(lag, time.Second, time.Millisecond))
+	events := migrator.Events()
+	migrator.Register(CreateMigration("1", "backfill items").
+		Chunked("UPDATE items SET done = 1 WHERE id IN (SELECT id FROM items WHERE done = 0 LIMIT 3)").
+		Build())
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var remaining int
+	if err := db.QueryRow("SELECT COUNT(*) FROM items WHERE done = 0").Scan(&remaining); err != nil {
+		t.Fatalf("failed to count: %v", err)
+	}
+	if remaining != 0 {
+		t.Errorf("expected all rows to be backfilled, got %d remaining", remaining)
+	}
+
+	if lagChecks != 5 {
+		t.Errorf("expected 5 lag checks, got %d", lagChecks)
+	}
+
+	throttled := 0
+	for len(events) > 0 {
+		if event := <-events; event.Kind == EventThrottled {
+			throttled++
+		}
+	}
+	if throttled != 1 {
+		t.Errorf("expected 1 throttled event, got %d", throttled)
+	}
+}
+
+func TestMigrator_ThrottleLagError(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	lagErr := errors.New("replica unreachable")
+	migrator := New(db, WithThrottle(func(context.Context) (time.Duration, error) {
+		return 0, lagErr
+	}, time.Second, 0))
+	migrator.Register(CreateMigration("1", "seed").
+		RawUp("CREATE TABLE items (id INTEGER PRIMARY KEY, done INTEGER)").
+		RawUp("INSERT INTO items (done) VALUES (0)").
+		Chunked("UPDATE items SET done = 1 WHERE id IN (SELECT id FROM items WHERE done = 0 LIMIT 1)").
+		Build())
+
+	if _, err := migrator.Up(); !errors.Is(err, lagErr) {
+		t.Errorf("expected lag error, got %v", err)
+	}
+}
+
+func TestReplicaLagQuery(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	lag, err := ReplicaLagQuery(db, "SELECT 1.5")(context.Background())
+	if err != nil || lag != 1500*time.Millisecond {
+		t.Errorf("expected 1.5s lag, got %v (%v)", lag, err)
+	}
+
+	lag, err = ReplicaLagQuery(db, "SELECT NULL")(context.Background())
+	if err != nil || lag != 0 {
+		t.Errorf("expected zero lag for NULL, got %v (%v)", lag, err)
+	}
+}
+
+func TestMigrator_ChunkedBounds(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, done INTEGER); INSERT INTO items (done) VALUES (0)"); err != nil {
+		t.Fatalf("failed to seed items: %v", err)
+	}
+
+	unbounded := New(db)
+	unbounded.Register(CreateMigration("1", "unbounded").Chunked("UPDATE items SET done = 1").Build())
+	if _, err := unbounded.Up(); !errors.Is(err, ErrUnboundedChunk) {
+		t.Errorf("expected ErrUnboundedChunk, got %v", err)
+	}
+
+	endless := New(db, WithMaxChunks(5))
+	endless.Register(CreateMigration("2", "endless").
+		Chunked("UPDATE items SET done = done + 1 WHERE id IN (SELECT id FROM items LIMIT 1)").
+		Build())
+	if _, err := endless.Up(); !errors.Is(err, ErrChunkLimitExceeded) {
+		t.Errorf("expected ErrChunkLimitExceeded, got %v", err)
+	}
+
+	var done int
+	if err := db.QueryRow("SELECT done FROM items").Scan(&done); err != nil {
+		t.Fatalf("failed to read items: %v", err)
+	}
+	if done != 5 {
+		t.Errorf("expected exactly 5 chunks to run, got %d", done)
+	}
+}
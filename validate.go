@@ -0,0 +1,256 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type SQLValidator func(dialect Dialect, statement string) error
+
+type ValidationIssue struct {
+	ID        string
+	Direction Direction
+	Statement string
+	Err       error
+}
+
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		details[i] = fmt.Sprintf("%s (%s): %v: %q", issue.ID, issue.Direction, issue.Err, firstLine(issue.Statement))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidSQL, strings.Join(details, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidSQL
+}
+
+var statementKeywords = map[string]bool{
+	"ALTER": true, "ANALYZE": true, "CALL": true, "COMMENT": true, "CREATE": true, "DELETE": true,
+	"DROP": true, "EXPLAIN": true, "GRANT": true, "INSERT": true, "LOCK": true, "REVOKE": true,
+	"SELECT": true, "SET": true, "TRUNCATE": true, "UPDATE": true, "VALUES": true, "WITH": true,
+}
+
+var dialectStatementKeywords = map[Dialect][]string{
+	DialectPostgres: {"CLUSTER", "COPY", "DO", "LISTEN", "NOTIFY", "REFRESH", "REINDEX", "RESET", "SECURITY", "VACUUM"},
+	DialectMySQL:    {"OPTIMIZE", "RENAME", "REPLACE", "SHOW"},
+	DialectSQLite:   {"PRAGMA", "REINDEX", "REPLACE", "VACUUM"},
+}
+
+var objectKeywords = map[string]bool{
+	"AGGREGATE": true, "CAST": true, "COLLATION": true, "COLUMN": true, "CONSTRAINT": true, "CONVERSION": true,
+	"DATABASE": true, "DEFINER": true, "DOMAIN": true, "EVENT": true, "EXTENSION": true, "FOREIGN": true,
+	"FULLTEXT": true, "FUNCTION": true, "GLOBAL": true, "INDEX": true, "LANGUAGE": true, "LOCAL": true,
+	"MATERIALIZED": true, "OPERATOR": true, "OR": true, "POLICY": true, "PROCEDURE": true, "PUBLICATION": true,
+	"RECURSIVE": true, "ROLE": true, "RULE": true, "SCHEMA": true, "SEQUENCE": true, "SERVER": true,
+	"SPATIAL": true, "STATISTICS": true, "SUBSCRIPTION": true, "TABLE": true, "TABLESPACE": true,
+	"TEMP": true, "TEMPORARY": true, "TEXT": true, "TRIGGER": true, "TYPE": true, "UNIQUE": true,
+	"UNLOGGED": true, "USER": true, "VIEW": true, "VIRTUAL": true, "ALGORITHM": true, "SQL": true,
+	"DEFAULT": true, "ACCESS": true, "OWNED": true, "SYSTEM": true, "IGNORE": true, "ONLINE": true,
+}
+
+func (r *Migrator) Validate(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	issues, err := r.validateMigrations(r.pendingMigrations(applied))
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+func (r *Migrator) validateMigrations(migrations []Migration) ([]ValidationIssue, error) {
+	validator := r.sqlValidator
+	if validator == nil {
+		validator = ValidateSyntax
+	}
+
+	var issues []ValidationIssue
+	for _, migration := range migrations {
+		if _, ok := migration.(Streaming); ok {
+			continue
+		}
+		if _, ok := migration.(*copyMigration); ok {
+			continue
+		}
+
+		up, err := r.upQueries(migration)
+		if err != nil {
+			return nil, err
+		}
+		down, err := r.downQueries(migration)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pass := range []struct {
+			direction Direction
+			queries   []string
+		}{{DirectionUp, up}, {DirectionDown, down}} {
+			for _, query := range pass.queries {
+				if err := validator(r.dialect, query); err != nil {
+					issues = append(issues, ValidationIssue{ID: migration.ID(), Direction: pass.direction, Statement: query, Err: err})
+				}
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+func ValidateSyntax(dialect Dialect, query string) error {
+	statements, err := splitSQL(dialect, query)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := validateStatement(dialect, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateStatement(dialect Dialect, statement string) error {
+	tokens := tokenizeSQL(statement)
+	if len(tokens) == 0 || strings.HasPrefix(tokens[0], "(") {
+		return nil
+	}
+
+	keyword := strings.ToUpper(tokens[0])
+	if !isStatementKeyword(dialect, keyword) {
+		return fmt.Errorf("unknown statement %q", tokens[0])
+	}
+
+	if keyword == "CREATE" || keyword == "ALTER" || keyword == "DROP" {
+		if len(tokens) < 2 {
+			return fmt.Errorf("incomplete %s statement", keyword)
+		}
+		if !objectKeywords[strings.ToUpper(tokens[1])] {
+			return fmt.Errorf("unknown object type %q after %s", tokens[1], keyword)
+		}
+	}
+	return nil
+}
+
+func isStatementKeyword(dialect Dialect, keyword string) bool {
+	if statementKeywords[keyword] {
+		return true
+	}
+	for d, keywords := range dialectStatementKeywords {
+		if dialect != DialectGeneric && d != dialect {
+			continue
+		}
+		for _, k := range keywords {
+			if k == keyword {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitSQL(dialect Dialect, query string) ([]string, error) {
+	var statements []string
+	depth, start := 0, 0
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				i = len(query)
+				continue
+			}
+			i += end
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("unterminated block comment")
+			}
+			i += end + 3
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := closingQuote(query, i, ch, dialect)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated %s", quoteKind(ch))
+			}
+			i = end
+		case ch == '$' && dialect != DialectMySQL && dialect != DialectSQLite:
+			if tag := dollarTag(query[i:]); tag != "" {
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					return nil, errors.New("unterminated dollar-quoted string")
+				}
+				i += len(tag) + end + len(tag) - 1
+			}
+		case ch == '(':
+			depth++
+		case ch == ')':
+			if depth--; depth < 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+		case ch == ';':
+			if depth != 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+			statements = append(statements, query[start:i])
+			start = i + 1
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses")
+	}
+	return append(statements, query[start:]), nil
+}
+
+func closingQuote(statement string, start int, quote byte, dialect Dialect) int {
+	for i := start + 1; i < len(statement); i++ {
+		switch {
+		case statement[i] == '\\' && quote == '\'' && dialect == DialectMySQL:
+			i++
+		case statement[i] == quote:
+			if i+1 < len(statement) && statement[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func quoteKind(quote byte) string {
+	if quote == '\'' {
+		return "string literal"
+	}
+	return "quoted identifier"
+}
+
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '$':
+			return s[:i+1]
+		case ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (i > 1 && ch >= '0' && ch <= '9'):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
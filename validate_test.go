@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateSyntax(t *testing.T) {
+	t.Parallel()
+
+	valid := []struct {
+		dialect Dialect
+		query   string
+	}{
+		{DialectGeneric, "CREATE TABLE users (id INTEGER, name TEXT DEFAULT 'it''s (fine')"},
+		{DialectGeneric, "-- comment only"},
+		{DialectGeneric, "CREATE INDEX idx ON users (id); /* ) */ INSERT INTO users (id) VALUES (1);"},
+		{DialectPostgres, "CREATE FUNCTION f() RETURNS int AS $body$ SELECT (1; $body$ LANGUAGE sql"},
+		{DialectPostgres, "UPDATE users SET id = $1"},
+		{DialectMySQL, `INSERT INTO users (name) VALUES ('a\'b')`},
+		{DialectMySQL, "CREATE DEFINER = CURRENT_USER TRIGGER t BEFORE INSERT ON users FOR EACH ROW SET NEW.id = 1"},
+		{DialectSQLite, "PRAGMA foreign_keys = ON"},
+	}
+	for _, tt := range valid {
+		if err := ValidateSyntax(tt.dialect, tt.query); err != nil {
+			t.Errorf("expected %q to be valid, got %v", tt.query, err)
+		}
+	}
+
+	invalid := []struct {
+		dialect Dialect
+		query   string
+		message string
+	}{
+		{DialectGeneric, "CRAETE TABLE users (id INTEGER)", `unknown statement "CRAETE"`},
+		{DialectGeneric, "CREATE TABEL users (id INTEGER)", `unknown object type "TABEL"`},
+		{DialectGeneric, "CREATE TABLE users (id INTEGER", "unbalanced parentheses"},
+		{DialectGeneric, "INSERT INTO users VALUES ('a)", "unterminated string literal"},
+		{DialectGeneric, "SELECT 1 /* open", "unterminated block comment"},
+		{DialectPostgres, "DO $$ BEGIN", "unterminated dollar-quoted string"},
+		{DialectPostgres, "PRAGMA foreign_keys = ON", `unknown statement "PRAGMA"`},
+		{DialectGeneric, "SELECT 1; DROP", "incomplete DROP statement"},
+	}
+	for _, tt := range invalid {
+		err := ValidateSyntax(tt.dialect, tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("expected %q to fail with %q, got %v", tt.query, tt.message, err)
+		}
+	}
+}
+
+func TestMigrator_Validate(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	migrator := New(db)
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}, downQueries: []string{"DROP TABLE users"}},
+		&mockMigration{id: "2", upQueries: []string{"ALTER TABLE users ADD COLUMN email TEXT"}, downQueries: []string{"ALTER TABEL users DROP COLUMN email"}},
+	)
+
+	err = migrator.Validate(context.Background())
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, ErrInvalidSQL) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Issues) != 1 || validationErr.Issues[0].ID != "2" || validationErr.Issues[0].Direction != DirectionDown {
+		t.Errorf("expected one issue in down of 2, got %+v", validationErr.Issues)
+	}
+
+	plan, err := migrator.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("expected plan, got %v", err)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "2 has invalid down SQL") {
+		t.Errorf("expected validation warning in plan, got %v", plan.Warnings)
+	}
+
+	custom := New(db, WithSQLValidator(func(Dialect, string) error { return nil }))
+	custom.Register(&mockMigration{id: "1", upQueries: []string{"NOT SQL AT ALL"}})
+	if err := custom.Validate(context.Background()); err != nil {
+		t.Errorf("expected custom validator to be used, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func Checksum(migration Migration) (string, error) {
+	return computeChecksum(migration, nil)
+}
+
+func (r *Migrator) checksum(migration Migration) (string, error) {
+	return computeChecksum(migration, r.renderQueries)
+}
+
+func computeChecksum(migration Migration, render func(Migration, []string) ([]string, error)) (string, error) {
+	if streaming, ok := migration.(Streaming); ok {
+		return streamChecksum(streaming)
+	}
+	if copying, ok := migration.(*copyMigration); ok {
+		return copying.checksum()
+	}
+
+	queries := migration.Up()
+	if render != nil {
+		var err error
+		if queries, err = render(migration, queries); err != nil {
+			return "", err
+		}
+	}
+	return checksumQueries(queries), nil
+}
+
+func checksumQueries(queries []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(queries, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *Migrator) Verify(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.getAppliedMigrations(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToGetAppliedMigrations, err)
+	}
+
+	return r.verifyHistory(applied)
+}
+
+func (r *Migrator) VerifyHistory(history []MigrationStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.verifyHistory(history)
+}
+
+func (r *Migrator) verifyHistory(history []MigrationStatus) error {
+	migrationMap := r.buildMigrationMap(r.activeMigrations())
+
+	var errs []error
+	for _, applied := range history {
+		migration, exists := migrationMap[applied.ID]
+		if !exists {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrAppliedMigrationMissing, applied.ID))
+			continue
+		}
+
+		if applied.Checksum == "" || isRepeatable(migration) {
+			continue
+		}
+
+		checksum, err := r.checksum(migration)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if applied.Checksum != checksum {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrAppliedMigrationModified, applied.ID))
+		}
+	}
+
+	return errors.Join(errs...)
+}
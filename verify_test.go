@@ -0,0 +1,94 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	first := &mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id INTEGER)"}}
+	same := &mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id INTEGER)"}}
+	changed := &mockMigration{id: "1", upQueries: []string{"CREATE TABLE a (id BIGINT)"}}
+
+	if mustChecksum(t, first) != mustChecksum(t, same) {
+		t.Error("expected identical migrations to have the same checksum")
+	}
+	if mustChecksum(t, first) == mustChecksum(t, changed) {
+		t.Error("expected changed migration to have a different checksum")
+	}
+	if len(mustChecksum(t, first)) != 64 {
+		t.Errorf("expected hex-encoded sha256 checksum, got %s", mustChecksum(t, first))
+	}
+}
+
+func mustChecksum(t *testing.T, migration Migration) string {
+	t.Helper()
+
+	checksum, err := Checksum(migration)
+	if err != nil {
+		t.Fatalf("failed to checksum migration %s: %v", migration.ID(), err)
+	}
+	return checksum
+}
+
+func TestMigrator_Verify(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	original := New(db)
+	original.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id INTEGER)"}},
+		&mockMigration{id: "2", description: "second", upQueries: []string{"CREATE TABLE b (id INTEGER)"}},
+	)
+	if _, err := original.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if err := original.Verify(context.Background()); err != nil {
+		t.Errorf("expected untouched registry to verify, got %v", err)
+	}
+
+	edited := New(db)
+	edited.Register(
+		&mockMigration{id: "1", description: "first", upQueries: []string{"CREATE TABLE a (id BIGINT)"}},
+	)
+
+	err = edited.Verify(context.Background())
+	if !errors.Is(err, ErrAppliedMigrationModified) {
+		t.Errorf("expected ErrAppliedMigrationModified, got %v", err)
+	}
+	if !errors.Is(err, ErrAppliedMigrationMissing) {
+		t.Errorf("expected ErrAppliedMigrationMissing, got %v", err)
+	}
+}
+
+func TestMigrator_VerifyHistory(t *testing.T) {
+	t.Parallel()
+
+	migration := &mockMigration{id: "1", upQueries: []string{"SELECT 1"}}
+	migrator := &Migrator{}
+	migrator.Register(migration)
+
+	history := []MigrationStatus{
+		{ID: "1", Checksum: mustChecksum(t, migration)},
+	}
+	if err := migrator.VerifyHistory(history); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	legacy := []MigrationStatus{{ID: "1"}}
+	if err := migrator.VerifyHistory(legacy); err != nil {
+		t.Errorf("expected legacy rows without checksum to be skipped, got %v", err)
+	}
+}
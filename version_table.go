@@ -0,0 +1,90 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const versionTableName = "schema_version"
+
+const versionTableTemplate = `
+CREATE TABLE IF NOT EXISTS %[2]s (
+    module VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    batch INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (module)
+);
+`
+
+type SchemaVersion struct {
+	Version   string
+	Batch     int
+	UpdatedAt time.Time
+}
+
+func (r *Migrator) versionQuery(format string) string {
+	return r.dialect.rebind(fmt.Sprintf(format, r.table, r.tablePrefix+versionTableName))
+}
+
+func (r *Migrator) createVersionTable(ctx context.Context) error {
+	if !r.versionTable {
+		return nil
+	}
+	_, err := r.conn.Exec(ctx, r.versionQuery(versionTableTemplate))
+	return err
+}
+
+func (r *Migrator) syncVersion(ctx context.Context, tx Executor) error {
+	if !r.versionTable {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, r.versionQuery("DELETE FROM %[2]s WHERE module = ?"), r.module()); err != nil {
+		return errors.Join(ErrFailedToUpdateSchemaVersion, err)
+	}
+	_, err := tx.Exec(ctx, r.versionQuery(`INSERT INTO %[2]s (module, version, batch)
+SELECT module, id, batch FROM %[1]s WHERE module = ? AND status = ? ORDER BY batch DESC, id DESC LIMIT 1`),
+		r.module(), string(StateApplied))
+	if err != nil {
+		return errors.Join(ErrFailedToUpdateSchemaVersion, err)
+	}
+	return nil
+}
+
+func (r *Migrator) SchemaVersion(ctx context.Context) (*SchemaVersion, error) {
+	if !r.versionTable {
+		return nil, ErrVersionTableDisabled
+	}
+
+	view := r.readView("")
+	if err := view.createMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := view.conn.Query(ctx, view.versionQuery("SELECT version, batch, updated_at FROM %[2]s WHERE module = ?"), view.module())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return &SchemaVersion{}, nil
+	}
+
+	var version SchemaVersion
+	var updatedAt sql.NullTime
+	if err := rows.Scan(&version.Version, &version.Batch, &updatedAt); err != nil {
+		return nil, err
+	}
+	version.UpdatedAt = updatedAt.Time
+	return &version, rows.Err()
+}
@@ -0,0 +1,54 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestMigrator_WithVersionTable(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	migrator := New(db, WithVersionTable())
+	migrator.Register(
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").RawDown("DROP TABLE users").Build(),
+		CreateMigration("002", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").RawDown("ALTER TABLE users DROP COLUMN email").Build(),
+	)
+
+	if version, err := migrator.SchemaVersion(ctx); err != nil || version.Version != "" {
+		t.Fatalf("expected empty version before migrating, got %+v (%v)", version, err)
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	version, err := migrator.SchemaVersion(ctx)
+	if err != nil || version.Version != "002" || version.Batch != 1 {
+		t.Fatalf("expected version 002 in batch 1, got %+v (%v)", version, err)
+	}
+	if count := countRows(t, db, "schema_version"); count != 1 {
+		t.Errorf("expected a single version row, got %d", count)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if version, err := migrator.SchemaVersion(ctx); err != nil || version.Version != "001" {
+		t.Errorf("expected version 001 after rollback, got %+v (%v)", version, err)
+	}
+
+	if _, err := New(db).SchemaVersion(ctx); !errors.Is(err, ErrVersionTableDisabled) {
+		t.Errorf("expected ErrVersionTableDisabled, got %v", err)
+	}
+}
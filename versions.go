@@ -0,0 +1,125 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type EnvironmentVersion struct {
+	Name    string
+	Version string
+	Applied []string
+	Pending []string
+	Err     error
+}
+
+type VersionDiff struct {
+	ID        string
+	AppliedIn []string
+	MissingIn []string
+}
+
+type VersionReport struct {
+	Environments []EnvironmentVersion
+	Diffs        []VersionDiff
+}
+
+func (r *VersionReport) Consistent() bool {
+	return len(r.Diffs) == 0 && len(r.ByVersion()) <= 1
+}
+
+func (r *VersionReport) ByVersion() map[string][]string {
+	versions := make(map[string][]string)
+	for _, env := range r.Environments {
+		if env.Err != nil {
+			continue
+		}
+		versions[env.Version] = append(versions[env.Version], env.Name)
+	}
+	return versions
+}
+
+func (r *VersionReport) Err() error {
+	var errs []error
+	for _, env := range r.Environments {
+		if env.Err != nil {
+			errs = append(errs, fmt.Errorf("environment %s: %w", env.Name, env.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Migrator) CompareVersions(ctx context.Context, dbs map[string]*sql.DB) (*VersionReport, error) {
+	names := make([]string, 0, len(dbs))
+	for name := range dbs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	report := &VersionReport{}
+	for _, name := range names {
+		report.Environments = append(report.Environments, r.environmentVersion(ctx, name, dbs[name]))
+	}
+	report.Diffs = r.diffEnvironments(report.Environments)
+
+	return report, report.Err()
+}
+
+func (r *Migrator) environmentVersion(ctx context.Context, name string, db *sql.DB) EnvironmentVersion {
+	env := EnvironmentVersion{Name: name}
+
+	view := r.readView("")
+	view.conn = stdConn{db: db}
+
+	applied, err := view.getAppliedMigrations(ctx)
+	if err != nil {
+		env.Err = errors.Join(ErrFailedToGetAppliedMigrations, err)
+		return env
+	}
+
+	env.Version = currentVersion(applied)
+	for _, migration := range applied {
+		env.Applied = append(env.Applied, migration.ID)
+	}
+	for _, migration := range view.pendingMigrations(applied) {
+		env.Pending = append(env.Pending, migration.ID())
+	}
+	return env
+}
+
+func (r *Migrator) diffEnvironments(environments []EnvironmentVersion) []VersionDiff {
+	var reachable []EnvironmentVersion
+	appliedIn := make(map[string][]string)
+	var ids []string
+	for _, env := range environments {
+		if env.Err != nil {
+			continue
+		}
+		reachable = append(reachable, env)
+		for _, id := range env.Applied {
+			if _, ok := appliedIn[id]; !ok {
+				ids = append(ids, id)
+			}
+			appliedIn[id] = append(appliedIn[id], env.Name)
+		}
+	}
+	slices.SortFunc(ids, r.compareIDs)
+
+	var diffs []VersionDiff
+	for _, id := range ids {
+		if len(appliedIn[id]) == len(reachable) {
+			continue
+		}
+		diff := VersionDiff{ID: id, AppliedIn: appliedIn[id]}
+		for _, env := range reachable {
+			if !slices.Contains(appliedIn[id], env.Name) {
+				diff.MissingIn = append(diff.MissingIn, env.Name)
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
@@ -0,0 +1,75 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestMigrator_CompareVersions(t *testing.T) {
+	t.Parallel()
+
+	open := func() *sql.DB {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("failed to open sqlite database: %v", err)
+		}
+		db.SetMaxOpenConns(1)
+		return db
+	}
+	production, staging := open(), open()
+	defer func() {
+		_ = production.Close()
+		_ = staging.Close()
+	}()
+
+	migrations := []Migration{
+		CreateMigration("001", "create users").RawUp("CREATE TABLE users (id INTEGER)").Build(),
+		CreateMigration("002", "add email").RawUp("ALTER TABLE users ADD COLUMN email TEXT").Build(),
+	}
+
+	old := New(production)
+	old.Register(migrations[0])
+	if _, err := old.Up(); err != nil {
+		t.Fatalf("failed to migrate production: %v", err)
+	}
+
+	migrator := New(staging)
+	migrator.Register(migrations...)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to migrate staging: %v", err)
+	}
+
+	report, err := migrator.CompareVersions(context.Background(), map[string]*sql.DB{
+		"production": production,
+		"staging":    staging,
+	})
+	if err != nil {
+		t.Fatalf("failed to compare versions: %v", err)
+	}
+
+	if report.Consistent() {
+		t.Error("expected environments to differ")
+	}
+	if len(report.Environments) != 2 || report.Environments[0].Version != "001" || report.Environments[1].Version != "002" {
+		t.Fatalf("unexpected environments: %+v", report.Environments)
+	}
+	if pending := report.Environments[0].Pending; len(pending) != 1 || pending[0] != "002" {
+		t.Errorf("expected 002 to be pending in production, got %v", pending)
+	}
+	if len(report.Diffs) != 1 || report.Diffs[0].ID != "002" || report.Diffs[0].MissingIn[0] != "production" || report.Diffs[0].AppliedIn[0] != "staging" {
+		t.Errorf("unexpected diffs: %+v", report.Diffs)
+	}
+
+	old.Register(migrations[1])
+	if _, err := old.Up(); err != nil {
+		t.Fatalf("failed to migrate production: %v", err)
+	}
+	report, err = migrator.CompareVersions(context.Background(), map[string]*sql.DB{
+		"production": production,
+		"staging":    staging,
+	})
+	if err != nil || !report.Consistent() {
+		t.Errorf("expected environments to match, got %+v (%v)", report, err)
+	}
+}
@@ -0,0 +1,25 @@
+package migrator
+
+import "slices"
+
+func (r *Migrator) readView(group string) *Migrator {
+	r.stateMu.RLock()
+	defer r.stateMu.RUnlock()
+
+	return &Migrator{
+		conn:         r.conn,
+		dialect:      r.dialect,
+		tablePrefix:  r.tablePrefix,
+		table:        r.table,
+		namespace:    r.namespace,
+		group:        group,
+		ordering:     r.ordering,
+		skip:         r.skip,
+		templateData: r.templateData,
+		clock:        r.clock,
+		logger:       r.logger,
+		baseline:     r.baseline,
+		versionTable: r.versionTable,
+		migrations:   slices.Clone(r.migrations),
+	}
+}
@@ -0,0 +1,68 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestMigrator_StatusDuringUp(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	type snapshot struct {
+		applied int
+		pending int
+		err     error
+	}
+	snapshots := make(chan snapshot, 1)
+
+	var migrator *Migrator
+	migrator = New(db, WithProgress(func(done, total int, current Migration) {
+		if current == nil || current.ID() != "2" {
+			return
+		}
+		go func() {
+			history, err := migrator.Status()
+			if err != nil {
+				snapshots <- snapshot{err: err}
+				return
+			}
+			pending, err := migrator.PendingCount(context.Background())
+			snapshots <- snapshot{applied: len(history), pending: pending, err: err}
+		}()
+
+		select {
+		case s := <-snapshots:
+			snapshots <- s
+		case <-time.After(5 * time.Second):
+			t.Error("status blocked while a migration was running")
+		}
+	}))
+	migrator.Register(
+		&mockMigration{id: "1", upQueries: []string{"CREATE TABLE users (id INTEGER)"}},
+		&nonTransactionalMigration{mockMigration{id: "2", upQueries: []string{"CREATE INDEX idx_users_id ON users (id)"}}},
+	)
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	select {
+	case s := <-snapshots:
+		if s.err != nil || s.applied != 1 || s.pending != 1 {
+			t.Errorf("expected status mid-run to show 1 applied and 1 pending, got %+v", s)
+		}
+	default:
+		t.Fatal("expected status to be read during the run")
+	}
+}
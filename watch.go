@@ -0,0 +1,182 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultWatchInterval = time.Second
+
+type FileChange struct {
+	Name string
+	Diff string
+}
+
+type WatchEvent struct {
+	Changes []FileChange
+	Applied []string
+	Drifted []string
+	Err     error
+}
+
+func (r *Migrator) Watch(ctx context.Context, fsys fs.FS, dir string, interval time.Duration, onEvent func(WatchEvent)) error {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	var previous map[string]string
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		current, err := snapshotDir(fsys, dir)
+		if err != nil {
+			onEvent(WatchEvent{Err: err})
+		} else if changes := diffSnapshots(previous, current); previous == nil || len(changes) > 0 {
+			event := r.applyWatched(ctx, fsys, dir)
+			if previous != nil {
+				event.Changes = changes
+			}
+			previous = current
+			onEvent(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Migrator) applyWatched(ctx context.Context, fsys fs.FS, dir string) WatchEvent {
+	var event WatchEvent
+
+	migrations, err := LoadFS(fsys, dir)
+	if err != nil {
+		event.Err = err
+		return event
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stateMu.Lock()
+	r.migrations, r.registryErr = nil, nil
+	r.register(migrations)
+	r.stateMu.Unlock()
+
+	event.Err = r.run(ctx, DirectionUp, func(ctx context.Context, result *Result) error {
+		err := r.up(ctx, runConfig{}, result)
+		event.Applied = result.IDs()
+		return err
+	})
+
+	history, err := r.getHistory(ctx)
+	if err != nil {
+		event.Err = errors.Join(event.Err, ErrFailedToGetAppliedMigrations, err)
+		return event
+	}
+	rows, err := r.statusRows(history)
+	if err != nil {
+		event.Err = errors.Join(event.Err, err)
+		return event
+	}
+	for _, row := range rows {
+		if row.State == StateDrifted {
+			event.Drifted = append(event.Drifted, row.ID)
+		}
+	}
+	return event
+}
+
+func snapshotDir(fsys fs.FS, dir string) (map[string]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), gzipSuffix)
+		if entry.IsDir() || (!strings.HasSuffix(name, upSuffix) && !strings.HasSuffix(name, downSuffix)) {
+			continue
+		}
+		content, err := readSQLFile(fsys, path.Join(dir, entry.Name()), name != entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		files[entry.Name()] = content
+	}
+	return files, nil
+}
+
+func diffSnapshots(previous, current map[string]string) []FileChange {
+	names := make(map[string]bool)
+	for name := range previous {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+
+	var changes []FileChange
+	for name := range names {
+		before, after := previous[name], current[name]
+		if before != after {
+			changes = append(changes, FileChange{Name: name, Diff: lineDiff(before, after)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func lineDiff(before, after string) string {
+	a, b := splitLines(before), splitLines(after)
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return diff.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,81 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMigrator_Watch(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	db.SetMaxOpenConns(1)
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Errorf("failed to write %s: %v", name, err)
+		}
+	}
+	write("001_users.up.sql", "CREATE TABLE users (id INTEGER);\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := make(chan WatchEvent, 10)
+	done := make(chan error, 1)
+	migrator := New(db)
+	go func() {
+		done <- migrator.Watch(ctx, os.DirFS(dir), ".", 20*time.Millisecond, func(event WatchEvent) {
+			events <- event
+		})
+	}()
+
+	first := <-events
+	if first.Err != nil || len(first.Applied) != 1 || first.Applied[0] != "001" || len(first.Changes) != 0 {
+		t.Fatalf("expected initial apply of 001, got %+v", first)
+	}
+
+	write("002_posts.up.sql", "CREATE TABLE posts (id INTEGER);\n")
+	second := <-events
+	if second.Err != nil || len(second.Applied) != 1 || second.Applied[0] != "002" {
+		t.Fatalf("expected 002 to be applied, got %+v", second)
+	}
+	if len(second.Changes) != 1 || second.Changes[0].Diff != "+ CREATE TABLE posts (id INTEGER);\n" {
+		t.Errorf("expected diff for new file, got %+v", second.Changes)
+	}
+
+	write("001_users.up.sql", "CREATE TABLE users (id INTEGER, email TEXT);\n")
+	third := <-events
+	if len(third.Applied) != 0 || len(third.Drifted) != 1 || third.Drifted[0] != "001" {
+		t.Errorf("expected edited applied migration to be reported as drifted, got %+v", third)
+	}
+	if expected := "- CREATE TABLE users (id INTEGER);\n+ CREATE TABLE users (id INTEGER, email TEXT);\n"; len(third.Changes) != 1 || third.Changes[0].Diff != expected {
+		t.Errorf("expected line diff, got %+v", third.Changes)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	got := lineDiff("a\nb\nc\n", "a\nc\nd\n")
+	if expected := "  a\n- b\n  c\n+ d\n"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}